@@ -0,0 +1,142 @@
+package fastrand64
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DrawSnapshot is a pool's draw count at a point in time, compare two to get its rate of draws,
+// or the snapshots of several pools with DrawShares to find one monopolizing them
+type DrawSnapshot struct {
+	Draws uint64 // Uint64 and Uint32 calls on the pool's generators since EnableAccounting
+	Time  time.Time
+}
+
+// Rate returns the draws per second between an earlier snapshot and this one, 0 if no time passed
+func (d DrawSnapshot) Rate(earlier DrawSnapshot) float64 {
+	seconds := d.Time.Sub(earlier.Time).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(d.Draws-earlier.Draws) / seconds
+}
+
+// DrawShares returns each pool's fraction of all the draws made between two snapshots of the same
+// pools, eg the pools of NewIsolatedPools, one per tenant. All 0 when there were no draws.
+func DrawShares(before []DrawSnapshot, after []DrawSnapshot) []float64 {
+	if len(before) != len(after) {
+		panic("DrawShares before and after must have the same length")
+	}
+	shares := make([]float64, len(after))
+	var total uint64
+	for i := range after {
+		total += after[i].Draws - before[i].Draws
+	}
+	if total == 0 {
+		return shares
+	}
+	for i := range after {
+		shares[i] = float64(after[i].Draws-before[i].Draws) / float64(total)
+	}
+	return shares
+}
+
+// EnableAccounting makes the pool count the draws on its generators, for DrawSnapshot. It costs a
+// store per draw. Idle generators are dropped, like SwapSource, so call it before the pool is
+// shared, and generators allocated after it, including after later SwapSource calls, are counted.
+// Counted generators are wrapped, which hides any methods beyond Uint64 and Uint32, so a pool's
+// Bytes no longer takes a generator's block fill
+func (s *ThreadsafePoolRNG) EnableAccounting() {
+	old := s.source.Load().(*poolSource)
+	if old.account != nil {
+		return
+	}
+	src := &poolSource{seeded: old.seeded, account: &drawAccount{live: map[*drawCounter]struct{}{}}}
+	factory := src.account.counted(func() UnsafeRNG { return old.rngPool.New().(UnsafeRNG) })
+	src.rngPool.New = func() interface{} { return factory() }
+	s.source.Store(src)
+}
+
+// DrawSnapshot returns the pool's draw count now. Pools without EnableAccounting return an error
+func (s *ThreadsafePoolRNG) DrawSnapshot() (DrawSnapshot, error) {
+	account := s.source.Load().(*poolSource).account
+	if account == nil {
+		return DrawSnapshot{}, fmt.Errorf("pool RNG has no draw accounting, see EnableAccounting")
+	}
+	return DrawSnapshot{Draws: account.total(), Time: time.Now()}, nil
+}
+
+// drawAccount is a pool's draw counts, one counter per generator so the counting goroutines never
+// contend. The count of a generator the pool drops is folded into retired when it is collected
+type drawAccount struct {
+	mu      sync.Mutex
+	live    map[*drawCounter]struct{}
+	retired uint64
+}
+
+// drawCounter is written only by the goroutine holding its generator, and read atomically
+type drawCounter struct {
+	n uint64
+}
+
+// counted wraps factory so every generator it makes is counted
+func (a *drawAccount) counted(factory func() UnsafeRNG) func() UnsafeRNG {
+	return func() UnsafeRNG {
+		c := &drawCounter{}
+		a.mu.Lock()
+		a.live[c] = struct{}{}
+		a.mu.Unlock()
+
+		r := factory()
+		if r32, ok := r.(UnsafeRNG32); ok {
+			w := &countingRNG32{countingRNG{rng: r, count: c}, r32}
+			runtime.SetFinalizer(w, func(w *countingRNG32) { a.retire(w.count) })
+			return w
+		}
+		w := &countingRNG{rng: r, count: c}
+		runtime.SetFinalizer(w, func(w *countingRNG) { a.retire(w.count) })
+		return w
+	}
+}
+
+func (a *drawAccount) retire(c *drawCounter) {
+	a.mu.Lock()
+	a.retired += atomic.LoadUint64(&c.n)
+	delete(a.live, c)
+	a.mu.Unlock()
+}
+
+func (a *drawAccount) total() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	total := a.retired
+	for c := range a.live {
+		total += atomic.LoadUint64(&c.n)
+	}
+	return total
+}
+
+// countingRNG counts the draws of the generator it wraps
+type countingRNG struct {
+	rng   UnsafeRNG
+	count *drawCounter
+}
+
+func (w *countingRNG) Uint64() uint64 {
+	atomic.StoreUint64(&w.count.n, w.count.n+1)
+	return w.rng.Uint64()
+}
+
+// countingRNG32 keeps the native Uint32 of the generator it wraps
+type countingRNG32 struct {
+	countingRNG
+	rng32 UnsafeRNG32
+}
+
+func (w *countingRNG32) Uint32() uint32 {
+	atomic.StoreUint64(&w.count.n, w.count.n+1)
+	return w.rng32.Uint32()
+}
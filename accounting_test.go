@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ThreadsafePoolRNG_EnableAccounting(t *testing.T) {
+	pools := NewIsolatedPools(2, NewSeedSeq([]byte("tenants")))
+	_, err := pools[0].DrawSnapshot()
+	assert.Error(t, err)
+
+	before := make([]DrawSnapshot, len(pools))
+	for i, pool := range pools {
+		pool.EnableAccounting()
+		pool.EnableAccounting()
+		before[i], err = pool.DrawSnapshot()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), before[i].Draws)
+	}
+
+	for i := 0; i < 30; i++ {
+		pools[0].Uint64()
+	}
+	pools[0].Bytes(80) // 10 words and the extra word for the tail
+	pools[1].Uint32n(10)
+
+	after := make([]DrawSnapshot, len(pools))
+	for i, pool := range pools {
+		after[i], _ = pool.DrawSnapshot()
+	}
+	assert.Equal(t, uint64(41), after[0].Draws)
+	assert.Equal(t, uint64(1), after[1].Draws)
+	assert.Equal(t, []float64{41.0 / 42, 1.0 / 42}, DrawShares(before, after))
+	assert.Equal(t, []float64{0, 0}, DrawShares(after, after))
+	assert.Panics(t, func() { DrawShares(before, after[:1]) })
+
+	// generators with a native Uint32 keep it, and swapped sources are still counted
+	pools[1].SwapSource(func() UnsafeRNG { return NewUnsafeXoroshiro64ssRNG(1) })
+	assert.Equal(t, NewUnsafeXoroshiro64ssRNG(1).Uint32(), pools[1].Uint32())
+	snap, _ := pools[1].DrawSnapshot()
+	assert.Equal(t, uint64(2), snap.Draws)
+
+	// a dropped generator's count is kept
+	account := pools[0].source.Load().(*poolSource).account
+	for c := range account.live {
+		account.retire(c)
+	}
+	snap, _ = pools[0].DrawSnapshot()
+	assert.Equal(t, uint64(41), snap.Draws)
+
+	assert.Equal(t, 10.0, DrawSnapshot{Draws: 30, Time: before[0].Time.Add(2 * time.Second)}.Rate(DrawSnapshot{Draws: 10, Time: before[0].Time}))
+	assert.Equal(t, 0.0, after[0].Rate(after[0]))
+}
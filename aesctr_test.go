@@ -0,0 +1,65 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeAesCtrRNG_Uint64(t *testing.T) {
+	// NIST SP 800-38A F.5.1 CTR-AES128, the output blocks are the keystream
+	var key, iv [16]byte
+	hex.Decode(key[:], []byte("2b7e151628aed2a6abf7158809cf4f3c"))
+	hex.Decode(iv[:], []byte("f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff"))
+	rng := &UnsafeAesCtrRNG{}
+	rng.SetState(key, iv)
+	assert.Equal(t, uint64(0xb07c609873df8cec), rng.Uint64())
+	assert.Equal(t, uint64(0xe4a19eea7516d2f2), rng.Uint64())
+	assert.Equal(t, uint64(0x635173673c7c2b36), rng.Uint64())
+
+	// Read continues the same keystream, across the Uint64 buffer
+	rng.SetState(key, iv)
+	rng.Uint64()
+	p := make([]byte, 1000)
+	n, err := rng.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, n)
+	assert.Equal(t, "f2d21675ea9ea1e4362b7c3c67735163", hex.EncodeToString(p[:16]))
+	other := &UnsafeAesCtrRNG{}
+	other.SetState(key, iv)
+	q := make([]byte, 1008)
+	Bytes(other, q)
+	assert.Equal(t, q[8:], p)
+
+	// a short Read leaves Uint64 unaligned with the buffer, it still continues the keystream
+	rng.SetState(key, iv)
+	rng.Uint64()
+	rng.Read(p[:3])
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, binary.LittleEndian.Uint64(q[11+8*i:]), rng.Uint64())
+	}
+
+	rng1 := NewUnsafeAesCtrRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeAesCtrRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeAesCtrRNG(1).Uint64(), NewUnsafeAesCtrRNG(2).Uint64())
+}
+
+func Test_HasAESNI(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		assert.False(t, HasAESNI())
+	}
+	if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+		cpuinfo, err := ioutil.ReadFile("/proc/cpuinfo")
+		if err == nil {
+			assert.Equal(t, regexp.MustCompile(`(?m)^flags\s*:.*\baes\b`).Match(cpuinfo), HasAESNI())
+		}
+	}
+}
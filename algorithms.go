@@ -0,0 +1,158 @@
+package fastrand64
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SpeedClass is a rough speed bucket for an algorithm's Uint64, for choosing between them
+type SpeedClass int
+
+const (
+	// SpeedFastest is about 1-2ns per Uint64 on a modern amd64
+	SpeedFastest SpeedClass = iota
+	// SpeedFast is about 2-3ns per Uint64
+	SpeedFast
+	// SpeedModerate is about 3-6ns per Uint64
+	SpeedModerate
+	// SpeedSlow is over 6ns per Uint64
+	SpeedSlow
+)
+
+func (c SpeedClass) String() string {
+	switch c {
+	case SpeedFastest:
+		return "fastest"
+	case SpeedFast:
+		return "fast"
+	case SpeedModerate:
+		return "moderate"
+	case SpeedSlow:
+		return "slow"
+	}
+	return fmt.Sprintf("SpeedClass(%d)", int(c))
+}
+
+// Algorithm describes one of the generators in this package. Version is bumped whenever the
+// algorithm's seeding or output changes, so applications storing a name and seed can tell whether
+// a recorded run still reproduces.
+type Algorithm struct {
+	Name       string
+	Aliases    []string // other names NewByName accepts, eg without symbols
+	Version    int
+	StateBits  int
+	Period     string
+	Speed      SpeedClass
+	Weaknesses string
+	New        func(seed int64) UnsafeRNG
+}
+
+var algorithms = []Algorithm{
+	{"xoshiro256**", []string{"xoshiro256ss"}, 1, 256, "2^256-1", SpeedFast,
+		"F2-linear engine, the scrambler hides it from all known tests",
+		func(seed int64) UnsafeRNG { return NewUnsafeXoshiro256ssRNG(seed) }},
+	{"xoshiro256++", []string{"xoshiro256pp"}, 1, 256, "2^256-1", SpeedFast,
+		"F2-linear engine, the scrambler hides it from all known tests",
+		func(seed int64) UnsafeRNG { return NewUnsafeXoshiro256ppRNG(seed) }},
+	{"xoshiro512**", []string{"xoshiro512ss"}, 1, 512, "2^512-1", SpeedFast,
+		"F2-linear engine, Jump is slow",
+		func(seed int64) UnsafeRNG { return NewUnsafeXoshiro512ssRNG(seed) }},
+	{"xoroshiro64**", []string{"xoroshiro64ss"}, 1, 64, "2^64-1", SpeedFast,
+		"the period is too short for large parallel simulations, a Uint64 is two steps, meant for 32 bit targets",
+		func(seed int64) UnsafeRNG { return NewUnsafeXoroshiro64ssRNG(seed) }},
+	{"xoroshiro128++", []string{"xoroshiro128pp"}, 1, 128, "2^128-1", SpeedFastest,
+		"small state, too few non overlapping streams for massively parallel use",
+		func(seed int64) UnsafeRNG { return NewUnsafeXoroshiro128ppRNG(seed) }},
+	{"shishua", nil, 1, 2304, "at least 2^71 bytes", SpeedModerate,
+		"young, but passes PractRand to 32TB, Uint64 is slower than Read which fills at tens of GB/s with AVX2",
+		func(seed int64) UnsafeRNG { return NewUnsafeShishuaRNG(seed) }},
+	{"siphash", []string{"siphash24"}, 1, 192, "2^64 per key", SpeedSlow,
+		"slower than the non cryptographic generators",
+		func(seed int64) UnsafeRNG { return NewUnsafeSipHashRNG(seed) }},
+	{"splitmix64", nil, 1, 64, "2^64", SpeedFastest,
+		"a single 2^64 stream, generators seeded close together overlap",
+		func(seed int64) UnsafeRNG { return NewUnsafeSplitmix64RNG(seed) }},
+	{"pcg32", nil, 1, 128, "2^64", SpeedModerate,
+		"32 bit output, Uint64 costs two steps",
+		func(seed int64) UnsafeRNG { return NewUnsafePcg32RNG(seed) }},
+	{"pcg32x2", nil, 1, 256, "2^64", SpeedModerate,
+		"two 2^64 period streams side by side",
+		func(seed int64) UnsafeRNG { return NewUnsafePcg32x2RNG(seed) }},
+	{"pcg64", nil, 1, 256, "2^128", SpeedFast,
+		"streams with related increments are correlated",
+		func(seed int64) UnsafeRNG { return NewUnsafePcg64RNG(seed) }},
+	{"pcg64dxsm", nil, 1, 256, "2^128", SpeedFast,
+		"none known",
+		func(seed int64) UnsafeRNG { return NewUnsafePcg64DxsmRNG(seed) }},
+	{"philox4x64", []string{"philox"}, 1, 384, "2^256 per key", SpeedModerate,
+		"none known",
+		func(seed int64) UnsafeRNG { return NewUnsafePhilox4x64RNG(seed) }},
+	{"chacha8", nil, 1, 256, "no fixed period, rekeys from its output", SpeedSlow,
+		"none known, designed to be hard to predict but not a vetted CSPRNG API",
+		func(seed int64) UnsafeRNG { return NewUnsafeChaCha8RNG(seed) }},
+	{"aes128ctr", []string{"aesctr"}, 1, 256, "2^128 blocks per key", aesSpeed(),
+		"none known, cryptographically strong, much slower without AES-NI",
+		func(seed int64) UnsafeRNG { return NewUnsafeAesCtrRNG(seed) }},
+	{"kiss64", []string{"kiss"}, 1, 256, "about 2^250", SpeedModerate,
+		"each component alone fails tests, only the sum is strong",
+		func(seed int64) UnsafeRNG { return NewUnsafeKiss64RNG(seed) }},
+	{"lehmer128", nil, 1, 128, "2^126", SpeedFastest,
+		"multiplicative, the low state bits are weak so only the high half is output",
+		func(seed int64) UnsafeRNG { return NewUnsafeLehmer128RNG(seed) }},
+	{"squares", nil, 1, 128, "2^64 per key", SpeedFast,
+		"only keys with distinct hex digits are good",
+		func(seed int64) UnsafeRNG { return NewUnsafeSquaresRNG(seed) }},
+	{"gjrand", []string{"gjrand64"}, 1, 256, "no fixed period, at least 2^64", SpeedFast,
+		"chaotic, the counter rules out short cycles",
+		func(seed int64) UnsafeRNG { return NewUnsafeGjrandRNG(seed) }},
+	{"isaac64", nil, 1, 16384 + 192, "no fixed period, at least 2^72 and on average 2^16583", SpeedModerate,
+		"no known statistical or practical attacks, but not a vetted cryptographic generator",
+		func(seed int64) UnsafeRNG { return NewUnsafeIsaac64RNG(seed) }},
+	{"jsf64", nil, 1, 256, "no fixed period, cycles average about 2^255", SpeedFast,
+		"chaotic, a few seeds may land on short cycles",
+		func(seed int64) UnsafeRNG { return NewUnsafeJsf64RNG(seed) }},
+	{"romuduojr", nil, 1, 128, "no fixed period", SpeedFastest,
+		"chaotic, only recommended for up to about 2^51 outputs",
+		func(seed int64) UnsafeRNG { return NewUnsafeRomuDuoJrRNG(seed) }},
+	{"romutrio", nil, 1, 192, "no fixed period", SpeedFastest,
+		"chaotic, a small chance of short cycles",
+		func(seed int64) UnsafeRNG { return NewUnsafeRomuTrioRNG(seed) }},
+}
+
+func aesSpeed() SpeedClass {
+	if hasAESNI {
+		return SpeedModerate
+	}
+	return SpeedSlow
+}
+
+// Algorithms lists the generators of this package, sorted by name
+func Algorithms() []Algorithm {
+	all := append([]Algorithm(nil), algorithms...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// LookupAlgorithm finds an algorithm by its name or one of its aliases
+func LookupAlgorithm(name string) (Algorithm, error) {
+	for _, a := range algorithms {
+		if a.Name == name {
+			return a, nil
+		}
+		for _, alias := range a.Aliases {
+			if alias == name {
+				return a, nil
+			}
+		}
+	}
+	return Algorithm{}, fmt.Errorf("no generator named %q", name)
+}
+
+// NewByName creates the named thread unsafe generator, eg NewByName("xoshiro256**", seed)
+func NewByName(name string, seed int64) (UnsafeRNG, error) {
+	a, err := LookupAlgorithm(name)
+	if err != nil {
+		return nil, err
+	}
+	return a.New(seed), nil
+}
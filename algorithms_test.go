@@ -0,0 +1,43 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Algorithms(t *testing.T) {
+	all := Algorithms()
+	assert.Len(t, all, len(algorithms))
+	names := map[string]bool{}
+	for i, a := range all {
+		if i > 0 {
+			assert.Less(t, all[i-1].Name, a.Name)
+		}
+		for _, name := range append([]string{a.Name}, a.Aliases...) {
+			assert.False(t, names[name], name)
+			names[name] = true
+		}
+		assert.Greater(t, a.Version, 0, a.Name)
+		assert.Greater(t, a.StateBits, 0, a.Name)
+		assert.NotEmpty(t, a.Period, a.Name)
+		assert.NotEmpty(t, a.Weaknesses, a.Name)
+		assert.NotEqual(t, a.New(1).Uint64(), a.New(2).Uint64(), a.Name)
+	}
+}
+
+func Test_NewByName(t *testing.T) {
+	r, err := NewByName("xoshiro256**", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(42), r)
+
+	r, err = NewByName("xoshiro256ss", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(42), r)
+
+	_, err = NewByName("mt19937", 42)
+	assert.Error(t, err)
+
+	assert.Equal(t, "fastest", SpeedFastest.String())
+	assert.Equal(t, "SpeedClass(9)", SpeedClass(9).String())
+}
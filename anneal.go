@@ -0,0 +1,198 @@
+package fastrand64
+
+import "math"
+
+// Accept is the Metropolis criterion of simulated annealing, it always accepts a move that lowers
+// the energy, delta <= 0, and accepts a worse one with probability exp(-delta/temperature), so a
+// hot search wanders and a cold one only descends. Temperature <= 0 accepts only improvements
+func Accept(r UnsafeRNG, delta float64, temperature float64) bool {
+	if delta <= 0 {
+		return true
+	}
+	if !(temperature > 0) {
+		return false
+	}
+	return unitFloat64(r) < math.Exp(-delta/temperature)
+}
+
+// Accept applies the Metropolis criterion using a pool checkout. Threadsafe
+func (s *ThreadsafePoolRNG) Accept(delta float64, temperature float64) bool {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	ok := Accept(r, delta, temperature)
+	rngPool.Put(r)
+	return ok
+}
+
+// CoolingSchedule returns the temperature of step, counting from 0, of a run of steps steps
+type CoolingSchedule func(step int, steps int) float64
+
+// GeometricCooling multiplies the temperature by alpha every step from t0, eg alpha 0.999
+func GeometricCooling(t0 float64, alpha float64) CoolingSchedule {
+	return func(step int, steps int) float64 {
+		return t0 * math.Pow(alpha, float64(step))
+	}
+}
+
+// ExponentialCooling falls geometrically from t0 at the first step to t1 at the last, whatever the
+// run's length, the usual choice when t0 and t1 are known from the scale of the energy changes
+func ExponentialCooling(t0 float64, t1 float64) CoolingSchedule {
+	return func(step int, steps int) float64 {
+		if steps <= 1 {
+			return t0
+		}
+		return t0 * math.Pow(t1/t0, float64(step)/float64(steps-1))
+	}
+}
+
+// LinearCooling falls in a straight line from t0 at the first step to t1 at the last
+func LinearCooling(t0 float64, t1 float64) CoolingSchedule {
+	return func(step int, steps int) float64 {
+		if steps <= 1 {
+			return t0
+		}
+		return t0 + (t1-t0)*float64(step)/float64(steps-1)
+	}
+}
+
+// LogarithmicCooling is c/ln(step+2), slow enough that annealing provably converges, and usually
+// too slow to use except as a baseline
+func LogarithmicCooling(c float64) CoolingSchedule {
+	return func(step int, steps int) float64 {
+		return c / math.Log(float64(step)+2)
+	}
+}
+
+// MoveKind is a kind of neighbour move on a sequence
+type MoveKind int
+
+const (
+	// MoveSwap exchanges the elements at I and J
+	MoveSwap MoveKind = iota
+	// MoveInsert takes the element at I out and puts it back at J, shifting those between
+	MoveInsert
+	// MoveReverse reverses the elements from I to J inclusive, the 2-opt move of tour problems
+	MoveReverse
+)
+
+// Move is a random neighbour of a sequence, a kind and two distinct indices. It is applied through
+// a swap function like sort.Slice's, so it works on any sequence, and it can be undone, so a
+// search can price a move incrementally and revert it when Accept rejects it
+type Move struct {
+	Kind MoveKind
+	I, J int
+}
+
+// RandomMove picks one of kinds, all three if none are given, and two distinct indices in [0, n),
+// from a thread unsafe RNG. n must be >= 2. Reverse moves have I < J
+func RandomMove(r UnsafeRNG, n int, kinds ...MoveKind) Move {
+	if n < 2 {
+		panic("RandomMove needs a sequence of at least 2 elements")
+	}
+	kind := MoveKind(intn(r, 3))
+	if len(kinds) > 0 {
+		kind = kinds[intn(r, len(kinds))]
+	}
+	i := intn(r, n)
+	j := intn(r, n-1)
+	if j >= i {
+		j++
+	}
+	if kind == MoveReverse && i > j {
+		i, j = j, i
+	}
+	return Move{Kind: kind, I: i, J: j}
+}
+
+// Apply makes the move with swap
+func (m Move) Apply(swap func(i, j int)) {
+	switch m.Kind {
+	case MoveSwap:
+		swap(m.I, m.J)
+	case MoveInsert:
+		for k := m.I; k < m.J; k++ {
+			swap(k, k+1)
+		}
+		for k := m.I; k > m.J; k-- {
+			swap(k, k-1)
+		}
+	case MoveReverse:
+		for i, j := m.I, m.J; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+}
+
+// Undo reverts Apply
+func (m Move) Undo(swap func(i, j int)) {
+	if m.Kind == MoveInsert {
+		m.I, m.J = m.J, m.I
+	}
+	m.Apply(swap)
+}
+
+// Annealer is a problem for Anneal, it holds the current state
+type Annealer interface {
+	// Propose makes a random neighbour move on the state, eg with RandomMove, and returns the
+	// change in energy it caused
+	Propose(r UnsafeRNG) float64
+	// Reject reverts the latest Propose
+	Reject()
+}
+
+// AnnealOptions configure Anneal, zero fields take the defaults
+type AnnealOptions struct {
+	Steps    int             // proposals to make, default 10000
+	Schedule CoolingSchedule // default ExponentialCooling(1, 0.001)
+	// OnBest is called whenever the state reaches a new lowest energy, to copy the best state
+	// seen, since the search may wander away from it
+	OnBest func(energy float64)
+}
+
+// AnnealResult is the outcome of an Anneal run
+type AnnealResult struct {
+	Energy   float64 // of the final state
+	Best     float64 // the lowest energy seen
+	Accepted int     // proposals accepted
+}
+
+// Anneal runs simulated annealing from a state with the given energy, proposing moves with a
+// thread unsafe RNG and accepting them by the Metropolis criterion at the schedule's temperature.
+// The same problem, options and generator state always make the same run
+func Anneal(r UnsafeRNG, a Annealer, energy float64, opts AnnealOptions) AnnealResult {
+	if opts.Steps <= 0 {
+		opts.Steps = 10000
+	}
+	if opts.Schedule == nil {
+		opts.Schedule = ExponentialCooling(1, 0.001)
+	}
+	res := AnnealResult{Energy: energy, Best: energy}
+	if opts.OnBest != nil {
+		opts.OnBest(energy)
+	}
+	for step := 0; step < opts.Steps; step++ {
+		delta := a.Propose(r)
+		if !Accept(r, delta, opts.Schedule(step, opts.Steps)) {
+			a.Reject()
+			continue
+		}
+		res.Accepted++
+		res.Energy += delta
+		if res.Energy < res.Best {
+			res.Best = res.Energy
+			if opts.OnBest != nil {
+				opts.OnBest(res.Energy)
+			}
+		}
+	}
+	return res
+}
+
+// Anneal runs simulated annealing using a single pool checkout for the whole run
+func (s *ThreadsafePoolRNG) Anneal(a Annealer, energy float64, opts AnnealOptions) AnnealResult {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	res := Anneal(r, a, energy, opts)
+	rngPool.Put(r)
+	return res
+}
@@ -0,0 +1,140 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Accept(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	assert.True(t, Accept(r, -1, 0))
+	assert.True(t, Accept(r, 0, 0))
+	assert.False(t, Accept(r, 1, 0))
+	assert.False(t, Accept(r, 1, math.NaN()))
+
+	const n = 100000
+	accepted := 0
+	for i := 0; i < n; i++ {
+		if Accept(r, 2, 4) {
+			accepted++
+		}
+	}
+	p := math.Exp(-0.5)
+	assert.InDelta(t, p, float64(accepted)/n, 5*math.Sqrt(p*(1-p)/n))
+	assert.True(t, NewSyncPoolXoshiro256ssRNG().Accept(-1, 1))
+}
+
+func Test_CoolingSchedule(t *testing.T) {
+	assert.InDelta(t, 10*math.Pow(0.9, 5), GeometricCooling(10, 0.9)(5, 100), 1e-12)
+
+	exp := ExponentialCooling(10, 0.01)
+	assert.InDelta(t, 10, exp(0, 101), 1e-12)
+	assert.InDelta(t, math.Sqrt(10*0.01), exp(50, 101), 1e-12)
+	assert.InDelta(t, 0.01, exp(100, 101), 1e-12)
+	assert.Equal(t, 10.0, exp(0, 1))
+
+	lin := LinearCooling(10, 0)
+	assert.Equal(t, 10.0, lin(0, 11))
+	assert.InDelta(t, 5, lin(5, 11), 1e-12)
+	assert.InDelta(t, 0, lin(10, 11), 1e-12)
+
+	assert.InDelta(t, 1/math.Log(2), LogarithmicCooling(1)(0, 10), 1e-12)
+}
+
+func Test_Move(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4, 5}
+	swap := func(i, j int) { xs[i], xs[j] = xs[j], xs[i] }
+	for _, c := range []struct {
+		m        Move
+		expected []int
+	}{
+		{Move{MoveSwap, 1, 4}, []int{0, 4, 2, 3, 1, 5}},
+		{Move{MoveInsert, 1, 4}, []int{0, 2, 3, 4, 1, 5}},
+		{Move{MoveInsert, 4, 1}, []int{0, 4, 1, 2, 3, 5}},
+		{Move{MoveReverse, 1, 4}, []int{0, 4, 3, 2, 1, 5}},
+	} {
+		c.m.Apply(swap)
+		assert.Equal(t, c.expected, xs, c.m)
+		c.m.Undo(swap)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, xs, c.m)
+	}
+
+	r := NewUnsafeXoshiro256ssRNG(2)
+	kinds := map[MoveKind]int{}
+	for i := 0; i < 3000; i++ {
+		m := RandomMove(r, 6)
+		kinds[m.Kind]++
+		assert.NotEqual(t, m.I, m.J)
+		assert.True(t, m.I >= 0 && m.I < 6 && m.J >= 0 && m.J < 6, m)
+		if m.Kind == MoveReverse {
+			assert.Less(t, m.I, m.J)
+		}
+		m.Apply(swap)
+		m.Undo(swap)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, xs, m)
+	}
+	assert.Len(t, kinds, 3)
+	assert.Equal(t, MoveInsert, RandomMove(r, 2, MoveInsert).Kind)
+	assert.Panics(t, func() { RandomMove(r, 1) })
+}
+
+// circleTour is a travelling salesman tour of points evenly spaced on a unit circle, visited in
+// shuffled order, the shortest tour goes round the circle
+type circleTour struct {
+	order []int
+	last  Move
+}
+
+func (c *circleTour) length() float64 {
+	total := 0.0
+	n := len(c.order)
+	for i := range c.order {
+		a := 2 * math.Pi * float64(c.order[i]) / float64(n)
+		b := 2 * math.Pi * float64(c.order[(i+1)%n]) / float64(n)
+		total += math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+	}
+	return total
+}
+
+func (c *circleTour) swap(i, j int) {
+	c.order[i], c.order[j] = c.order[j], c.order[i]
+}
+
+func (c *circleTour) Propose(r UnsafeRNG) float64 {
+	before := c.length()
+	c.last = RandomMove(r, len(c.order), MoveReverse, MoveInsert)
+	c.last.Apply(c.swap)
+	return c.length() - before
+}
+
+func (c *circleTour) Reject() {
+	c.last.Undo(c.swap)
+}
+
+func Test_Anneal(t *testing.T) {
+	const n = 16
+	tour := &circleTour{order: Perm(NewUnsafeXoshiro256ssRNG(3), make([]int, n))}
+	start := tour.length()
+	var best []int
+	res := Anneal(NewUnsafeXoshiro256ssRNG(4), tour, start, AnnealOptions{
+		Steps:    20000,
+		Schedule: ExponentialCooling(1, 0.001),
+		OnBest:   func(float64) { best = append(best[:0], tour.order...) },
+	})
+	optimal := 2 * n * math.Sin(math.Pi/n)
+	assert.InDelta(t, tour.length(), res.Energy, 1e-9)
+	assert.InDelta(t, optimal, res.Best, 1e-9)
+	assert.Greater(t, res.Accepted, 0)
+	tour.order = best
+	assert.InDelta(t, optimal, tour.length(), 1e-9)
+
+	// the same generator state makes the same run
+	again := &circleTour{order: Perm(NewUnsafeXoshiro256ssRNG(3), make([]int, n))}
+	assert.Equal(t, res, Anneal(NewUnsafeXoshiro256ssRNG(4), again, start, AnnealOptions{Steps: 20000}))
+
+	pooled := &circleTour{order: Perm(NewUnsafeXoshiro256ssRNG(3), make([]int, n))}
+	res = NewSyncPoolXoshiro256ssRNG().Anneal(pooled, start, AnnealOptions{})
+	assert.Less(t, res.Best, start)
+}
@@ -0,0 +1,90 @@
+package fastrand64
+
+import "math"
+
+// AnomalyKind labels each point of an AnomalyDataset, the kinds are bits so a point that is both
+// a spike and the start of a level shift is AnomalySpike|AnomalyLevelShift
+type AnomalyKind int
+
+const (
+	// AnomalyNone is a normal point
+	AnomalyNone AnomalyKind = 0
+	// AnomalySpike is a single point outlier
+	AnomalySpike AnomalyKind = 1
+	// AnomalyLevelShift is the first point of a persistent change in the baseline
+	AnomalyLevelShift AnomalyKind = 2
+)
+
+// Has reports whether the label includes kind
+func (k AnomalyKind) Has(kind AnomalyKind) bool {
+	return k&kind != 0
+}
+
+// AnomalyDatasetOptions configures GenerateAnomalyDataset. The normal signal is
+// Baseline + SeasonAmplitude*sin(2*pi*t/SeasonPeriod) + Normal(0, NoiseSigma)
+type AnomalyDatasetOptions struct {
+	Seed            int64
+	Length          int
+	Baseline        float64
+	NoiseSigma      float64
+	SeasonPeriod    int // 0 disables seasonality
+	SeasonAmplitude float64
+	// SpikeRate is the per point probability of a spike, of magnitude SpikeScale*(1+Exp(1)) and random sign
+	SpikeRate  float64
+	SpikeScale float64
+	// LevelShiftRate is the per point probability of a level shift, of magnitude
+	// LevelShiftScale*(1+Exp(1)) and random sign
+	LevelShiftRate  float64
+	LevelShiftScale float64
+}
+
+// AnomalyDataset is a labeled time series, Kinds[i] labels Values[i]
+type AnomalyDataset struct {
+	Values []float64
+	Kinds  []AnomalyKind
+}
+
+// GenerateAnomalyDataset builds a labeled time series with injected anomalies,
+// the same options (including Seed) always produce the same dataset
+func GenerateAnomalyDataset(opts AnomalyDatasetOptions) *AnomalyDataset {
+	r := NewUnsafeXoshiro256ssRNG(opts.Seed)
+	d := &AnomalyDataset{
+		Values: GaussianNoise(r, make([]float64, opts.Length), opts.NoiseSigma),
+		Kinds:  make([]AnomalyKind, opts.Length),
+	}
+
+	level := opts.Baseline
+	for i := range d.Values {
+		if unitFloat64(r) < opts.LevelShiftRate {
+			level += anomalyMagnitude(r, opts.LevelShiftScale)
+			d.Kinds[i] = AnomalyLevelShift
+		}
+		d.Values[i] += level
+		if opts.SeasonPeriod > 0 {
+			d.Values[i] += opts.SeasonAmplitude * math.Sin(2*math.Pi*float64(i)/float64(opts.SeasonPeriod))
+		}
+		if unitFloat64(r) < opts.SpikeRate {
+			d.Values[i] += anomalyMagnitude(r, opts.SpikeScale)
+			d.Kinds[i] |= AnomalySpike
+		}
+	}
+	return d
+}
+
+func anomalyMagnitude(r UnsafeRNG, scale float64) float64 {
+	x := r.Uint64()
+	m := scale * (1 - math.Log(1-float64(x>>11)*(1.0/(1<<53))))
+	if x&1 == 1 {
+		return -m
+	}
+	return m
+}
+
+// Labels returns true for every anomalous point, for detectors that only score anomaly vs normal
+func (d *AnomalyDataset) Labels() []bool {
+	labels := make([]bool, len(d.Kinds))
+	for i, k := range d.Kinds {
+		labels[i] = k != AnomalyNone
+	}
+	return labels
+}
@@ -0,0 +1,91 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateAnomalyDataset(t *testing.T) {
+	opts := AnomalyDatasetOptions{
+		Seed:            7,
+		Length:          10000,
+		Baseline:        100,
+		NoiseSigma:      1,
+		SeasonPeriod:    24,
+		SeasonAmplitude: 5,
+		SpikeRate:       0.01,
+		SpikeScale:      20,
+		LevelShiftRate:  0.001,
+		LevelShiftScale: 10,
+	}
+	d := GenerateAnomalyDataset(opts)
+	assert.Equal(t, d, GenerateAnomalyDataset(opts))
+	assert.Len(t, d.Values, opts.Length)
+
+	spikes, shifts := 0, 0
+	for i, k := range d.Kinds {
+		if k == AnomalySpike {
+			// spikes are at least 20 away from the signal, neighbours are not
+			if i > 0 && i < len(d.Values)-1 && d.Kinds[i-1] == AnomalyNone && d.Kinds[i+1] == AnomalyNone {
+				assert.Greater(t, math.Abs(d.Values[i]-(d.Values[i-1]+d.Values[i+1])/2), 10.0)
+			}
+		}
+		if k.Has(AnomalySpike) {
+			spikes++
+		}
+		if k.Has(AnomalyLevelShift) {
+			shifts++
+		}
+	}
+	assert.InDelta(t, 100, spikes, 35)
+	assert.InDelta(t, 10, shifts, 9)
+
+	labels := d.Labels()
+	assert.Len(t, labels, opts.Length)
+	for i, k := range d.Kinds {
+		assert.Equal(t, k != AnomalyNone, labels[i])
+	}
+}
+
+func Test_GenerateAnomalyDataset_Clean(t *testing.T) {
+	d := GenerateAnomalyDataset(AnomalyDatasetOptions{Seed: 1, Length: 100, Baseline: 3})
+	for i := range d.Values {
+		assert.Equal(t, 3.0, d.Values[i])
+		assert.Equal(t, AnomalyNone, d.Kinds[i])
+	}
+}
+
+func Test_GenerateAnomalyDataset_Overlap(t *testing.T) {
+	// a spike on the first point of a level shift keeps both labels
+	d := GenerateAnomalyDataset(AnomalyDatasetOptions{
+		Seed:            3,
+		Length:          10000,
+		SpikeRate:       0.5,
+		SpikeScale:      1,
+		LevelShiftRate:  0.5,
+		LevelShiftScale: 1,
+	})
+	counts := map[AnomalyKind]int{}
+	spikes, shifts := 0, 0
+	for _, k := range d.Kinds {
+		counts[k]++
+		if k.Has(AnomalySpike) {
+			spikes++
+		}
+		if k.Has(AnomalyLevelShift) {
+			shifts++
+		}
+	}
+	assert.InDelta(t, 2500, counts[AnomalySpike|AnomalyLevelShift], 250)
+	assert.InDelta(t, 5000, spikes, 300)
+	assert.InDelta(t, 5000, shifts, 300)
+	assert.Len(t, counts, 4)
+
+	both := AnomalySpike | AnomalyLevelShift
+	assert.True(t, both.Has(AnomalySpike))
+	assert.True(t, both.Has(AnomalyLevelShift))
+	assert.False(t, AnomalySpike.Has(AnomalyLevelShift))
+	assert.False(t, AnomalyNone.Has(AnomalySpike))
+}
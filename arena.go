@@ -0,0 +1,30 @@
+//go:build goexperiment.arenas && go1.21
+// +build goexperiment.arenas,go1.21
+
+// the go1.21 constraint raises this file's language version past the module's, for generics
+
+package fastrand64
+
+import "arena"
+
+// BytesArena is Bytes allocating from an arena, so request handlers can free random scratch data
+// in bulk with the rest of the request and keep GC pressure flat. Only built with
+// GOEXPERIMENT=arenas
+func (s *ThreadsafePoolRNG) BytesArena(a *arena.Arena, n int) []byte {
+	bytes := arena.MakeSlice[byte](a, n, n)
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	Bytes(r, bytes)
+	rngPool.Put(r)
+	return bytes
+}
+
+// PermArena is Perm allocating from an arena. Only built with GOEXPERIMENT=arenas
+func (s *ThreadsafePoolRNG) PermArena(a *arena.Arena, n int) []int {
+	p := arena.MakeSlice[int](a, n, n)
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	Perm(r, p)
+	rngPool.Put(r)
+	return p
+}
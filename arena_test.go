@@ -0,0 +1,27 @@
+//go:build goexperiment.arenas && go1.21
+// +build goexperiment.arenas,go1.21
+
+package fastrand64
+
+import (
+	"arena"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Arena(t *testing.T) {
+	a := arena.NewArena()
+	defer a.Free()
+
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	assert.Equal(t, Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 100)), rng.BytesArena(a, 100))
+
+	p := rng.PermArena(a, 50)
+	assert.Len(t, p, 50)
+	sort.Ints(p)
+	for i, x := range p {
+		assert.Equal(t, i, x)
+	}
+}
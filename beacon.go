@@ -0,0 +1,27 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// BeaconSeedSeq derives a SeedSeq from a public randomness beacon round, eg a drand round number
+// and its published randomness, so distributed jobs that agree on a round all derive identical
+// streams. This package does no networking: fetch (and verify the signature of) the round yourself.
+func BeaconSeedSeq(round uint64, randomness []byte) SeedSeq {
+	entropy := make([]byte, 0, len("beacon\x00")+8+len(randomness))
+	entropy = append(entropy, "beacon\x00"...)
+	var r [8]byte
+	binary.BigEndian.PutUint64(r[:], round)
+	entropy = append(entropy, r[:]...)
+	return NewSeedSeq(append(entropy, randomness...))
+}
+
+// BeaconSeedSeqHex is BeaconSeedSeq for randomness as a hex string, the way drand publishes it in json
+func BeaconSeedSeqHex(round uint64, randomnessHex string) (SeedSeq, error) {
+	randomness, err := hex.DecodeString(randomnessHex)
+	if err != nil {
+		return SeedSeq{}, err
+	}
+	return BeaconSeedSeq(round, randomness), nil
+}
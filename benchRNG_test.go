@@ -9,141 +9,208 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-type UnsafePcg32RNG struct {
-	state uint64
-	inc   uint64
+func Test_UnsafeCast(t *testing.T) {
+	var i uint64 = 1
+	b := make([]byte, 16)
+
+	header := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	ptr := (*uint64)(unsafe.Pointer(header.Data))
+
+	ptr = (*uint64)(unsafe.Pointer((uintptr)(unsafe.Pointer(ptr)) + 8))
+	*ptr = i
+
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, b)
 }
 
-func (r *UnsafePcg32RNG) SetState(initstate uint64, initseq uint64) {
-	r.state = 0
-	r.inc = (initseq << 1) | 1
-	r.Uint32()
-	r.state += initstate
-	r.Uint32()
+// ////////////////////////////////////////////////////////////////
+
+func Benchmark_UnsafePcg32x2RNG(b *testing.B) {
+	rng := NewUnsafePcg32x2RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func (r *UnsafePcg32RNG) Seed(seed int64) {
-	r.SetState(
-		Splitmix64(uint64(seed)+uint64(0)),
-		Splitmix64(uint64(seed)+uint64(1)),
-	)
+func Benchmark_UnsafeJsf64RNG(b *testing.B) {
+	rng := NewUnsafeJsf64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func (r *UnsafePcg32RNG) Uint32() uint32 {
-	oldstate := r.state
-	r.state = oldstate*6364136223846793005 + r.inc
-	xorshifted := ((oldstate >> 18) ^ oldstate) >> 27
-	rot := oldstate >> 59
-	result := (xorshifted >> rot) | (xorshifted << ((-rot) & 31))
-	return uint32(result)
+// for a comparison table of every registered generator on your machine run: fastrand bench
+
+func Benchmark_UnsafeLehmer128RNG(b *testing.B) {
+	rng := NewUnsafeLehmer128RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func NewUnsafePcg32RNG(seed int64) *UnsafePcg32RNG {
-	r := &UnsafePcg32RNG{}
-	r.Seed(seed)
-	return r
+func Benchmark_UnsafePhilox4x64RNG(b *testing.B) {
+	rng := NewUnsafePhilox4x64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-type UnsafePcg32x2RNG struct {
-	gen0 UnsafePcg32RNG
-	gen1 UnsafePcg32RNG
+func Benchmark_UnsafeSquaresRNG(b *testing.B) {
+	rng := NewUnsafeSquaresRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func (r *UnsafePcg32x2RNG) SetState(seed1 uint64, seq1 uint64, seed2 uint64, seq2 uint64) {
-	mask := ^uint64(0) >> 1
-	// The stream for each of the two generators *must* be distinct
-	if (seq1 & mask) == (seq2 & mask) {
-		seq2 = ^seq2
+func Benchmark_UnsafeChaCha8RNG(b *testing.B) {
+	rng := NewUnsafeChaCha8RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
 	}
-	r.gen0.SetState(seed1, seq1)
-	r.gen1.SetState(seed2, seq2)
+	BenchSink = &r
 }
 
-func (r *UnsafePcg32x2RNG) Seed(seed int64) {
-	r.SetState(
-		Splitmix64(uint64(seed)+uint64(0)),
-		Splitmix64(uint64(seed)+uint64(1)),
-		Splitmix64(uint64(seed)+uint64(2)),
-		Splitmix64(uint64(seed)+uint64(3)),
-	)
+func Benchmark_UnsafeAesCtrRNG(b *testing.B) {
+	rng := NewUnsafeAesCtrRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func (r *UnsafePcg32x2RNG) Uint64() uint64 {
-	return (uint64(r.gen0.Uint32()) << 32) | uint64(r.gen1.Uint32())
+func Benchmark_UnsafeAesCtrRNG_Read_1024bytes(b *testing.B) {
+	rng := NewUnsafeAesCtrRNG(time.Now().UnixNano())
+	bytes := make([]byte, 1024)
+	b.SetBytes(int64(len(bytes)))
+	for i := 0; i < b.N; i++ {
+		rng.Read(bytes)
+	}
+	BenchSink = &bytes
 }
 
-func NewUnsafePcg32x2RNG(seed int64) *UnsafePcg32x2RNG {
-	r := &UnsafePcg32x2RNG{}
-	r.Seed(seed)
-	return r
+func Benchmark_UnsafeIsaac64RNG(b *testing.B) {
+	rng := NewUnsafeIsaac64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-type UnsafeJsf64RNG struct {
-	a uint64
-	b uint64
-	c uint64
-	d uint64
+func Benchmark_UnsafeIsaac64RNG_Read_1024bytes(b *testing.B) {
+	rng := NewUnsafeIsaac64RNG(time.Now().UnixNano())
+	bytes := make([]byte, 1024)
+	b.SetBytes(int64(len(bytes)))
+	for i := 0; i < b.N; i++ {
+		rng.Read(bytes)
+	}
+	BenchSink = &bytes
 }
 
-func rot64(x uint64, k uint64) uint64 {
-	return ((x << k) | (x >> (64 - k)))
+func Benchmark_UnsafeShishuaRNG(b *testing.B) {
+	rng := NewUnsafeShishuaRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func (x *UnsafeJsf64RNG) Uint64() uint64 {
-	e := x.a - rot64(x.b, 7)
-	x.a = x.b ^ rot64(x.c, 13)
-	x.b = x.c + rot64(x.d, 37)
-	x.c = x.d + e
-	x.d = e + x.a
-	return x.d
+func Benchmark_UnsafeShishuaRNG_Read_1024bytes(b *testing.B) {
+	rng := NewUnsafeShishuaRNG(time.Now().UnixNano())
+	bytes := make([]byte, 1024)
+	b.SetBytes(int64(len(bytes)))
+	for i := 0; i < b.N; i++ {
+		rng.Read(bytes)
+	}
+	BenchSink = &bytes
 }
 
-func (x *UnsafeJsf64RNG) Seed(seed int64) {
-	x.a = 0xf1ea5eed
-	x.b = uint64(seed)
-	x.c = uint64(seed)
-	x.d = uint64(seed)
-	for i := 0; i < 20; i++ {
-		x.Uint64()
+func Benchmark_UnsafeRdrandRNG(b *testing.B) {
+	rng := NewUnsafeRdrandRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
 	}
+	BenchSink = &r
 }
 
-func NewUnsafeJsf64RNG(seed int64) *UnsafeJsf64RNG {
-	r := &UnsafeJsf64RNG{}
-	r.Seed(seed)
-	return r
+func Benchmark_UnsafeKiss64RNG(b *testing.B) {
+	rng := NewUnsafeKiss64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
 }
 
-func Test_UnsafeCast(t *testing.T) {
-	var i uint64 = 1
-	b := make([]byte, 16)
+func Benchmark_UnsafeGjrandRNG(b *testing.B) {
+	rng := NewUnsafeGjrandRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
 
-	header := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	ptr := (*uint64)(unsafe.Pointer(header.Data))
+func Benchmark_UnsafeRomuDuoJrRNG(b *testing.B) {
+	rng := NewUnsafeRomuDuoJrRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
 
-	ptr = (*uint64)(unsafe.Pointer((uintptr)(unsafe.Pointer(ptr)) + 8))
-	*ptr = i
+func Benchmark_UnsafeRomuTrioRNG(b *testing.B) {
+	rng := NewUnsafeRomuTrioRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
 
-	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, b)
+// the Bytes fill path, which is where the per word cost of the generator shows most
+
+func benchmarkUnsafeBytes(b *testing.B, rng UnsafeRNG) {
+	bytes := make([]byte, 1024)
+	b.SetBytes(int64(len(bytes)))
+	for i := 0; i < b.N; i++ {
+		Bytes(rng, bytes)
+	}
+	BenchSink = &bytes
 }
 
-// ////////////////////////////////////////////////////////////////
+func Benchmark_UnsafeBytes_Xoshiro256ss_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeXoshiro256ssRNG(time.Now().UnixNano()))
+}
 
-/*
+func Benchmark_UnsafeBytes_Jsf64_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeJsf64RNG(time.Now().UnixNano()))
+}
 
-Running tool: C:\Go\bin\go.exe test -benchmem -run=^$ github.com/villenny/concurrency-go -bench ^(Benchmark_UnsafePcg32x2RNG|Benchmark_UnsafeJsf64RNG)$
+func Benchmark_UnsafeBytes_RomuDuoJr_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeRomuDuoJrRNG(time.Now().UnixNano()))
+}
 
-goos: windows
-goarch: amd64
-pkg: github.com/villenny/concurrency-go
-Benchmark_UnsafePcg32x2RNG-8   	223279117	         5.28 ns/op	       0 B/op	       0 allocs/op
-Benchmark_UnsafeJsf64RNG-8     	295147552	         4.10 ns/op	       0 B/op	       0 allocs/op
-PASS
-ok  	github.com/villenny/concurrency-go	3.550s
+func Benchmark_UnsafeBytes_RomuTrio_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeRomuTrioRNG(time.Now().UnixNano()))
+}
 
-*/
-func Benchmark_UnsafePcg32x2RNG(b *testing.B) {
-	rng := NewUnsafePcg32x2RNG(time.Now().UnixNano())
+func Benchmark_RuntimeRNG(b *testing.B) {
+	rng := NewRuntimeRNG()
 	var r uint64
 	for i := 0; i < b.N; i++ {
 		r = rng.Uint64()
@@ -151,11 +218,148 @@ func Benchmark_UnsafePcg32x2RNG(b *testing.B) {
 	BenchSink = &r
 }
 
-func Benchmark_UnsafeJsf64RNG(b *testing.B) {
-	rng := NewUnsafeJsf64RNG(time.Now().UnixNano())
+func Benchmark_RuntimeRNG_Parallel(b *testing.B) {
+	rng := NewRuntimeRNG()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rng.Uint64()
+		for pb.Next() {
+			r = rng.Uint64()
+		}
+		BenchSink = &r
+	})
+}
+
+func Benchmark_UnsafeBytes_Isaac64_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeIsaac64RNG(time.Now().UnixNano()))
+}
+
+func Benchmark_UnsafeBytes_Shishua_1024bytes(b *testing.B) {
+	benchmarkUnsafeBytes(b, NewUnsafeShishuaRNG(time.Now().UnixNano()))
+}
+
+func Benchmark_UnsafeSipHashRNG(b *testing.B) {
+	rng := NewUnsafeSipHashRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafeCryptoRNG(b *testing.B) {
+	rng := NewUnsafeCryptoRNG()
 	var r uint64
 	for i := 0; i < b.N; i++ {
 		r = rng.Uint64()
 	}
 	BenchSink = &r
 }
+
+func Benchmark_UnsafeXoshiro256ssRNG_Float64(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = rng.Float64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Float32_Serial(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var r float32
+	for i := 0; i < b.N; i++ {
+		r = rng.Float32()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_NormFloat64_Serial(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = rng.NormFloat64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_NormFloat64_Ziggurat(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = normFloat64(rng)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_NormFloat64_Polar(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = normal(rng)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_ExpFloat64_Ziggurat(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = expFloat64(rng)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_Poisson(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = Poisson(rng, 100)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_ValueNoise2D_4Octaves(b *testing.B) {
+	key := World(time.Now().UnixNano()).Feature("height")
+	var r float64
+	for i := 0; i < b.N; i++ {
+		r = ValueNoise2D(key, float64(i)*0.01, 3.5, 4)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_Geometric(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = Geometric(rng, 0.01)
+	}
+	BenchSink = &r
+}
+
+func Benchmark_Branch_Xoshiro256ss(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	var r *UnsafeXoshiro256ssRNG
+	for i := 0; i < b.N; i++ {
+		r = rng.Branch()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_Zipf(b *testing.B) {
+	z := NewZipf(NewSyncPoolXoshiro256ssRNG(), 1.1, 1, 1000000)
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = z.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_SampleTemperature(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(time.Now().UnixNano())
+	visits := []float64{12, 80, 3, 0, 45, 7, 160, 1}
+	var r int
+	for i := 0; i < b.N; i++ {
+		r = SampleTemperature(rng, visits, 1)
+	}
+	BenchSink = &r
+}
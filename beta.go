@@ -0,0 +1,128 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// Beta is the beta distribution on [0, 1] with shapes alpha and beta, mean alpha/(alpha+beta), eg
+// the uncertain rate of a coin after alpha-1 heads and beta-1 tails, for Thompson sampling
+type Beta struct {
+	alpha, beta float64
+}
+
+// NewBeta validates alpha > 0 and beta > 0
+func NewBeta(alpha float64, beta float64) (*Beta, error) {
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		return nil, errors.New("Beta alpha must be finite and > 0")
+	}
+	if !(beta > 0) || math.IsInf(beta, 1) {
+		return nil, errors.New("Beta beta must be finite and > 0")
+	}
+	return &Beta{alpha: alpha, beta: beta}, nil
+}
+
+// Sample draws X/(X+Y) for X and Y gamma with shapes alpha and beta, or with Johnk's method when
+// both shapes are <= 1, where both gammas can underflow to 0
+func (d *Beta) Sample(r UnsafeRNG) float64 {
+	if d.alpha <= 1 && d.beta <= 1 {
+		return betaJohnk(r, d.alpha, d.beta)
+	}
+	x := standardGamma(r, d.alpha)
+	return x / (x + standardGamma(r, d.beta))
+}
+
+// betaJohnk accepts U^(1/a) / (U^(1/a) + V^(1/b)) when the sum is <= 1, in logs if it underflows
+func betaJohnk(r UnsafeRNG, a float64, b float64) float64 {
+	for {
+		u, v := openUnitFloat64(r), openUnitFloat64(r)
+		x, y := math.Pow(u, 1/a), math.Pow(v, 1/b)
+		if x+y > 1 {
+			continue
+		}
+		if x+y > 0 {
+			return x / (x + y)
+		}
+		logX, logY := math.Log(u)/a, math.Log(v)/b
+		logM := math.Max(logX, logY)
+		logX -= logM
+		logY -= logM
+		return math.Exp(logX - math.Log(math.Exp(logX)+math.Exp(logY)))
+	}
+}
+
+// Quantile returns the inverse CDF, numerically inverting the regularized incomplete beta function
+func (d *Beta) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	cdf := func(x float64) float64 { return betaI(d.alpha, d.beta, x) }
+	return invertCDF(cdf, p, 0, 1)
+}
+
+// Beta draws from Beta(alpha, beta) using a single pool checkout, it panics unless alpha and beta
+// are finite and > 0, as NewBeta validates. Threadsafe
+func (s *ThreadsafePoolRNG) Beta(alpha float64, beta float64) float64 {
+	d, err := NewBeta(alpha, beta)
+	if err != nil {
+		panic(err.Error())
+	}
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := d.Sample(r)
+	rngPool.Put(r)
+	return x
+}
+
+// betaI is the regularized incomplete beta function I_x(a, b), by its continued fraction, using the
+// symmetry I_x(a, b) = 1 - I_(1-x)(b, a) where that converges faster
+func betaI(a float64, b float64, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	prefix := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1)/(a+b+2) {
+		return prefix * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - prefix*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of I_x(a, b) by modified Lentz
+func betaContinuedFraction(a float64, b float64, x float64) float64 {
+	const tiny = 1e-300
+	c := 1.0
+	dd := 1 - (a+b)*x/(a+1)
+	if math.Abs(dd) < tiny {
+		dd = tiny
+	}
+	dd = 1 / dd
+	h := dd
+	for m := 1.0; m < 1000; m++ {
+		// the even then the odd step
+		for _, an := range [2]float64{
+			m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m)),
+			-(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1)),
+		} {
+			dd = 1 + an*dd
+			if math.Abs(dd) < tiny {
+				dd = tiny
+			}
+			c = 1 + an/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			dd = 1 / dd
+			h *= dd * c
+		}
+		if math.Abs(dd*c-1) < 1e-16 {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Beta(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, c := range []struct{ alpha, beta float64 }{{0.5, 0.5}, {0.01, 0.02}, {1, 1}, {2.5, 0.8}, {30, 70}} {
+		d, err := NewBeta(c.alpha, c.beta)
+		assert.NoError(t, err)
+		xs := rng.FillDist(d, make([]float64, 200000))
+		for _, x := range xs {
+			assert.True(t, x >= 0 && x <= 1, x)
+		}
+		a, b := c.alpha, c.beta
+		expectedVariance := a * b / ((a + b) * (a + b) * (a + b + 1))
+		mean, variance := meanAndVariance(xs)
+		assert.InDelta(t, a/(a+b), mean, 5*math.Sqrt(expectedVariance/float64(len(xs))), c)
+		assert.InEpsilon(t, expectedVariance, variance, 0.04, c)
+	}
+	x := rng.Beta(2, 3)
+	assert.True(t, x > 0 && x < 1, x)
+
+	for _, params := range [][2]float64{{0, 1}, {1, 0}, {0, 0}, {-1, 2}, {math.Inf(1), 2}, {1, math.NaN()}} {
+		_, err := NewBeta(params[0], params[1])
+		assert.Error(t, err)
+		assert.Panics(t, func() { rng.Beta(params[0], params[1]) }, params)
+	}
+}
+
+func Test_betaI(t *testing.T) {
+	for _, x := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+		assert.InDelta(t, x, betaI(1, 1, x), 1e-14)
+		// I_x(a, 1) = x^a, I_x(2, 2) = 3x^2 - 2x^3
+		assert.InDelta(t, math.Pow(x, 3.5), betaI(3.5, 1, x), 1e-13)
+		assert.InDelta(t, 3*x*x-2*x*x*x, betaI(2, 2, x), 1e-13)
+		// the arcsine distribution
+		assert.InDelta(t, 2/math.Pi*math.Asin(math.Sqrt(x)), betaI(0.5, 0.5, x), 1e-13)
+	}
+	assert.Equal(t, 0.0, betaI(2, 3, 0))
+	assert.Equal(t, 1.0, betaI(2, 3, 1))
+
+	d, _ := NewBeta(2, 1)
+	assert.InDelta(t, math.Sqrt(0.3), d.Quantile(0.3), 1e-12)
+	assert.True(t, math.IsNaN(d.Quantile(1.5)))
+}
@@ -0,0 +1,256 @@
+package fastrand64
+
+import "math"
+
+// binomialInversionMean is the mean below which inversion beats BTPE, and small enough that
+// (1-p)**n never underflows
+const binomialInversionMean = 30
+
+// Binomial draws from Binomial(n, p) from a thread unsafe RNG: inversion for small means and
+// BTPE for large ones, so any n costs O(1) expected time. p must be in [0, 1]. For many draws
+// with the same n and p, BinomialSampler does the setup once
+func Binomial(r UnsafeRNG, n uint64, p float64) uint64 {
+	var b BinomialSampler
+	b.init(n, p)
+	return b.Sample(r)
+}
+
+// Binomial draws from Binomial(n, p) using a single pool checkout. Threadsafe
+func (s *ThreadsafePoolRNG) Binomial(n uint64, p float64) uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := Binomial(r, n, p)
+	rngPool.Put(r)
+	return x
+}
+
+// BinomialSampler draws from Binomial(n, p) for fixed n and p, with the sampler's setup, which
+// for BTPE is a dozen logs and square roots, done once. It draws the same values as Binomial, and
+// is read only after NewBinomialSampler, so it can be shared between goroutines, each drawing with
+// its own generator
+type BinomialSampler struct {
+	n       uint64
+	fixed   bool   // p is 0 or 1, or n is 0, so every draw is value
+	value   uint64 // the fixed draw
+	flip    bool   // drawing n minus Binomial(n, 1-p), for p > 0.5
+	useBtpe bool
+	inv     binomialInv
+	btpe    btpe
+}
+
+// NewBinomialSampler sets up the sampler for Binomial(n, p), p must be in [0, 1]
+func NewBinomialSampler(n uint64, p float64) *BinomialSampler {
+	b := &BinomialSampler{}
+	b.init(n, p)
+	return b
+}
+
+func (b *BinomialSampler) init(n uint64, p float64) {
+	if !(p >= 0 && p <= 1) {
+		panic("Binomial p must be in [0, 1]")
+	}
+	b.n = n
+	switch {
+	case p <= 0 || n == 0:
+		b.fixed, b.value = true, 0
+		return
+	case p >= 1:
+		b.fixed, b.value = true, n
+		return
+	case p > 0.5:
+		b.flip = true
+		p = 1 - p
+	}
+	if float64(n)*p < binomialInversionMean {
+		b.inv.init(n, p)
+	} else {
+		b.useBtpe = true
+		b.btpe.init(n, p)
+	}
+}
+
+// Sample draws one value from a thread unsafe RNG
+func (b *BinomialSampler) Sample(r UnsafeRNG) uint64 {
+	if b.fixed {
+		return b.value
+	}
+	var x uint64
+	if b.useBtpe {
+		x = b.btpe.sample(r)
+	} else {
+		x = b.inv.sample(r)
+	}
+	if b.flip {
+		return b.n - x
+	}
+	return x
+}
+
+// SampleBinomial draws from a BinomialSampler using a pool checkout. Threadsafe
+func (s *ThreadsafePoolRNG) SampleBinomial(b *BinomialSampler) uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := b.Sample(r)
+	rngPool.Put(r)
+	return x
+}
+
+// binomialInv walks the cdf from 0, O(n*p), for p <= 0.5 and n*p < binomialInversionMean
+type binomialInv struct {
+	n       uint64
+	s, a    float64
+	pmfZero float64 // (1-p)**n
+}
+
+func (b *binomialInv) init(n uint64, p float64) {
+	q := 1 - p
+	b.n = n
+	b.s = p / q
+	b.a = float64(n+1) * b.s
+	b.pmfZero = math.Pow(q, float64(n))
+}
+
+func (b *binomialInv) sample(r UnsafeRNG) uint64 {
+	prob := b.pmfZero
+	u := unitFloat64(r)
+	x := uint64(0)
+	for u > prob && x < b.n {
+		u -= prob
+		x++
+		prob *= b.a/float64(x) - b.s
+	}
+	return x
+}
+
+// btpe holds the setup of the BTPE binomial sampler for fixed n and p <= 0.5, see
+// Kachitvichyanukul, Schmeiser: "Binomial random variate generation", CACM 1988
+type btpe struct {
+	n, r, q, fm, xm, xl, xr, c, laml, lamr, p1, p2, p3, p4 float64
+	m                                                      float64
+}
+
+func (b *btpe) init(n uint64, p float64) {
+	*b = btpe{n: float64(n), r: p, q: 1 - p}
+	b.fm = b.n*b.r + b.r
+	b.m = math.Floor(b.fm)
+	b.p1 = math.Floor(2.195*math.Sqrt(b.n*b.r*b.q)-4.6*b.q) + 0.5
+	b.xm = b.m + 0.5
+	b.xl = b.xm - b.p1
+	b.xr = b.xm + b.p1
+	b.c = 0.134 + 20.5/(15.3+b.m)
+	a := (b.fm - b.xl) / (b.fm - b.xl*b.r)
+	b.laml = a * (1 + a/2)
+	a = (b.xr - b.fm) / (b.xr * b.q)
+	b.lamr = a * (1 + a/2)
+	b.p2 = b.p1 * (1 + 2*b.c)
+	b.p3 = b.p2 + b.c/b.laml
+	b.p4 = b.p3 + b.c/b.lamr
+}
+
+// sample draws using a triangle, parallelogram and exponential tails hat over the pmf, with a
+// squeeze and Stirling based acceptance test far from the mode
+func (b *btpe) sample(rng UnsafeRNG) uint64 {
+	n, r, q, m := b.n, b.r, b.q, b.m
+	nrq := n * r * q
+	for {
+		u := unitFloat64(rng) * b.p4
+		v := unitFloat64(rng)
+		var y float64
+		switch {
+		case u <= b.p1:
+			// triangular region, accept immediately
+			return uint64(math.Floor(b.xm - b.p1*v + u))
+		case u <= b.p2:
+			// parallelogram region
+			x := b.xl + (u-b.p1)/b.c
+			v = v*b.c + 1 - math.Abs(m-x+0.5)/b.p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= b.p3:
+			// left exponential tail
+			y = math.Floor(b.xl + math.Log(v)/b.laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v = v * (u - b.p2) * b.laml
+		default:
+			// right exponential tail
+			y = math.Floor(b.xr - math.Log(v)/b.lamr)
+			if y > n || v == 0 {
+				continue
+			}
+			v = v * (u - b.p3) * b.lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// evaluate the pmf ratio f(y)/f(m) by recursion
+			s := r / q
+			a := s * (n + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return uint64(y)
+			}
+			continue
+		}
+
+		// squeeze on log(f(y)/f(m))
+		rho := (k / nrq) * ((k*(k/3+0.625)+0.16666666666666666)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		logV := math.Log(v)
+		if logV < t-rho {
+			return uint64(y)
+		}
+		if logV > t+rho {
+			continue
+		}
+
+		// final acceptance with Stirling's approximation
+		x1 := y + 1
+		f1 := m + 1
+		z := n + 1 - m
+		w := n - y + 1
+		if logV > b.xm*math.Log(f1/x1)+(n-m+0.5)*math.Log(z/w)+(y-m)*math.Log(w*r/(x1*q))+
+			stirlingTail(f1)+stirlingTail(z)+stirlingTail(x1)+stirlingTail(w) {
+			continue
+		}
+		return uint64(y)
+	}
+}
+
+// stirlingTail is the correction term of Stirling's series for log(x!)
+func stirlingTail(x float64) float64 {
+	x2 := x * x
+	return (13680 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
+
+// Thin binomially thins each counter, keeping each counted event independently with probability p,
+// so countsOut[i] ~ Binomial(countsIn[i], p), p must be in [0, 1]. For metrics pipelines that
+// downsample at ingest.
+func Thin(r UnsafeRNG, countsIn []uint64, p float64) []uint64 {
+	countsOut := make([]uint64, len(countsIn))
+	for i, n := range countsIn {
+		countsOut[i] = Binomial(r, n, p)
+	}
+	return countsOut
+}
+
+// Thin binomially thins a vector of counters using a single pool checkout, see Thin
+func (s *ThreadsafePoolRNG) Thin(countsIn []uint64, p float64) []uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	countsOut := Thin(r, countsIn, p)
+	rngPool.Put(r)
+	return countsOut
+}
@@ -0,0 +1,131 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Thin(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	countsIn := make([]uint64, 20000)
+	for i := range countsIn {
+		countsIn[i] = 100
+	}
+	countsOut := Thin(NewUnsafeXoshiro256ssRNG(1), countsIn, 0.3)
+	assert.Len(t, countsOut, len(countsIn))
+
+	xs := make([]float64, len(countsOut))
+	for i, c := range countsOut {
+		assert.LessOrEqual(t, c, uint64(100))
+		xs[i] = float64(c)
+	}
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 30, mean, 0.1)
+	assert.InDelta(t, 21, variance, 0.6)
+
+	assert.Equal(t, []uint64{0, 5, 1000}, rng.Thin([]uint64{0, 5, 1000}, 1))
+	assert.Equal(t, []uint64{0, 0, 0}, rng.Thin([]uint64{0, 5, 1000}, 0))
+}
+
+func Test_Binomial_LargeMean(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for _, p := range []float64{0.01, 0.5, 0.9} {
+		xs := make([]float64, 4000)
+		for i := range xs {
+			xs[i] = float64(Binomial(r, 100000, p))
+		}
+		mean, variance := meanAndVariance(xs)
+		assert.InEpsilon(t, 100000*p, mean, 0.002)
+		assert.InEpsilon(t, 100000*p*(1-p), variance, 0.1)
+	}
+}
+
+// binomialChiSquare bins draws of Binomial(n, p) against the exact pmf, merging sparse tail bins,
+// and returns the chi square statistic and its degrees of freedom
+func binomialChiSquare(r UnsafeRNG, n uint64, p float64, draws int) (float64, int) {
+	observed := make([]float64, n+1)
+	for i := 0; i < draws; i++ {
+		observed[Binomial(r, n, p)]++
+	}
+	lgN, _ := math.Lgamma(float64(n) + 1)
+	stat, df := 0.0, -1
+	expectedBin, observedBin := 0.0, 0.0
+	for k := uint64(0); k <= n; k++ {
+		lgK, _ := math.Lgamma(float64(k) + 1)
+		lgNK, _ := math.Lgamma(float64(n-k) + 1)
+		pmf := math.Exp(lgN - lgK - lgNK + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+		expectedBin += pmf * float64(draws)
+		observedBin += observed[k]
+		if expectedBin >= 20 || k == n {
+			stat += (observedBin - expectedBin) * (observedBin - expectedBin) / expectedBin
+			df++
+			expectedBin, observedBin = 0, 0
+		}
+	}
+	return stat, df
+}
+
+func Test_Binomial_Distribution(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(3)
+	for _, c := range []struct {
+		n uint64
+		p float64
+	}{{20, 0.3}, {100, 0.4}, {1000, 0.05}, {5000, 0.7}, {200000, 0.5}} {
+		stat, df := binomialChiSquare(r, c.n, c.p, 200000)
+		// roughly a 4 sigma bound on a chi square with df degrees of freedom
+		assert.Less(t, stat, float64(df)+4*math.Sqrt(2*float64(df)), c)
+	}
+}
+
+func Benchmark_UnsafeBinomial_Large(b *testing.B) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	var x uint64
+	for i := 0; i < b.N; i++ {
+		x = Binomial(r, 1000000, 0.3)
+	}
+	BenchSink = &x
+}
+
+func Benchmark_BinomialSampler_Large(b *testing.B) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	sampler := NewBinomialSampler(1000000, 0.3)
+	var x uint64
+	for i := 0; i < b.N; i++ {
+		x = sampler.Sample(r)
+	}
+	BenchSink = &x
+}
+
+func Test_BinomialSampler(t *testing.T) {
+	for _, c := range []struct {
+		n uint64
+		p float64
+	}{{0, 0.5}, {10, 0}, {10, 1}, {20, 0.3}, {20, 0.8}, {1000, 0.4}, {1000, 0.95}} {
+		b := NewBinomialSampler(c.n, c.p)
+		r1, r2 := NewUnsafeXoshiro256ssRNG(1), NewUnsafeXoshiro256ssRNG(1)
+		for i := 0; i < 100; i++ {
+			assert.Equal(t, Binomial(r1, c.n, c.p), b.Sample(r2), c)
+		}
+	}
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	assert.LessOrEqual(t, rng.Binomial(100, 0.5), uint64(100))
+	assert.LessOrEqual(t, rng.SampleBinomial(NewBinomialSampler(100, 0.5)), uint64(100))
+	r := NewUnsafeXoshiro256ssRNG(1)
+	allocs := testing.AllocsPerRun(100, func() {
+		Binomial(r, 1000, 0.4)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func Test_Binomial_InvalidP(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, p := range []float64{math.NaN(), -0.1, 1.1, math.Inf(1), math.Inf(-1)} {
+		assert.Panics(t, func() { Binomial(NewUnsafeXoshiro256ssRNG(1), 10, p) }, p)
+		assert.Panics(t, func() { NewBinomialSampler(10, p) }, p)
+		assert.Panics(t, func() { rng.Binomial(10, p) }, p)
+		assert.Panics(t, func() { rng.Thin([]uint64{10}, p) }, p)
+	}
+}
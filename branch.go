@@ -0,0 +1,120 @@
+package fastrand64
+
+// Branch is for speculative simulation, eg AI lookahead or MCTS rollouts, where a game state and
+// its generator are forked thousands of times a second and each fork must draw its own future.
+// A branch is a new generator of the same algorithm seeded from one draw of the parent, remixed
+// through branchSeed, so the parent's stream moves on by a single value and the branch's stream is
+// unrelated to it. The small state generators below copy their whole state in a few words, so a
+// branch costs one draw and a seeding. Branching is deterministic, the same parent state always
+// branches the same way, so a replayed search makes the same forks.
+
+// branchSeed remixes a parent's draw into a branch seed, its own splitmix64 domain so a branch
+// isn't the generator Seed(draw) would make
+func branchSeed(draw uint64) int64 {
+	return int64(Splitmix64(draw ^ 0x6272616e63682121))
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeXoshiro256ssRNG) Branch() *UnsafeXoshiro256ssRNG {
+	b := &UnsafeXoshiro256ssRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeXoshiro256ppRNG) Branch() *UnsafeXoshiro256ppRNG {
+	b := &UnsafeXoshiro256ppRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeXoroshiro128ppRNG) Branch() *UnsafeXoroshiro128ppRNG {
+	b := &UnsafeXoroshiro128ppRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeXoroshiro64ssRNG) Branch() *UnsafeXoroshiro64ssRNG {
+	b := &UnsafeXoroshiro64ssRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeSplitmix64RNG) Branch() *UnsafeSplitmix64RNG {
+	b := &UnsafeSplitmix64RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeSquaresRNG) Branch() *UnsafeSquaresRNG {
+	b := &UnsafeSquaresRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeRomuDuoJrRNG) Branch() *UnsafeRomuDuoJrRNG {
+	b := &UnsafeRomuDuoJrRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeRomuTrioRNG) Branch() *UnsafeRomuTrioRNG {
+	b := &UnsafeRomuTrioRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafePcg32RNG) Branch() *UnsafePcg32RNG {
+	b := &UnsafePcg32RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafePcg64RNG) Branch() *UnsafePcg64RNG {
+	b := &UnsafePcg64RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafePcg64DxsmRNG) Branch() *UnsafePcg64DxsmRNG {
+	b := &UnsafePcg64DxsmRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeLehmer128RNG) Branch() *UnsafeLehmer128RNG {
+	b := &UnsafeLehmer128RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeJsf64RNG) Branch() *UnsafeJsf64RNG {
+	b := &UnsafeJsf64RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeKiss64RNG) Branch() *UnsafeKiss64RNG {
+	b := &UnsafeKiss64RNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
+
+// Branch returns an independent generator forked from r, (not thread safe)
+func (r *UnsafeGjrandRNG) Branch() *UnsafeGjrandRNG {
+	b := &UnsafeGjrandRNG{}
+	b.Seed(branchSeed(r.Uint64()))
+	return b
+}
@@ -0,0 +1,106 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Branch(t *testing.T) {
+	// each case makes a parent and a function branching it
+	for name, fork := range map[string]func() (UnsafeRNG, func() UnsafeRNG){
+		"xoshiro256ss": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeXoshiro256ssRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"xoshiro256pp": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeXoshiro256ppRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"xoroshiro128pp": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeXoroshiro128ppRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"xoroshiro64ss": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeXoroshiro64ssRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"splitmix64": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeSplitmix64RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"squares": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeSquaresRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"romuduojr": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeRomuDuoJrRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"romutrio": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeRomuTrioRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"pcg32": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafePcg32RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"pcg64": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafePcg64RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"pcg64dxsm": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafePcg64DxsmRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"lehmer128": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeLehmer128RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"jsf64": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeJsf64RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"kiss64": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeKiss64RNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+		"gjrand": func() (UnsafeRNG, func() UnsafeRNG) {
+			r := NewUnsafeGjrandRNG(1)
+			return r, func() UnsafeRNG { return r.Branch() }
+		},
+	} {
+		parent, branch := fork()
+		replay, _ := fork()
+		first := branch()
+		// the parent moves on by one draw
+		replay.Uint64()
+		assert.Equal(t, replay.Uint64(), parent.Uint64(), name)
+
+		// branching is deterministic
+		_, branchAgain := fork()
+		assert.Equal(t, first.Uint64(), branchAgain().Uint64(), name)
+
+		// two branches, and a branch and its parent, don't share a stream
+		second := branch()
+		var a, b, c []float64
+		for i := 0; i < 10000; i++ {
+			a = append(a, unitFloat64(first))
+			b = append(b, unitFloat64(second))
+			c = append(c, unitFloat64(parent))
+		}
+		assert.Less(t, math.Abs(correlation(a, b)), 0.05, name)
+		assert.Less(t, math.Abs(correlation(a, c)), 0.05, name)
+	}
+}
+
+func correlation(xs []float64, ys []float64) float64 {
+	mx, vx := meanAndVariance(xs)
+	my, vy := meanAndVariance(ys)
+	cov := 0.0
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+	}
+	return cov / float64(len(xs)-1) / math.Sqrt(vx*vy)
+}
@@ -0,0 +1,42 @@
+package fastrand64
+
+import "bytes"
+
+// AppendRandom appends n random bytes from a thread unsafe RNG to dst and returns the extended
+// slice, growing it like append. The bytes are the same as Bytes filling an n byte slice, and
+// there is no allocation when dst has the capacity.
+func AppendRandom(dst []byte, r UnsafeRNG, n int) []byte {
+	// the compiler grows dst in place for this form, without allocating the zeroed slice
+	dst = append(dst, make([]byte, n)...)
+	Bytes(r, dst[len(dst)-n:])
+	return dst
+}
+
+// AppendRandom appends n random bytes to dst using a single pool checkout
+func (s *ThreadsafePoolRNG) AppendRandom(dst []byte, n int) []byte {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	dst = AppendRandom(dst, r, n)
+	rngPool.Put(r)
+	return dst
+}
+
+// FillBuffer appends n random bytes from a thread unsafe RNG to b, generated straight into b's
+// storage. For a bufio.Writer, io.Copy from a RandomStream, which writes whole chunks, is the
+// equivalent.
+func FillBuffer(b *bytes.Buffer, r UnsafeRNG, n int) {
+	b.Grow(n)
+	// after Grow the n bytes past the unread ones are b's own storage, so fill them in place, then
+	// Write, copying them onto themselves, only extends the length
+	tail := b.Bytes()[b.Len() : b.Len()+n]
+	Bytes(r, tail)
+	b.Write(tail)
+}
+
+// FillBuffer appends n random bytes to b using a single pool checkout
+func (s *ThreadsafePoolRNG) FillBuffer(b *bytes.Buffer, n int) {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	FillBuffer(b, r, n)
+	rngPool.Put(r)
+}
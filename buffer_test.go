@@ -0,0 +1,48 @@
+package fastrand64
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendRandom(t *testing.T) {
+	dst := AppendRandom([]byte("header:"), NewUnsafeXoshiro256ssRNG(1), 21)
+	assert.Equal(t, append([]byte("header:"), Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 21))...), dst)
+
+	// no allocation with the capacity
+	r := NewUnsafeXoshiro256ssRNG(1)
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendRandom(buf[:0], r, 1000)
+	})
+	assert.Equal(t, 0.0, allocs)
+	assert.Len(t, buf, 1000)
+
+	assert.Len(t, NewSyncPoolXoshiro256ssRNG().AppendRandom(nil, 100), 100)
+}
+
+func Test_FillBuffer(t *testing.T) {
+	var b bytes.Buffer
+	b.WriteString("header:")
+	FillBuffer(&b, NewUnsafeXoshiro256ssRNG(1), 21)
+	assert.Equal(t, append([]byte("header:"), Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 21))...), b.Bytes())
+
+	// with read bytes ahead of the unread ones
+	head := make([]byte, 3)
+	b.Read(head)
+	FillBuffer(&b, NewUnsafeXoshiro256ssRNG(2), 5)
+	assert.Equal(t, 7-3+21+5, b.Len())
+	assert.Equal(t, Bytes(NewUnsafeXoshiro256ssRNG(2), make([]byte, 5)), b.Bytes()[b.Len()-5:])
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	b.Reset()
+	b.Grow(1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		b.Reset()
+		rng.FillBuffer(&b, 1000)
+	})
+	assert.Equal(t, 0.0, allocs)
+	assert.Equal(t, 1000, b.Len())
+}
@@ -0,0 +1,145 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// UnsafeChaCha8RNG is the ChaCha8 generator Go 1.22 adopted for its runtime and math/rand/v2, for
+// when you want fast but much harder to predict. Blocks are produced 4 at a time and buffered,
+// and every 4th refill the generator rekeys itself from its own output so a leaked state doesn't
+// reveal earlier values. Setting the same seed as rand.NewChaCha8 gives the same stream.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://c2sp.org/chacha8rand
+type UnsafeChaCha8RNG struct {
+	buf  [32]uint64
+	seed [4]uint64
+	i    int // next word of buf
+	n    int // words of buf available
+	c    uint32
+}
+
+const (
+	chacha8CtrInc = 4  // blocks per refill
+	chacha8CtrMax = 16 // rekey when the block counter reaches this
+	chacha8Reseed = 4  // words of the last buffer kept back as the new key
+)
+
+// SetState sets the 32 byte seed, like rand.NewChaCha8(seed)
+func (r *UnsafeChaCha8RNG) SetState(seed [32]byte) {
+	for i := range r.seed {
+		r.seed[i] = binary.LittleEndian.Uint64(seed[8*i:])
+	}
+	chacha8Block(&r.seed, &r.buf, 0)
+	r.c = 0
+	r.i = 0
+	r.n = len(r.buf)
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to fill the 32 byte seed
+func (r *UnsafeChaCha8RNG) Seed(seed int64) {
+	var ss UnsafeXoshiro256ssRNG
+	ss.Seed(seed)
+	var key [32]byte
+	for i, x := range []uint64{ss.s0, ss.s1, ss.s2, ss.s3} {
+		binary.LittleEndian.PutUint64(key[8*i:], x)
+	}
+	r.SetState(key)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeChaCha8RNG) Uint64() uint64 {
+	if r.i >= r.n {
+		r.refill()
+	}
+	x := r.buf[r.i]
+	r.i++
+	return x
+}
+
+func (r *UnsafeChaCha8RNG) refill() {
+	r.c += chacha8CtrInc
+	if r.c == chacha8CtrMax {
+		// rekey from the words the previous buffer held back, for forward secrecy
+		copy(r.seed[:], r.buf[len(r.buf)-chacha8Reseed:])
+		r.c = 0
+	}
+	chacha8Block(&r.seed, &r.buf, r.c)
+	r.i = 0
+	r.n = len(r.buf)
+	if r.c == chacha8CtrMax-chacha8CtrInc {
+		r.n = len(r.buf) - chacha8Reseed
+	}
+}
+
+// NewUnsafeChaCha8RNG creates a new Thread unsafe ChaCha8 generator
+func NewUnsafeChaCha8RNG(seed int64) *UnsafeChaCha8RNG {
+	noteSeed(seed)
+	r := &UnsafeChaCha8RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// chacha8Block computes the 4 ChaCha8 blocks for counters counter..counter+3 under seed, with the
+// blocks interleaved word by word the way Go's chacha8rand lays them out
+func chacha8Block(seed *[4]uint64, buf *[32]uint64, counter uint32) {
+	var b [16][4]uint32
+	for lane := 0; lane < 4; lane++ {
+		b[0][lane] = 0x61707865 // "expand 32-byte k"
+		b[1][lane] = 0x3320646e
+		b[2][lane] = 0x79622d32
+		b[3][lane] = 0x6b206574
+		for i, x := range seed {
+			b[4+2*i][lane] = uint32(x)
+			b[5+2*i][lane] = uint32(x >> 32)
+		}
+		b[12][lane] = counter + uint32(lane)
+	}
+
+	for lane := 0; lane < 4; lane++ {
+		var x [16]uint32
+		for i := range x {
+			x[i] = b[i][lane]
+		}
+		for round := 0; round < 4; round++ {
+			x[0], x[4], x[8], x[12] = chachaQuarterRound(x[0], x[4], x[8], x[12])
+			x[1], x[5], x[9], x[13] = chachaQuarterRound(x[1], x[5], x[9], x[13])
+			x[2], x[6], x[10], x[14] = chachaQuarterRound(x[2], x[6], x[10], x[14])
+			x[3], x[7], x[11], x[15] = chachaQuarterRound(x[3], x[7], x[11], x[15])
+
+			x[0], x[5], x[10], x[15] = chachaQuarterRound(x[0], x[5], x[10], x[15])
+			x[1], x[6], x[11], x[12] = chachaQuarterRound(x[1], x[6], x[11], x[12])
+			x[2], x[7], x[8], x[13] = chachaQuarterRound(x[2], x[7], x[8], x[13])
+			x[3], x[4], x[9], x[14] = chachaQuarterRound(x[3], x[4], x[9], x[14])
+		}
+		// only the key words are added back, the constants and counter carry no entropy
+		for i := range x {
+			if i >= 4 && i < 12 {
+				b[i][lane] += x[i]
+			} else {
+				b[i][lane] = x[i]
+			}
+		}
+	}
+
+	for k := range buf {
+		j := 2 * k
+		buf[k] = uint64(b[j/4][j%4]) | uint64(b[(j+1)/4][(j+1)%4])<<32
+	}
+}
+
+func chachaQuarterRound(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d ^= a
+	d = bits.RotateLeft32(d, 16)
+	c += d
+	b ^= c
+	b = bits.RotateLeft32(b, 12)
+	a += b
+	d ^= a
+	d = bits.RotateLeft32(d, 8)
+	c += d
+	b ^= c
+	b = bits.RotateLeft32(b, 7)
+	return a, b, c, d
+}
@@ -0,0 +1,23 @@
+//go:build go1.22
+// +build go1.22
+
+package fastrand64
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeChaCha8RNG_MathRandV2(t *testing.T) {
+	var seed [32]byte
+	copy(seed[:], "chacha8rand example seed 0123456")
+	expected := rand.NewChaCha8(seed)
+	rng := &UnsafeChaCha8RNG{}
+	rng.SetState(seed)
+	// several rekeys in
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, expected.Uint64(), rng.Uint64())
+	}
+}
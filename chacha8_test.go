@@ -0,0 +1,32 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeChaCha8RNG_Uint64(t *testing.T) {
+	// from math/rand/v2 NewChaCha8, the 122nd and later values come after the first rekey
+	var seed [32]byte
+	copy(seed[:], "chacha8rand example seed 0123456")
+	rng := &UnsafeChaCha8RNG{}
+	rng.SetState(seed)
+	expected := map[int]uint64{
+		0: 370611883011270976, 1: 2000405037922697302, 2: 8266354783693395249, 3: 17945069891721565711,
+		122: 1669938380135761570, 123: 13361708349351048555, 124: 788162291237419121, 125: 11077895508623838074,
+	}
+	for i := 0; i < 126; i++ {
+		x := rng.Uint64()
+		if e, ok := expected[i]; ok {
+			assert.Equal(t, e, x, i)
+		}
+	}
+
+	rng1 := NewUnsafeChaCha8RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeChaCha8RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeChaCha8RNG(1).Uint64(), NewUnsafeChaCha8RNG(2).Uint64())
+}
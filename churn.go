@@ -0,0 +1,160 @@
+package fastrand64
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"math"
+	"net"
+	"time"
+)
+
+// ChurnEventKind says whether an endpoint joined or left
+type ChurnEventKind int
+
+const (
+	// ChurnJoin is an endpoint appearing on the network
+	ChurnJoin ChurnEventKind = iota
+	// ChurnLeave is an endpoint disappearing
+	ChurnLeave
+)
+
+// ChurnEvent is one join or leave of an endpoint
+type ChurnEvent struct {
+	Time time.Time
+	Kind ChurnEventKind
+	MAC  net.HardwareAddr
+	IP   net.IP
+}
+
+// ChurnOptions configures a ChurnSimulator
+type ChurnOptions struct {
+	Seed  int64
+	Start time.Time
+	// Fleet endpoints join at Start, then new endpoints arrive as a Poisson process of ArrivalRate per second
+	Fleet       int
+	ArrivalRate float64
+	// Lifetime draws how long an endpoint stays, it defaults to exponential with mean MeanLifetime
+	Lifetime     func(r UnsafeRNG) time.Duration
+	MeanLifetime time.Duration
+	// Subnet endpoints get addresses in, defaults to 10.0.0.0/8. IPv4 only. The network and
+	// broadcast addresses are skipped, except in a /31, whose two addresses are both hosts like a
+	// point to point link (RFC 3021), and a /32, its one address
+	Subnet *net.IPNet
+}
+
+// ChurnSimulator generates a time ordered stream of endpoint join and leave events with random
+// locally administered MACs and addresses, the same options (including Seed) always produce the same
+// stream. It is NOT safe for concurrent use.
+type ChurnSimulator struct {
+	opts        ChurnOptions
+	r           *UnsafeXoshiro256ssRNG
+	pending     churnQueue
+	nextArrival time.Time
+	// active counts the endpoints holding each address, more than one once a full subnet reuses it
+	active       map[uint32]int
+	first, count uint32 // the usable addresses
+}
+
+// NewChurnSimulator returns a churn simulator, with the initial fleet queued to join at Start. It
+// panics if Subnet isn't IPv4
+func NewChurnSimulator(opts ChurnOptions) *ChurnSimulator {
+	if opts.Subnet == nil {
+		_, opts.Subnet, _ = net.ParseCIDR("10.0.0.0/8")
+	}
+	ones, bits := opts.Subnet.Mask.Size()
+	if opts.Subnet.IP.To4() == nil || bits != 32 {
+		panic("ChurnSimulator subnet " + opts.Subnet.String() + " must be IPv4")
+	}
+	if opts.Lifetime == nil {
+		mean := float64(opts.MeanLifetime)
+		opts.Lifetime = func(r UnsafeRNG) time.Duration {
+			return time.Duration(-mean * math.Log(1-unitFloat64(r)))
+		}
+	}
+	c := &ChurnSimulator{
+		opts:   opts,
+		r:      NewUnsafeXoshiro256ssRNG(opts.Seed),
+		active: map[uint32]int{},
+		first:  binary.BigEndian.Uint32(opts.Subnet.IP.To4()),
+	}
+	size := uint64(1) << uint(bits-ones)
+	if size <= 2 {
+		c.count = uint32(size)
+	} else {
+		c.first++
+		c.count = uint32(size - 2)
+	}
+	for i := 0; i < opts.Fleet; i++ {
+		c.join(opts.Start)
+	}
+	c.nextArrival = c.arrivalAfter(opts.Start)
+	return c
+}
+
+// Next returns the next event, false once no event can ever happen again
+// (everyone left and ArrivalRate is 0)
+func (c *ChurnSimulator) Next() (ChurnEvent, bool) {
+	if c.opts.ArrivalRate > 0 && (len(c.pending) == 0 || c.nextArrival.Before(c.pending[0].Time)) {
+		c.join(c.nextArrival)
+		c.nextArrival = c.arrivalAfter(c.nextArrival)
+	}
+	if len(c.pending) == 0 {
+		return ChurnEvent{}, false
+	}
+	e := heap.Pop(&c.pending).(ChurnEvent)
+	if e.Kind == ChurnJoin {
+		// the leave is queued behind the join
+		leave := e
+		leave.Kind = ChurnLeave
+		leave.Time = e.Time.Add(c.opts.Lifetime(c.r))
+		heap.Push(&c.pending, leave)
+	} else {
+		// only the last holder frees the address, an earlier holder leaving doesn't free it under a later one
+		addr := binary.BigEndian.Uint32(e.IP)
+		if c.active[addr]--; c.active[addr] <= 0 {
+			delete(c.active, addr)
+		}
+	}
+	return e, true
+}
+
+func (c *ChurnSimulator) arrivalAfter(t time.Time) time.Time {
+	if c.opts.ArrivalRate <= 0 {
+		return t
+	}
+	return t.Add(time.Duration(-math.Log(1-unitFloat64(c.r)) / c.opts.ArrivalRate * float64(time.Second)))
+}
+
+func (c *ChurnSimulator) join(t time.Time) {
+	mac := make(net.HardwareAddr, 6)
+	Bytes(c.r, mac)
+	mac[0] = mac[0]&0xfe | 0x02 // unicast, locally administered
+
+	// avoid handing out an address that is in use, unless the subnet is too full to find one quickly
+	var addr uint32
+	for try := 0; try < 64; try++ {
+		addr = c.first + uint32(uint64n(c.r, uint64(c.count)))
+		if c.active[addr] == 0 {
+			break
+		}
+	}
+	c.active[addr]++
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, addr)
+
+	heap.Push(&c.pending, ChurnEvent{Time: t, Kind: ChurnJoin, MAC: mac, IP: ip})
+}
+
+// churnQueue is a min heap of events by time
+type churnQueue []ChurnEvent
+
+func (q churnQueue) Len() int            { return len(q) }
+func (q churnQueue) Less(i, j int) bool  { return q[i].Time.Before(q[j].Time) }
+func (q churnQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *churnQueue) Push(x interface{}) { *q = append(*q, x.(ChurnEvent)) }
+func (q *churnQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
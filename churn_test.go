@@ -0,0 +1,116 @@
+package fastrand64
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChurnSimulator(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, subnet, _ := net.ParseCIDR("192.168.0.0/16")
+	opts := ChurnOptions{Seed: 9, Start: start, Fleet: 100, ArrivalRate: 1, MeanLifetime: 100 * time.Second, Subnet: subnet}
+	c1, c2 := NewChurnSimulator(opts), NewChurnSimulator(opts)
+
+	active := map[string]bool{}
+	last := start
+	joins, leaves := 0, 0
+	for i := 0; i < 20000; i++ {
+		e, ok := c1.Next()
+		assert.True(t, ok)
+		e2, _ := c2.Next()
+		assert.Equal(t, e, e2)
+
+		assert.False(t, e.Time.Before(last))
+		last = e.Time
+		assert.True(t, subnet.Contains(e.IP))
+		assert.Equal(t, byte(0x02), e.MAC[0]&0x03)
+		switch e.Kind {
+		case ChurnJoin:
+			joins++
+			assert.False(t, active[e.MAC.String()])
+			active[e.MAC.String()] = true
+		case ChurnLeave:
+			leaves++
+			assert.True(t, active[e.MAC.String()])
+			delete(active, e.MAC.String())
+		}
+	}
+	assert.InDelta(t, joins, leaves, 300)
+	// by Little's law the steady state fleet is rate * mean lifetime = 100
+	assert.InDelta(t, 100, len(active), 40)
+	assert.InDelta(t, 10000, last.Sub(start).Seconds(), 1000)
+}
+
+func Test_ChurnSimulator_Drains(t *testing.T) {
+	c := NewChurnSimulator(ChurnOptions{
+		Fleet:    3,
+		Lifetime: func(r UnsafeRNG) time.Duration { return time.Minute },
+	})
+	kinds := []ChurnEventKind{}
+	for {
+		e, ok := c.Next()
+		if !ok {
+			break
+		}
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []ChurnEventKind{ChurnJoin, ChurnJoin, ChurnJoin, ChurnLeave, ChurnLeave, ChurnLeave}, kinds)
+}
+
+func Test_ChurnSimulator_SmallSubnets(t *testing.T) {
+	for cidr, want := range map[string][]string{
+		"192.168.1.4/31": {"192.168.1.4", "192.168.1.5"}, // both hosts, RFC 3021
+		"192.168.1.4/32": {"192.168.1.4"},
+		"192.168.1.4/30": {"192.168.1.5", "192.168.1.6"}, // not the network or broadcast address
+	} {
+		_, subnet, _ := net.ParseCIDR(cidr)
+		c := NewChurnSimulator(ChurnOptions{Seed: 1, Fleet: 50, MeanLifetime: time.Minute, Subnet: subnet})
+		seen := map[string]bool{}
+		for {
+			e, ok := c.Next()
+			if !ok {
+				break
+			}
+			seen[e.IP.String()] = true
+		}
+		got := []string{}
+		for ip := range seen {
+			got = append(got, ip)
+		}
+		assert.ElementsMatch(t, want, got, cidr)
+	}
+}
+
+func Test_ChurnSimulator_IPv6(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("2001:db8::/64")
+	assert.Panics(t, func() { NewChurnSimulator(ChurnOptions{Fleet: 1, Subnet: subnet}) })
+}
+
+func Test_ChurnSimulator_LeaveAfterReuse(t *testing.T) {
+	// a /32 holds one address, so the second endpoint reuses it, and the first leaving mustn't free it
+	_, subnet, _ := net.ParseCIDR("10.1.2.3/32")
+	lifetimes := []time.Duration{time.Minute, time.Hour}
+	c := NewChurnSimulator(ChurnOptions{Fleet: 2, Subnet: subnet, Lifetime: func(r UnsafeRNG) time.Duration {
+		d := lifetimes[0]
+		lifetimes = lifetimes[1:]
+		return d
+	}})
+	addr := uint32(10<<24 | 1<<16 | 2<<8 | 3)
+	assert.Equal(t, 2, c.active[addr])
+
+	kinds := []ChurnEventKind{}
+	for len(kinds) < 3 {
+		e, _ := c.Next()
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []ChurnEventKind{ChurnJoin, ChurnJoin, ChurnLeave}, kinds)
+	assert.Equal(t, 1, c.active[addr])
+
+	e, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, ChurnLeave, e.Kind)
+	assert.Len(t, c.active, 0)
+}
@@ -0,0 +1,85 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// circularMean returns the mean direction and mean resultant length of angles
+func circularMean(angles []float64) (float64, float64) {
+	var c, s float64
+	for _, a := range angles {
+		c += math.Cos(a)
+		s += math.Sin(a)
+	}
+	n := float64(len(angles))
+	return math.Atan2(s, c), math.Hypot(c, s) / n
+}
+
+// besselI returns the modified bessel function of the first kind I_n(x) by its power series
+func besselI(n int, x float64) float64 {
+	term := math.Pow(x/2, float64(n))
+	for k := 1; k <= n; k++ {
+		term /= float64(k)
+	}
+	sum := term
+	for k := 1; k < 200 && term > sum*1e-17; k++ {
+		term *= (x * x / 4) / (float64(k) * float64(k+n))
+		sum += term
+	}
+	return sum
+}
+
+func Test_VonMises(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, c := range []struct{ mu, kappa float64 }{{0, 0.5}, {1, 2}, {-3, 10}, {3.1, 100}} {
+		d, err := NewVonMises(c.mu, c.kappa)
+		assert.NoError(t, err)
+		xs := rng.FillDist(d, make([]float64, 100000))
+		for _, x := range xs {
+			assert.True(t, x >= -math.Pi && x < math.Pi)
+		}
+		mean, length := circularMean(xs)
+		expected := besselI(1, c.kappa) / besselI(0, c.kappa)
+		assert.InDelta(t, 0, wrapAngle(mean-c.mu), 5/(expected*math.Sqrt(float64(len(xs)))), c)
+		assert.InDelta(t, expected, length, 0.01, c)
+	}
+
+	d, err := NewVonMises(0, 0)
+	assert.NoError(t, err)
+	_, length := circularMean(rng.FillDist(d, make([]float64, 100000)))
+	assert.InDelta(t, 0, length, 0.01)
+
+	for _, kappa := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err := NewVonMises(0, kappa)
+		assert.Error(t, err)
+	}
+}
+
+func Test_WrappedNormal(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, c := range []struct{ mu, sigma float64 }{{0, 0.3}, {2.5, 1}, {-2, 2}} {
+		d, err := NewWrappedNormal(c.mu, c.sigma)
+		assert.NoError(t, err)
+		xs := rng.FillDist(d, make([]float64, 100000))
+		for _, x := range xs {
+			assert.True(t, x >= -math.Pi && x < math.Pi)
+		}
+		mean, length := circularMean(xs)
+		expected := math.Exp(-c.sigma * c.sigma / 2)
+		assert.InDelta(t, 0, wrapAngle(mean-c.mu), 5/(expected*math.Sqrt(float64(len(xs)))), c)
+		assert.InDelta(t, expected, length, 0.01, c)
+	}
+
+	_, err := NewWrappedNormal(0, -1)
+	assert.Error(t, err)
+}
+
+func Test_wrapAngle(t *testing.T) {
+	assert.InDelta(t, 0, wrapAngle(2*math.Pi), 1e-12)
+	assert.InDelta(t, -math.Pi, wrapAngle(math.Pi), 1e-12)
+	assert.InDelta(t, -math.Pi/2, wrapAngle(3*math.Pi/2), 1e-12)
+	assert.InDelta(t, 1, wrapAngle(1-20*math.Pi), 1e-12)
+}
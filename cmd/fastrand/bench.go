@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/villenny/fastrand64-go"
+)
+
+// benchResult is one generator's timings
+type benchResult struct {
+	algorithm fastrand64.Algorithm
+	nsPerOp   float64 // per Uint64
+	mbPerSec  float64 // filling 1024 byte buffers with Bytes
+}
+
+// benchCommand times every registered generator on this machine and prints a comparison table,
+// so benchmark numbers can be reproduced rather than pasted
+func benchCommand(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "markdown", "output format, markdown or csv")
+	names := flags.String("algorithms", "", "comma separated algorithms to run, default all")
+	duration := flags.Duration("duration", 200*time.Millisecond, "time to spend on each measurement")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("bench takes no arguments, got %q", flags.Args())
+	}
+	if *format != "markdown" && *format != "csv" {
+		return fmt.Errorf("unknown -format %q, want markdown or csv", *format)
+	}
+	if *duration <= 0 {
+		return fmt.Errorf("-duration must be > 0")
+	}
+
+	algorithms := fastrand64.Algorithms()
+	if *names != "" {
+		algorithms = nil
+		for _, name := range strings.Split(*names, ",") {
+			a, err := fastrand64.LookupAlgorithm(strings.TrimSpace(name))
+			if err != nil {
+				return err
+			}
+			algorithms = append(algorithms, a)
+		}
+	}
+
+	var results []benchResult
+	for _, a := range algorithms {
+		results = append(results, benchAlgorithm(a, *duration))
+	}
+	if *format == "csv" {
+		return writeBenchCSV(stdout, results)
+	}
+	writeBenchMarkdown(stdout, results)
+	return nil
+}
+
+// benchSink keeps the compiler from optimizing the benchmark loops away
+var benchSink uint64
+
+func benchAlgorithm(a fastrand64.Algorithm, duration time.Duration) benchResult {
+	r := a.New(time.Now().UnixNano())
+	nsPerOp := measure(duration, func(n int) {
+		var x uint64
+		for i := 0; i < n; i++ {
+			x += r.Uint64()
+		}
+		benchSink += x
+	})
+	buf := make([]byte, 1024)
+	nsPerFill := measure(duration, func(n int) {
+		for i := 0; i < n; i++ {
+			fastrand64.Bytes(r, buf)
+		}
+		benchSink += uint64(buf[0])
+	})
+	return benchResult{algorithm: a, nsPerOp: nsPerOp, mbPerSec: float64(len(buf)) / nsPerFill * 1e3}
+}
+
+// measure runs fn with growing n, like testing.B, until one run takes duration, and returns the
+// time per iteration of that run
+func measure(duration time.Duration, fn func(n int)) float64 {
+	n := 1
+	for {
+		start := time.Now()
+		fn(n)
+		elapsed := time.Since(start)
+		if elapsed >= duration || n >= 1<<30 {
+			return float64(elapsed.Nanoseconds()) / float64(n)
+		}
+		next := 100 * n
+		if elapsed > 0 {
+			next = int(1.2 * float64(n) * float64(duration) / float64(elapsed))
+		}
+		if next > 100*n {
+			next = 100 * n
+		}
+		if next <= n {
+			next = n + 1
+		}
+		n = next
+	}
+}
+
+func writeBenchMarkdown(w io.Writer, results []benchResult) {
+	fmt.Fprintf(w, "%s/%s, %s, GOMAXPROCS=%d\n\n", runtime.GOOS, runtime.GOARCH, runtime.Version(), runtime.GOMAXPROCS(0))
+	fmt.Fprintln(w, "| algorithm | speed class | ns/Uint64 | Bytes MB/s |")
+	fmt.Fprintln(w, "|---|---|---:|---:|")
+	for _, r := range results {
+		fmt.Fprintf(w, "| %s | %s | %.2f | %.0f |\n", r.algorithm.Name, r.algorithm.Speed, r.nsPerOp, r.mbPerSec)
+	}
+}
+
+func writeBenchCSV(w io.Writer, results []benchResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"algorithm", "speed_class", "ns_per_uint64", "bytes_mb_per_sec", "goos", "goarch"})
+	for _, r := range results {
+		cw.Write([]string{
+			r.algorithm.Name, r.algorithm.Speed.String(),
+			fmt.Sprintf("%.3f", r.nsPerOp), fmt.Sprintf("%.1f", r.mbPerSec),
+			runtime.GOOS, runtime.GOARCH,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
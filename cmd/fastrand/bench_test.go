@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_benchCommand(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, run([]string{"bench", "-duration", "1ms", "-algorithms", "splitmix64, pcg64"}, &out, ioutil.Discard))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, "| algorithm | speed class | ns/Uint64 | Bytes MB/s |", lines[2])
+	assert.True(t, strings.HasPrefix(lines[4], "| splitmix64 | fastest | "))
+	assert.True(t, strings.HasPrefix(lines[5], "| pcg64 | fast | "))
+
+	out.Reset()
+	assert.NoError(t, run([]string{"bench", "-duration", "1ms", "-format", "csv", "-algorithms", "romutrio"}, &out, ioutil.Discard))
+	records, err := csv.NewReader(&out).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "ns_per_uint64", records[0][2])
+	assert.Equal(t, "romutrio", records[1][0])
+}
+
+func Test_benchCommand_Errors(t *testing.T) {
+	for _, args := range [][]string{
+		{"bench", "extra"},
+		{"bench", "-format", "xml"},
+		{"bench", "-duration", "0s"},
+		{"bench", "-algorithms", "mt19937"},
+	} {
+		assert.Error(t, run(args, ioutil.Discard, ioutil.Discard), args)
+	}
+}
+
+func Test_measure(t *testing.T) {
+	calls := 0
+	ns := measure(50*time.Millisecond, func(n int) {
+		calls++
+		time.Sleep(time.Duration(n) * 100 * time.Microsecond)
+	})
+	assert.Greater(t, calls, 1)
+	assert.GreaterOrEqual(t, ns, 100000.0)
+}
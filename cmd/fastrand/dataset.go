@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/villenny/fastrand64-go"
+)
+
+// columnFlags collects repeated -column flags
+type columnFlags []string
+
+func (c *columnFlags) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *columnFlags) Set(spec string) error {
+	*c = append(*c, spec)
+	return nil
+}
+
+// datasetCommand streams rows of typed random data as CSV, the columns come from -column flags,
+// a -schema JSON file holding a list of fastrand64.DatasetColumn, or both
+func datasetCommand(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("dataset", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var specs columnFlags
+	flags.Var(&specs, "column", "a name:type[:args] column, repeatable, eg age:int:18:90 or tier:choice:free|pro")
+	schema := flags.String("schema", "", "JSON file with a list of {name, type, params, choices, pattern} columns")
+	rows := flags.Int("rows", 1000, "rows to write")
+	algorithm := flags.String("algorithm", "xoshiro256**", "generator, see fastrand bench for the names")
+	seed := flags.Int64("seed", 1, "seed for the generator, the same seed writes the same file")
+	output := flags.String("o", "-", "file to write, - for stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("dataset takes no arguments, got %q", flags.Args())
+	}
+	if *rows < 0 {
+		return fmt.Errorf("-rows %d must not be negative", *rows)
+	}
+
+	var columns []fastrand64.DatasetColumn
+	if *schema != "" {
+		data, err := ioutil.ReadFile(*schema)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &columns); err != nil {
+			return fmt.Errorf("%s: %v", *schema, err)
+		}
+	}
+	for _, spec := range specs {
+		c, err := fastrand64.ParseDatasetColumn(spec)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, c)
+	}
+	d, err := fastrand64.NewDataset(columns)
+	if err != nil {
+		return err
+	}
+	r, err := fastrand64.NewByName(*algorithm, *seed)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	if err := d.WriteCSV(bw, r, *rows); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
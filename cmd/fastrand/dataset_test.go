@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_datasetCommand(t *testing.T) {
+	args := []string{"dataset", "-rows", "3", "-seed", "7", "-column", "id:seq", "-column", "tier:choice:free|pro"}
+	var out bytes.Buffer
+	assert.NoError(t, run(args, &out, ioutil.Discard))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, []string{"id,tier"}, lines[:1])
+	assert.Len(t, lines, 4)
+	for _, line := range lines[1:] {
+		assert.Contains(t, []string{"free", "pro"}, strings.Split(line, ",")[1])
+	}
+
+	// a schema file gives the same columns, and -o writes a file
+	dir, err := ioutil.TempDir("", "dataset")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	schema := filepath.Join(dir, "schema.json")
+	assert.NoError(t, ioutil.WriteFile(schema,
+		[]byte(`[{"name": "id", "type": "seq"}, {"name": "tier", "type": "choice", "choices": ["free", "pro"]}]`), 0644))
+	output := filepath.Join(dir, "out.csv")
+	assert.NoError(t, run([]string{"dataset", "-rows", "3", "-seed", "7", "-schema", schema, "-o", output}, ioutil.Discard, ioutil.Discard))
+	written, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, out.String(), string(written))
+}
+
+func Test_datasetCommand_Errors(t *testing.T) {
+	for _, args := range [][]string{
+		{"dataset"},
+		{"dataset", "extra"},
+		{"dataset", "-column", "id"},
+		{"dataset", "-column", "x:nope"},
+		{"dataset", "-column", "id:seq", "-rows", "-1"},
+		{"dataset", "-column", "id:seq", "-algorithm", "nope"},
+		{"dataset", "-schema", "/does/not/exist.json"},
+	} {
+		assert.Error(t, run(args, ioutil.Discard, ioutil.Discard), args)
+	}
+}
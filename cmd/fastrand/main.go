@@ -0,0 +1,71 @@
+// Command fastrand is a command line front end to the fastrand64 package.
+//
+// Usage:
+//
+//	fastrand <command> [flags]
+//
+// Commands:
+//
+//	bench   time every registered generator and print a Markdown or CSV table
+//	dataset stream rows of typed random data as CSV, from column flags or a JSON schema
+//	seed    generate high entropy seeds, or expand one, into per worker seeds
+//	test    run the statistical battery on a generator, exits 1 if any test fails
+//
+// Run fastrand <command> -h for a command's flags.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	summary string
+	run     func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"bench":   {"time every registered generator and print a Markdown or CSV table", benchCommand},
+	"dataset": {"stream rows of typed random data as CSV, from column flags or a JSON schema", datasetCommand},
+	"seed":    {"generate high entropy seeds, or expand one, into per worker seeds", seedCommand},
+	"test":    {"run the statistical battery on a generator, exits 1 if any test fails", statTestCommand},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "fastrand:", err)
+		if errors.Is(err, errStatTestsFailed) {
+			os.Exit(1)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return fmt.Errorf("no command given")
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: fastrand <command> [flags]")
+	fmt.Fprintln(w, "commands:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].summary)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/villenny/fastrand64-go"
+)
+
+// seedCommand prints the entropy of a SeedSeq, its seed, and the seeds of its children for n
+// workers. Give the same -passphrase or -hex again to reproduce a run's seeds anywhere.
+func seedCommand(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	passphrase := flags.String("passphrase", "", "expand this passphrase instead of generating entropy")
+	hexEntropy := flags.String("hex", "", "expand this hex entropy, eg printed by an earlier run")
+	bits := flags.Int("bits", 256, "bits of entropy to generate")
+	workers := flags.Int("workers", 0, "print child seeds for this many workers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("seed takes no arguments, got %q", flags.Args())
+	}
+	if *passphrase != "" && *hexEntropy != "" {
+		return fmt.Errorf("give at most one of -passphrase and -hex")
+	}
+	if *workers < 0 {
+		return fmt.Errorf("-workers %d must be >= 0", *workers)
+	}
+
+	var entropy []byte
+	switch {
+	case *passphrase != "":
+		entropy = []byte(*passphrase)
+	case *hexEntropy != "":
+		var err error
+		if entropy, err = hex.DecodeString(*hexEntropy); err != nil {
+			return fmt.Errorf("-hex: %v", err)
+		}
+	default:
+		if *bits < 64 || *bits%8 != 0 {
+			return fmt.Errorf("-bits %d must be a multiple of 8 and at least 64", *bits)
+		}
+		entropy = make([]byte, *bits/8)
+		if _, err := rand.Read(entropy); err != nil {
+			return err
+		}
+	}
+
+	seq := fastrand64.NewSeedSeq(entropy)
+	fmt.Fprintf(stdout, "entropy %x\n", entropy)
+	fmt.Fprintf(stdout, "seed %d\n", seq.Seed())
+	for i, child := range seq.Spawn(*workers) {
+		fmt.Fprintf(stdout, "worker %d %d\n", i, child.Seed())
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/villenny/fastrand64-go"
+)
+
+// errStatTestsFailed makes main exit with status 1, so CI can gate on it
+var errStatTestsFailed = errors.New("statistical tests failed")
+
+// statTestCommand runs the statistical battery on a registered generator, or on raw little endian
+// words from a file or stdin, eg the output of a custom generator in any language
+func statTestCommand(args []string, stdout io.Writer, stderr io.Writer) error {
+	return statTestCommandWithStdin(args, os.Stdin, stdout, stderr)
+}
+
+func statTestCommandWithStdin(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	algorithm := flags.String("algorithm", "xoshiro256**", "generator to test, see fastrand bench for the names")
+	seed := flags.Int64("seed", 1, "seed for the generator")
+	input := flags.String("input", "", "test raw little endian uint64 words from this file, - for stdin, instead of a generator")
+	words := flags.Int("words", 1<<20, "words to test, with -input at most the words in the input")
+	alpha := flags.Float64("alpha", 1e-4, "fail tests with a p-value below this")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("test takes no arguments, got %q", flags.Args())
+	}
+	if *alpha <= 0 || *alpha >= 1 {
+		return fmt.Errorf("-alpha %v must be in (0, 1)", *alpha)
+	}
+
+	var r fastrand64.UnsafeRNG
+	name := *algorithm
+	if *input != "" {
+		var data []byte
+		var err error
+		if *input == "-" {
+			data, err = ioutil.ReadAll(stdin)
+		} else {
+			data, err = ioutil.ReadFile(*input)
+		}
+		if err != nil {
+			return err
+		}
+		data = data[:len(data)/8*8]
+		tape, err := fastrand64.NewTapeRNG(data)
+		if err != nil {
+			return err
+		}
+		if *words > tape.Len() {
+			*words = tape.Len()
+		}
+		r, name = tape, *input
+	} else {
+		var err error
+		if r, err = fastrand64.NewByName(*algorithm, *seed); err != nil {
+			return err
+		}
+	}
+
+	results, err := fastrand64.RunStatTests(r, *words)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s, %d words, alpha %g\n", name, *words, *alpha)
+	failed := 0
+	for _, res := range results {
+		verdict := "PASS"
+		if !res.Passed(*alpha) {
+			verdict = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(stdout, "%s  %-16s  statistic %-12.6g  p %.6g\n", verdict, res.Name, res.Statistic, res.PValue)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errStatTestsFailed, failed, len(results))
+	}
+	return nil
+}
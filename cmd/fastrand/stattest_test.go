@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/villenny/fastrand64-go"
+)
+
+func Test_statTestCommand(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, run([]string{"test", "-algorithm", "pcg64dxsm", "-seed", "7", "-words", "65536"}, &out, ioutil.Discard))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, "pcg64dxsm, 65536 words, alpha 0.0001", lines[0])
+	assert.Len(t, lines, 8)
+	for _, line := range lines[1:] {
+		assert.True(t, strings.HasPrefix(line, "PASS  "), line)
+	}
+}
+
+func Test_statTestCommand_Input(t *testing.T) {
+	// a tape of a good generator passes
+	var tape bytes.Buffer
+	fastrand64.WriteRandom(&tape, fastrand64.NewUnsafeXoshiro256ssRNG(3), 8*8192)
+	var out bytes.Buffer
+	assert.NoError(t, statTestCommandWithStdin([]string{"-input", "-"}, &tape, &out, ioutil.Discard))
+	assert.Contains(t, out.String(), "-, 8192 words")
+
+	// and a file of counting words fails
+	dir, err := ioutil.TempDir("", "stattest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	counting := make([]byte, 8*8192)
+	for i := 0; i < 8192; i++ {
+		counting[8*i] = byte(i)
+		counting[8*i+1] = byte(i >> 8)
+	}
+	path := filepath.Join(dir, "counting.bin")
+	assert.NoError(t, ioutil.WriteFile(path, counting, 0644))
+	out.Reset()
+	err = run([]string{"test", "-input", path}, &out, ioutil.Discard)
+	assert.True(t, errors.Is(err, errStatTestsFailed))
+	assert.Contains(t, out.String(), "FAIL  monobit")
+}
+
+func Test_statTestCommand_Errors(t *testing.T) {
+	for _, args := range [][]string{
+		{"test", "extra"},
+		{"test", "-alpha", "0"},
+		{"test", "-algorithm", "mt19937"},
+		{"test", "-words", "10"},
+		{"test", "-input", "/no/such/file"},
+	} {
+		assert.Error(t, run(args, ioutil.Discard, ioutil.Discard), args)
+	}
+}
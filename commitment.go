@@ -0,0 +1,52 @@
+package fastrand64
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// SeedCommitment is a binding commitment to seed material, publish it before revealing the seed
+type SeedCommitment [sha256.Size]byte
+
+// domain separation, so a commitment digest is never the same as the expansion of the same seed
+const (
+	commitDomain = "fastrand64 commit v1\x00"
+	expandDomain = "fastrand64 expand v1\x00"
+)
+
+// Commit returns a commitment to seed, so parties of a multi-party simulation can pre-commit to
+// their randomness and reveal it later. The seed must carry enough entropy (say 16+ random bytes)
+// that it can't be brute forced from the commitment.
+//
+// This is a plain hash commit-reveal, NOT a VRF: nothing proves the seed itself was chosen fairly.
+func Commit(seed []byte) SeedCommitment {
+	h := sha256.New()
+	h.Write([]byte(commitDomain))
+	h.Write(seed)
+	var c SeedCommitment
+	h.Sum(c[:0])
+	return c
+}
+
+// Verify checks that a revealed seed matches its commitment
+func Verify(c SeedCommitment, seed []byte) bool {
+	expected := Commit(seed)
+	return subtle.ConstantTimeCompare(c[:], expected[:]) == 1
+}
+
+// ExpandSeed deterministically expands revealed seed material into a thread unsafe xoshiro256**
+// generator, using all 256 bits of its state, so every party derives the identical stream
+func ExpandSeed(seed []byte) *UnsafeXoshiro256ssRNG {
+	h := sha256.New()
+	h.Write([]byte(expandDomain))
+	h.Write(seed)
+	var digest [sha256.Size]byte
+	h.Sum(digest[:0])
+
+	var state [4]uint64
+	for i := range state {
+		state[i] = binary.LittleEndian.Uint64(digest[8*i:])
+	}
+	return newXoshiro256ssFromState(state[:])
+}
@@ -0,0 +1,29 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Commit_Verify(t *testing.T) {
+	seed := []byte("0123456789abcdef-party-a")
+	c := Commit(seed)
+	assert.True(t, Verify(c, seed))
+	assert.False(t, Verify(c, []byte("0123456789abcdef-party-b")))
+	assert.False(t, Verify(SeedCommitment{}, seed))
+	assert.Equal(t, c, Commit(seed))
+}
+
+func Test_ExpandSeed(t *testing.T) {
+	seed := []byte("revealed seed")
+	r1, r2, r3 := ExpandSeed(seed), ExpandSeed(seed), ExpandSeed([]byte("other seed"))
+	for i := 0; i < 16; i++ {
+		x := r1.Uint64()
+		assert.Equal(t, x, r2.Uint64())
+		assert.NotEqual(t, x, r3.Uint64())
+	}
+	// the expansion is independent of the commitment digest
+	c := Commit(seed)
+	assert.NotEqual(t, c[:8], Bytes(ExpandSeed(seed), make([]byte, 8)))
+}
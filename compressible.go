@@ -0,0 +1,36 @@
+package fastrand64
+
+// compressibleBlock is the granularity random and zero runs are interleaved at, small enough to
+// sit well inside any compressor's window
+const compressibleBlock = 4096
+
+// CompressibleBytes fills dst so that it compresses by roughly targetRatio (original / compressed
+// size), by making 1/targetRatio of every block random bytes and zeroing the rest.
+// targetRatio <= 1 gives incompressible random bytes.
+func CompressibleBytes(r UnsafeRNG, dst []byte, targetRatio float64) []byte {
+	randomShare := 1.0
+	if targetRatio > 1 {
+		randomShare = 1 / targetRatio
+	}
+	for i := 0; i < len(dst); i += compressibleBlock {
+		block := dst[i:]
+		if len(block) > compressibleBlock {
+			block = block[:compressibleBlock]
+		}
+		n := int(float64(len(block))*randomShare + 0.5)
+		Bytes(r, block[:n])
+		for j := n; j < len(block); j++ {
+			block[j] = 0
+		}
+	}
+	return dst
+}
+
+// CompressibleBytes allocates n bytes that compress by roughly targetRatio, see CompressibleBytes
+func (s *ThreadsafePoolRNG) CompressibleBytes(n int, targetRatio float64) []byte {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	result := CompressibleBytes(r, make([]byte, n), targetRatio)
+	rngPool.Put(r)
+	return result
+}
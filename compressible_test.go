@@ -0,0 +1,30 @@
+package fastrand64
+
+import (
+	"bytes"
+	"compress/flate"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func compressionRatio(t *testing.T, b []byte) float64 {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	assert.NoError(t, err)
+	_, err = w.Write(b)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return float64(len(b)) / float64(buf.Len())
+}
+
+func Test_SafeRNG_CompressibleBytes(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, target := range []float64{1, 2, 4, 8} {
+		b := rng.CompressibleBytes(1<<20, target)
+		assert.Len(t, b, 1<<20)
+		assert.InEpsilon(t, target, compressionRatio(t, b), 0.1)
+	}
+	assert.Len(t, rng.CompressibleBytes(5000, 3), 5000)
+	assert.InEpsilon(t, 1.0, compressionRatio(t, rng.CompressibleBytes(1<<16, 0.5)), 0.1)
+}
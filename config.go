@@ -0,0 +1,127 @@
+package fastrand64
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Config describes a pool RNG, so applications can expose RNG tuning through their usual config
+// files. The zero value is a randomly seeded pool of xoshiro256** generators.
+type Config struct {
+	// Algorithm is a name from Algorithms, default "xoshiro256**"
+	Algorithm string `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	// Backend is "pool" (default) for one generator per P, fastest but the order draws are
+	// interleaved between goroutines isn't reproducible, or "locked" for a single generator behind
+	// a mutex, a single reproducible stream when seeded, or "runtime" for the Go runtime's own
+	// generator, see RuntimeRNG, which ignores Algorithm and Buffer and can only be randomly seeded
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
+	// Seeding is "random" (default) for seeds from crypto/rand, or "fixed" to derive every
+	// generator's seed from Seed
+	Seeding string `json:"seeding,omitempty" yaml:"seeding,omitempty"`
+	Seed    int64  `json:"seed,omitempty" yaml:"seed,omitempty"`
+	// Buffer > 0 makes every generator produce that many words at a time through its block fill,
+	// which amortizes the generators that have one, shishua, isaac64 and crypto, over several
+	// draws. For the others it only adds a copy
+	Buffer int `json:"buffer,omitempty" yaml:"buffer,omitempty"`
+}
+
+// FromConfig constructs the pool RNG described by cfg
+func FromConfig(cfg Config) (*ThreadsafePoolRNG, error) {
+	if cfg.Backend == "runtime" {
+		// before the algorithm and buffer, which it ignores
+		if cfg.Seeding == "fixed" {
+			return nil, fmt.Errorf("config backend runtime can't be seeded")
+		}
+		return NewRuntimePoolRNG(), nil
+	}
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = "xoshiro256**"
+	}
+	a, err := LookupAlgorithm(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Buffer < 0 {
+		return nil, fmt.Errorf("config buffer %d must be >= 0", cfg.Buffer)
+	}
+
+	var seed SeedSeq
+	switch cfg.Seeding {
+	case "", "random":
+		var entropy [32]byte
+		if _, err := rand.Read(entropy[:]); err != nil {
+			return nil, err
+		}
+		seed = NewSeedSeq(entropy[:])
+	case "fixed":
+		var entropy [8]byte
+		binary.LittleEndian.PutUint64(entropy[:], uint64(cfg.Seed))
+		seed = NewSeedSeq(entropy[:])
+	default:
+		return nil, fmt.Errorf("unknown config seeding %q, want random or fixed", cfg.Seeding)
+	}
+	newRNG := func(i uint64) UnsafeRNG {
+		r := a.New(seed.Child(i).Seed())
+		if cfg.Buffer > 0 {
+			r = newBufferedRNG(r, cfg.Buffer)
+		}
+		return r
+	}
+
+	switch cfg.Backend {
+	case "", "pool":
+		var next uint64
+		return NewSyncPoolRNG(func() UnsafeRNG {
+			return newRNG(atomic.AddUint64(&next, 1) - 1)
+		}), nil
+	case "locked":
+		r := &lockedRNG{rng: newRNG(0)}
+		return NewSyncPoolRNG(func() UnsafeRNG { return r }), nil
+	}
+	return nil, fmt.Errorf("unknown config backend %q, want pool, locked or runtime", cfg.Backend)
+}
+
+// bufferedRNG draws its generator's words a block at a time, through the generator's own block
+// fill where it has one, whose little endian words are the same stream as its Uint64
+type bufferedRNG struct {
+	rng UnsafeRNG
+	buf []byte
+	pos int
+}
+
+func newBufferedRNG(r UnsafeRNG, words int) *bufferedRNG {
+	return &bufferedRNG{rng: r, buf: make([]byte, 8*words), pos: 8 * words}
+}
+
+func (b *bufferedRNG) Uint64() uint64 {
+	if b.pos == len(b.buf) {
+		if bulk, ok := b.rng.(bulkRNG); ok {
+			bulk.fillBytes(b.buf)
+		} else {
+			// not Bytes, which draws a word past the end of every slice
+			for i := 0; i < len(b.buf); i += 8 {
+				binary.LittleEndian.PutUint64(b.buf[i:], b.rng.Uint64())
+			}
+		}
+		b.pos = 0
+	}
+	x := binary.LittleEndian.Uint64(b.buf[b.pos:])
+	b.pos += 8
+	return x
+}
+
+// lockedRNG shares one generator between goroutines
+type lockedRNG struct {
+	mu  sync.Mutex
+	rng UnsafeRNG
+}
+
+func (l *lockedRNG) Uint64() uint64 {
+	l.mu.Lock()
+	x := l.rng.Uint64()
+	l.mu.Unlock()
+	return x
+}
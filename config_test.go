@@ -0,0 +1,102 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromConfig(t *testing.T) {
+	var cfg Config
+	assert.NoError(t, json.Unmarshal([]byte(`{"algorithm": "pcg64", "backend": "locked", "seeding": "fixed", "seed": 42, "buffer": 3}`), &cfg))
+	assert.Equal(t, Config{Algorithm: "pcg64", Backend: "locked", Seeding: "fixed", Seed: 42, Buffer: 3}, cfg)
+	rng, err := FromConfig(cfg)
+	assert.NoError(t, err)
+
+	// a locked fixed seed pool is one reproducible stream, buffering doesn't change it
+	var entropy [8]byte
+	binary.LittleEndian.PutUint64(entropy[:], 42)
+	expected := NewUnsafePcg64RNG(NewSeedSeq(entropy[:]).Child(0).Seed())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected.Uint64(), rng.Uint64())
+	}
+
+	// and it is safe to share
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				rng.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 400; i++ {
+		expected.Uint64()
+	}
+	assert.Equal(t, expected.Uint64(), rng.Uint64())
+}
+
+func Test_FromConfig_Defaults(t *testing.T) {
+	rng, err := FromConfig(Config{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, rng.Uint64(), rng.Uint64())
+
+	rng, err = FromConfig(Config{Backend: "runtime"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, rng.Uint64(), rng.Uint64())
+	// which ignores the algorithm and buffer
+	_, err = FromConfig(Config{Backend: "runtime", Algorithm: "mt19937", Buffer: -1})
+	assert.NoError(t, err)
+
+	for _, cfg := range []Config{
+		{Algorithm: "mt19937"},
+		{Backend: "channel"},
+		{Seeding: "sometimes"},
+		{Buffer: -1},
+		{Backend: "runtime", Seeding: "fixed"},
+	} {
+		_, err := FromConfig(cfg)
+		assert.Error(t, err, cfg)
+	}
+}
+
+// blockOnlyRNG counts block fills and fails a test that draws it a word at a time
+type blockOnlyRNG struct {
+	t     *testing.T
+	r     *UnsafeShishuaRNG
+	fills int
+}
+
+func (b *blockOnlyRNG) Uint64() uint64 {
+	b.t.Error("buffered generator drawn a word at a time")
+	return b.r.Uint64()
+}
+
+func (b *blockOnlyRNG) fillBytes(bytes []byte) {
+	b.fills++
+	b.r.fillBytes(bytes)
+}
+
+func Test_bufferedRNG(t *testing.T) {
+	// a generator with a block fill is filled through it, and gives the same words as unbuffered
+	bulk := &blockOnlyRNG{t: t, r: NewUnsafeShishuaRNG(1)}
+	buffered := newBufferedRNG(bulk, 64)
+	expected := NewUnsafeShishuaRNG(1)
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, expected.Uint64(), buffered.Uint64())
+	}
+	assert.Equal(t, 16, bulk.fills)
+
+	// and one without a block fill a word at a time, the same words too
+	buffered = newBufferedRNG(NewUnsafeXoshiro256ssRNG(1), 3)
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, r.Uint64(), buffered.Uint64())
+	}
+}
@@ -0,0 +1,33 @@
+package fastrand64
+
+// cpuid executes the CPUID instruction, implemented in cpu_amd64.s
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// rdrand64 and rdseed64 execute RDRAND and RDSEED once, ok is false when the hardware had no
+// value ready. Only call them if the CPU has the instruction
+func rdrand64() (x uint64, ok bool)
+func rdseed64() (x uint64, ok bool)
+
+// xgetbv0 reads XCR0, the register state the OS saves, only call it if the CPU has OSXSAVE
+func xgetbv0() uint32
+
+var (
+	// hasAESNI reports the AES-NI instructions, CPUID.1:ECX bit 25
+	hasAESNI = cpuidBit(1, 2, 25)
+	// hasRDRAND reports RDRAND, CPUID.1:ECX bit 30
+	hasRDRAND = cpuidBit(1, 2, 30)
+	// hasRDSEED reports RDSEED, CPUID.7:EBX bit 18
+	hasRDSEED = cpuidBit(7, 1, 18)
+	// hasAVX2 reports AVX2, CPUID.7:EBX bit 5, and an OS that saves the YMM registers
+	hasAVX2 = cpuidBit(7, 1, 5) && cpuidBit(1, 2, 27) && xgetbv0()&6 == 6
+)
+
+// cpuidBit tests a bit of register reg (0 eax, 1 ebx, 2 ecx, 3 edx) of CPUID leaf, subleaf 0
+func cpuidBit(leaf uint32, reg int, bit uint) bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if leaf > maxLeaf {
+		return false
+	}
+	eax, ebx, ecx, edx := cpuid(leaf, 0)
+	return [4]uint32{eax, ebx, ecx, edx}[reg]&(1<<bit) != 0
+}
@@ -0,0 +1,33 @@
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func rdrand64() (x uint64, ok bool)
+TEXT ·rdrand64(SB), NOSPLIT, $0-9
+	BYTE $0x48; BYTE $0x0F; BYTE $0xC7; BYTE $0xF0 // RDRAND AX
+	SETCS ok+8(FP)
+	MOVQ AX, x+0(FP)
+	RET
+
+// func rdseed64() (x uint64, ok bool)
+TEXT ·rdseed64(SB), NOSPLIT, $0-9
+	BYTE $0x48; BYTE $0x0F; BYTE $0xC7; BYTE $0xF8 // RDSEED AX
+	SETCS ok+8(FP)
+	MOVQ AX, x+0(FP)
+	RET
+
+// func xgetbv0() uint32
+TEXT ·xgetbv0(SB), NOSPLIT, $0-4
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, ret+0(FP)
+	RET
@@ -0,0 +1,15 @@
+//go:build !amd64
+// +build !amd64
+
+package fastrand64
+
+// the instructions are only detected on amd64
+var (
+	hasAESNI  = false
+	hasRDRAND = false
+	hasRDSEED = false
+	hasAVX2   = false
+)
+
+func rdrand64() (uint64, bool) { return 0, false }
+func rdseed64() (uint64, bool) { return 0, false }
@@ -0,0 +1,38 @@
+package fastrand64
+
+import "sync"
+
+// CRN supports common random numbers for paired experiments. Each named component of a simulation
+// draws from its own stream derived from the replication seed, so two variants run with the same
+// seed see identical randomness per component, however differently they interleave their draws.
+// Comparing the variants then only sees the variance of their difference. Threadsafe, but each
+// stream is a thread unsafe generator, so share a component's stream between goroutines with care.
+type CRN struct {
+	seed    SeedSeq
+	mu      sync.Mutex
+	streams map[string]*UnsafeXoshiro256ssRNG
+}
+
+// NewCRN creates a set of named streams for one replication
+func NewCRN(seed SeedSeq) *CRN {
+	return &CRN{seed: seed, streams: map[string]*UnsafeXoshiro256ssRNG{}}
+}
+
+// Stream returns the generator for a named component, creating it on first use. Later calls with
+// the same name return the same generator, continuing where it left off.
+func (c *CRN) Stream(name string) *UnsafeXoshiro256ssRNG {
+	c.mu.Lock()
+	r, ok := c.streams[name]
+	if !ok {
+		r = c.seed.Named(name).NewXoshiro256ssRNG()
+		c.streams[name] = r
+	}
+	c.mu.Unlock()
+	return r
+}
+
+// Replication returns a fresh CRN for replication i, the variants of one replication share its
+// seed while different replications are independent
+func (c *CRN) Replication(i uint64) *CRN {
+	return NewCRN(c.seed.Child(i))
+}
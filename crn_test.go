@@ -0,0 +1,57 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CRN_Stream(t *testing.T) {
+	seed := NewSeedSeq([]byte("experiment 7"))
+	a, b := NewCRN(seed), NewCRN(seed)
+
+	// variant a draws arrivals and services interleaved, variant b draws them in bulk
+	var arrivalsA, servicesA, arrivalsB, servicesB []uint64
+	for i := 0; i < 8; i++ {
+		arrivalsA = append(arrivalsA, a.Stream("arrivals").Uint64())
+		servicesA = append(servicesA, a.Stream("service").Uint64())
+	}
+	for i := 0; i < 8; i++ {
+		servicesB = append(servicesB, b.Stream("service").Uint64())
+	}
+	for i := 0; i < 8; i++ {
+		arrivalsB = append(arrivalsB, b.Stream("arrivals").Uint64())
+	}
+	assert.Equal(t, arrivalsA, arrivalsB)
+	assert.Equal(t, servicesA, servicesB)
+	assert.NotEqual(t, arrivalsA, servicesA)
+
+	other := a.Replication(1).Stream("arrivals")
+	assert.NotEqual(t, arrivalsA[0], other.Uint64())
+	assert.Equal(t, a.Replication(1).Stream("arrivals").Uint64(), b.Replication(1).Stream("arrivals").Uint64())
+}
+
+func Test_CRN_VarianceReduction(t *testing.T) {
+	// compare the mean of 100 exponential service times at rate 1 and rate 1.1
+	seed := NewSeedSeq([]byte("crn"))
+	variant := func(crn *CRN, rate float64) float64 {
+		sum := 0.0
+		for i := 0; i < 100; i++ {
+			// by inversion, so both variants map each uniform to the same quantile
+			sum += -math.Log(1-unitFloat64(crn.Stream("service"))) / rate
+		}
+		return sum / 100
+	}
+	paired := make([]float64, 500)
+	independent := make([]float64, 500)
+	for i := range paired {
+		crn := NewCRN(seed).Replication(uint64(i))
+		paired[i] = variant(crn, 1) - variant(NewCRN(seed).Replication(uint64(i)), 1.1)
+		independent[i] = variant(crn, 1) - variant(NewCRN(seed).Replication(uint64(i+1000)), 1.1)
+	}
+	pairedMean, pairedVariance := meanAndVariance(paired)
+	_, independentVariance := meanAndVariance(independent)
+	assert.InDelta(t, 1-1/1.1, pairedMean, 0.01)
+	assert.Less(t, pairedVariance*10, independentVariance)
+}
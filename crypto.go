@@ -0,0 +1,85 @@
+package fastrand64
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// cryptoBufferSize is how much UnsafeCryptoRNG reads from crypto/rand at a time
+const cryptoBufferSize = 4096
+
+// UnsafeCryptoRNG serves values from crypto/rand, read 4KB at a time into an internal buffer so
+// the system call is amortized over 512 Uint64s, for when security matters more than speed but
+// the code is written against the pool API, eg NewSyncPoolCryptoRNG. Its output can't be
+// reproduced or seeded. A failing crypto/rand means the OS has no entropy source, so Uint64
+// panics, Read returns the error. It is unsafe to call UnsafeRNG methods from concurrent
+// goroutines.
+type UnsafeCryptoRNG struct {
+	src io.Reader
+	buf [cryptoBufferSize]byte
+	pos int // bytes of buf already served
+}
+
+// refill moves the unserved tail of buf to the front and tops it up from crypto/rand
+func (r *UnsafeCryptoRNG) refill() error {
+	n := copy(r.buf[:], r.buf[r.pos:])
+	r.pos = 0
+	if _, err := io.ReadFull(r.src, r.buf[n:]); err != nil {
+		// keep the unserved bytes, and nothing else
+		r.pos = len(r.buf) - n
+		copy(r.buf[r.pos:], r.buf[:n])
+		return err
+	}
+	return nil
+}
+
+// Uint64 returns a uint64 from crypto/rand, (not thread safe)
+func (r *UnsafeCryptoRNG) Uint64() uint64 {
+	if len(r.buf)-r.pos < 8 {
+		if err := r.refill(); err != nil {
+			panic("fastrand64: reading crypto/rand: " + err.Error())
+		}
+	}
+	x := binary.LittleEndian.Uint64(r.buf[r.pos:])
+	r.pos += 8
+	return x
+}
+
+// Read fills p with bytes from crypto/rand, what's left in the buffer first, then reads of a
+// buffer or more go straight to crypto/rand
+func (r *UnsafeCryptoRNG) Read(p []byte) (int, error) {
+	n := copy(p, r.buf[r.pos:])
+	r.pos += n
+	if n == len(p) {
+		return n, nil
+	}
+	if len(p)-n >= len(r.buf) {
+		m, err := io.ReadFull(r.src, p[n:])
+		return n + m, err
+	}
+	if err := r.refill(); err != nil {
+		return n, err
+	}
+	m := copy(p[n:], r.buf[:])
+	r.pos = m
+	return n + m, nil
+}
+
+// fillBytes makes Bytes use Read
+func (r *UnsafeCryptoRNG) fillBytes(bytes []byte) {
+	if _, err := r.Read(bytes); err != nil {
+		panic("fastrand64: reading crypto/rand: " + err.Error())
+	}
+}
+
+// NewUnsafeCryptoRNG creates a new Thread unsafe buffered crypto/rand generator
+func NewUnsafeCryptoRNG() *UnsafeCryptoRNG {
+	return &UnsafeCryptoRNG{src: rand.Reader, pos: cryptoBufferSize}
+}
+
+// NewSyncPoolCryptoRNG wraps buffered crypto/rand generators in the pool API, each pooled
+// generator holds a 4KB buffer
+func NewSyncPoolCryptoRNG() *ThreadsafePoolRNG {
+	return NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeCryptoRNG() })
+}
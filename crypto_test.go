@@ -0,0 +1,68 @@
+package fastrand64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader counts the reads from its reader
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+func appendUint64(b []byte, x uint64) []byte {
+	var w [8]byte
+	binary.LittleEndian.PutUint64(w[:], x)
+	return append(b, w[:]...)
+}
+
+func Test_UnsafeCryptoRNG(t *testing.T) {
+	data := Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 4*cryptoBufferSize))
+	src := &countingReader{r: bytes.NewReader(data)}
+	rng := &UnsafeCryptoRNG{src: src, pos: cryptoBufferSize}
+
+	// the stream comes out in order through any mix of calls, 8 bytes per Uint64
+	var got []byte
+	for i := 0; i < 100; i++ {
+		got = appendUint64(got, rng.Uint64())
+	}
+	assert.Equal(t, 1, src.reads)
+	p := make([]byte, 3)
+	rng.Read(p)
+	got = append(got, p...)
+	for i := 0; i < 500; i++ {
+		got = appendUint64(got, rng.Uint64())
+	}
+	assert.Equal(t, 2, src.reads)
+	p = make([]byte, cryptoBufferSize+5)
+	n, err := rng.Read(p)
+	assert.Equal(t, len(p), n)
+	assert.NoError(t, err)
+	got = append(got, p...)
+	got = append(got, Bytes(rng, make([]byte, 20))...)
+	assert.Equal(t, data[:len(got)], got)
+
+	// out of entropy
+	assert.Panics(t, func() {
+		for {
+			rng.Uint64()
+		}
+	})
+	_, err = rng.Read(make([]byte, 10))
+	assert.Error(t, err)
+
+	assert.NotEqual(t, NewUnsafeCryptoRNG().Uint64(), NewUnsafeCryptoRNG().Uint64())
+	pool := NewSyncPoolCryptoRNG()
+	assert.Len(t, pool.Bytes(10000), 10000)
+	assert.Less(t, pool.Uint32n(10), uint32(10))
+}
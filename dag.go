@@ -0,0 +1,86 @@
+package fastrand64
+
+// DAG is a directed acyclic graph whose node ids are in topological order, every edge goes from a
+// lower level to a higher one and Level is the length of the longest path from a root to the node
+type DAG struct {
+	Children [][]int // ascending
+	Parents  [][]int // ascending
+	Level    []int
+	Levels   [][]int
+}
+
+// RandomDAG builds a random layered DAG: levels of 1..maxWidth nodes, an edge from each node to
+// each node of a later level with probability edgeProb, and every non-root node guaranteed a parent
+// in the level just before it. A seeded generator gives a reproducible graph.
+func RandomDAG(r UnsafeRNG, nodes int, edgeProb float64, maxWidth int) *DAG {
+	if maxWidth < 1 {
+		panic("RandomDAG maxWidth must be >= 1")
+	}
+	d := &DAG{
+		Children: make([][]int, nodes),
+		Parents:  make([][]int, nodes),
+		Level:    make([]int, nodes),
+	}
+	for id := 0; id < nodes; {
+		width := 1 + intn(r, maxWidth)
+		if width > nodes-id {
+			width = nodes - id
+		}
+		level := make([]int, width)
+		for i := range level {
+			level[i] = id
+			d.Level[id] = len(d.Levels)
+			id++
+		}
+		d.Levels = append(d.Levels, level)
+	}
+
+	for v := 0; v < nodes; v++ {
+		lv := d.Level[v]
+		if lv == 0 {
+			continue
+		}
+		for u := 0; u < d.Levels[lv][0]; u++ {
+			if unitFloat64(r) < edgeProb {
+				d.Parents[v] = append(d.Parents[v], u)
+			}
+		}
+		prev := d.Levels[lv-1]
+		if len(d.Parents[v]) == 0 || d.Parents[v][len(d.Parents[v])-1] < prev[0] {
+			d.Parents[v] = append(d.Parents[v], prev[intn(r, len(prev))])
+		}
+		for _, u := range d.Parents[v] {
+			d.Children[u] = append(d.Children[u], v)
+		}
+	}
+	return d
+}
+
+// Roots returns the nodes without parents
+func (d *DAG) Roots() []int {
+	var roots []int
+	for v, parents := range d.Parents {
+		if len(parents) == 0 {
+			roots = append(roots, v)
+		}
+	}
+	return roots
+}
+
+// Edges returns the number of edges
+func (d *DAG) Edges() int {
+	n := 0
+	for _, children := range d.Children {
+		n += len(children)
+	}
+	return n
+}
+
+// RandomDAG builds a random layered DAG using a single pool checkout, see RandomDAG
+func (s *ThreadsafePoolRNG) RandomDAG(nodes int, edgeProb float64, maxWidth int) *DAG {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	d := RandomDAG(r, nodes, edgeProb, maxWidth)
+	rngPool.Put(r)
+	return d
+}
@@ -0,0 +1,49 @@
+package fastrand64
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RandomDAG(t *testing.T) {
+	d := RandomDAG(NewUnsafeXoshiro256ssRNG(4), 500, 0.02, 8)
+	assert.Equal(t, d, RandomDAG(NewUnsafeXoshiro256ssRNG(4), 500, 0.02, 8))
+
+	count := 0
+	for lv, level := range d.Levels {
+		assert.LessOrEqual(t, len(level), 8)
+		for _, v := range level {
+			assert.Equal(t, lv, d.Level[v])
+			count++
+		}
+	}
+	assert.Equal(t, 500, count)
+	assert.Equal(t, d.Levels[0], d.Roots())
+
+	edges := 0
+	for v := range d.Parents {
+		assert.True(t, sort.IntsAreSorted(d.Parents[v]))
+		assert.True(t, sort.IntsAreSorted(d.Children[v]))
+		longest := -1
+		for _, u := range d.Parents[v] {
+			assert.Less(t, d.Level[u], d.Level[v])
+			assert.Contains(t, d.Children[u], v)
+			if d.Level[u] > longest {
+				longest = d.Level[u]
+			}
+		}
+		// Level is the longest path depth
+		assert.Equal(t, longest+1, d.Level[v])
+		edges += len(d.Parents[v])
+	}
+	assert.Equal(t, edges, d.Edges())
+}
+
+func Test_SafeRNG_RandomDAG_Chain(t *testing.T) {
+	d := NewSyncPoolXoshiro256ssRNG().RandomDAG(5, 0, 1)
+	assert.Equal(t, [][]int{nil, {0}, {1}, {2}, {3}}, d.Parents)
+	assert.Equal(t, 4, d.Edges())
+	assert.Panics(t, func() { RandomDAG(NewUnsafeXoshiro256ssRNG(1), 3, 0.5, 0) })
+}
@@ -0,0 +1,189 @@
+package fastrand64
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatasetColumn describes one column of a generated dataset
+type DatasetColumn struct {
+	Name string `json:"name"`
+	// Type is one of seq (the row number), int, float, bool, time, choice, regexp,
+	// or any name NewDistribution accepts
+	Type string `json:"type"`
+	// Params are [min, max] for int (inclusive) and float, [min, max] unix seconds for time,
+	// and the constructor's params for a distribution
+	Params []float64 `json:"params,omitempty"`
+	// Choices are the values of a choice column, picked uniformly
+	Choices []string `json:"choices,omitempty"`
+	// Pattern is the regexp values of a regexp column match, see FromRegexp
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// ParseDatasetColumn parses a "name:type[:args]" column spec, where args are ":" separated
+// params, "|" separated choices for a choice column, or the rest of the spec for a regexp column,
+// eg "id:seq", "age:int:18:90", "latency:lognormal:1:0.5", "tier:choice:free|pro" or "sku:regexp:[A-Z]{3}-\d{4}"
+func ParseDatasetColumn(spec string) (DatasetColumn, error) {
+	fields := strings.SplitN(spec, ":", 3)
+	if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+		return DatasetColumn{}, fmt.Errorf("column %q is not name:type[:args]", spec)
+	}
+	c := DatasetColumn{Name: fields[0], Type: fields[1]}
+	if len(fields) < 3 {
+		return c, nil
+	}
+	switch c.Type {
+	case "seq", "bool":
+		return DatasetColumn{}, fmt.Errorf("column %s: %s takes no args, got %q", c.Name, c.Type, fields[2])
+	case "choice":
+		c.Choices = strings.Split(fields[2], "|")
+	case "regexp":
+		c.Pattern = fields[2]
+	default:
+		for _, f := range strings.Split(fields[2], ":") {
+			p, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return DatasetColumn{}, fmt.Errorf("column %s: %v", c.Name, err)
+			}
+			c.Params = append(c.Params, p)
+		}
+	}
+	return c, nil
+}
+
+// Dataset generates rows of typed random values, see NewDataset. It holds no generator, so
+// the same dataset can write any number of files from any generators concurrently.
+type Dataset struct {
+	columns []DatasetColumn
+	values  []func(r UnsafeRNG, row int) string
+}
+
+// NewDataset validates the columns and returns a dataset generating them
+func NewDataset(columns []DatasetColumn) (*Dataset, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("a dataset needs at least one column")
+	}
+	d := &Dataset{columns: columns, values: make([]func(UnsafeRNG, int) string, len(columns))}
+	for i, c := range columns {
+		value, err := datasetValue(c)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", c.Name, err)
+		}
+		d.values[i] = value
+	}
+	return d, nil
+}
+
+func datasetValue(c DatasetColumn) (func(r UnsafeRNG, row int) string, error) {
+	minMax := func() (float64, float64, error) {
+		if len(c.Params) != 2 || c.Params[0] > c.Params[1] {
+			return 0, 0, fmt.Errorf("%s needs params min <= max, got %v", c.Type, c.Params)
+		}
+		return c.Params[0], c.Params[1], nil
+	}
+	noArgs := func() error {
+		if len(c.Params) > 0 || len(c.Choices) > 0 || c.Pattern != "" {
+			return fmt.Errorf("%s takes no args", c.Type)
+		}
+		return nil
+	}
+	switch c.Type {
+	case "seq":
+		if err := noArgs(); err != nil {
+			return nil, err
+		}
+		return func(r UnsafeRNG, row int) string { return strconv.Itoa(row) }, nil
+	case "int", "time":
+		lo, hi, err := minMax()
+		if err != nil {
+			return nil, err
+		}
+		min, span := int64(lo), uint64(int64(hi)-int64(lo))+1
+		draw := func(r UnsafeRNG) int64 {
+			if span == 0 {
+				return int64(r.Uint64())
+			}
+			return min + int64(uint64n(r, span))
+		}
+		if c.Type == "time" {
+			return func(r UnsafeRNG, row int) string {
+				return time.Unix(draw(r), 0).UTC().Format(time.RFC3339)
+			}, nil
+		}
+		return func(r UnsafeRNG, row int) string { return strconv.FormatInt(draw(r), 10) }, nil
+	case "float":
+		lo, hi, err := minMax()
+		if err != nil {
+			return nil, err
+		}
+		return func(r UnsafeRNG, row int) string {
+			return strconv.FormatFloat(lo+unitFloat64(r)*(hi-lo), 'g', -1, 64)
+		}, nil
+	case "bool":
+		if err := noArgs(); err != nil {
+			return nil, err
+		}
+		return func(r UnsafeRNG, row int) string { return strconv.FormatBool(r.Uint64()&1 == 1) }, nil
+	case "choice":
+		if len(c.Choices) == 0 {
+			return nil, fmt.Errorf("choice needs at least one choice")
+		}
+		return func(r UnsafeRNG, row int) string { return c.Choices[intn(r, len(c.Choices))] }, nil
+	case "regexp":
+		re, err := syntax.Parse(c.Pattern, syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		re = re.Simplify()
+		return func(r UnsafeRNG, row int) string {
+			var sb strings.Builder
+			genRegexp(r, re, &sb)
+			return sb.String()
+		}, nil
+	}
+	dist, err := NewDistribution(c.Type, c.Params...)
+	if err != nil {
+		return nil, err
+	}
+	return func(r UnsafeRNG, row int) string {
+		return strconv.FormatFloat(dist.Sample(r), 'g', -1, 64)
+	}, nil
+}
+
+// Columns returns the dataset's columns
+func (d *Dataset) Columns() []DatasetColumn {
+	return d.columns
+}
+
+// Row appends one row of values to dst, row is the value of seq columns
+func (d *Dataset) Row(r UnsafeRNG, row int, dst []string) []string {
+	for _, value := range d.values {
+		dst = append(dst, value(r, row))
+	}
+	return dst
+}
+
+// WriteCSV streams a header and rows rows to w, row by row so any number of rows fits in
+// constant memory. The same generator state always writes the same file.
+func (d *Dataset) WriteCSV(w io.Writer, r UnsafeRNG, rows int) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, 0, len(d.columns))
+	for _, c := range d.columns {
+		record = append(record, c.Name)
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for row := 0; row < rows; row++ {
+		if err := cw.Write(d.Row(r, row, record[:0])); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
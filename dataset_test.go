@@ -0,0 +1,99 @@
+package fastrand64
+
+import (
+	"bytes"
+	"encoding/csv"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDatasetColumn(t *testing.T) {
+	c, err := ParseDatasetColumn("age:int:18:90")
+	assert.NoError(t, err)
+	assert.Equal(t, DatasetColumn{Name: "age", Type: "int", Params: []float64{18, 90}}, c)
+
+	c, err = ParseDatasetColumn("tier:choice:free|pro|enterprise")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"free", "pro", "enterprise"}, c.Choices)
+
+	// a regexp keeps its colons
+	c, err = ParseDatasetColumn("url:regexp:https://[a-z]{4}")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://[a-z]{4}", c.Pattern)
+
+	c, err = ParseDatasetColumn("id:seq")
+	assert.NoError(t, err)
+	assert.Equal(t, DatasetColumn{Name: "id", Type: "seq"}, c)
+
+	for _, spec := range []string{"", "id", ":seq", "id:", "x:int:1:y", "id:seq:1", "ok:bool:0.5", "ok:bool:"} {
+		_, err = ParseDatasetColumn(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func Test_Dataset_WriteCSV(t *testing.T) {
+	var columns []DatasetColumn
+	for _, spec := range []string{"id:seq", "age:int:18:90", "score:float:0:1", "ok:bool",
+		"at:time:1577836800:1609459199", "tier:choice:free|pro", "sku:regexp:[A-Z]{3}-\\d{4}", "latency:lognormal:1:0.5"} {
+		c, err := ParseDatasetColumn(spec)
+		assert.NoError(t, err)
+		columns = append(columns, c)
+	}
+	d, err := NewDataset(columns)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, d.WriteCSV(&buf, NewUnsafeXoshiro256ssRNG(1), 1000))
+	records, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1001)
+	assert.Equal(t, []string{"id", "age", "score", "ok", "at", "tier", "sku", "latency"}, records[0])
+
+	sku := regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+	for i, record := range records[1:] {
+		assert.Equal(t, strconv.Itoa(i), record[0])
+		age, err := strconv.Atoi(record[1])
+		assert.NoError(t, err)
+		assert.True(t, age >= 18 && age <= 90)
+		score, err := strconv.ParseFloat(record[2], 64)
+		assert.NoError(t, err)
+		assert.True(t, score >= 0 && score < 1)
+		assert.Contains(t, []string{"true", "false"}, record[3])
+		at, err := time.Parse(time.RFC3339, record[4])
+		assert.NoError(t, err)
+		assert.Equal(t, 2020, at.Year())
+		assert.Contains(t, []string{"free", "pro"}, record[5])
+		assert.True(t, sku.MatchString(record[6]), record[6])
+		latency, err := strconv.ParseFloat(record[7], 64)
+		assert.NoError(t, err)
+		assert.Greater(t, latency, 0.0)
+	}
+
+	// the same seed writes the same file
+	var again bytes.Buffer
+	assert.NoError(t, d.WriteCSV(&again, NewUnsafeXoshiro256ssRNG(1), 1000))
+	assert.Equal(t, buf.String(), again.String())
+}
+
+func Test_NewDataset_Errors(t *testing.T) {
+	for _, c := range []DatasetColumn{
+		{Name: "a", Type: "int"},
+		{Name: "a", Type: "float", Params: []float64{2, 1}},
+		{Name: "a", Type: "choice"},
+		{Name: "a", Type: "regexp", Pattern: "("},
+		{Name: "a", Type: "gamma", Params: []float64{1}},
+		{Name: "a", Type: "nope"},
+		{Name: "a", Type: "seq", Params: []float64{1}},
+		{Name: "a", Type: "bool", Choices: []string{"y", "n"}},
+		{Name: "a", Type: "bool", Pattern: "y|n"},
+	} {
+		_, err := NewDataset([]DatasetColumn{c})
+		assert.Error(t, err, c.Type)
+	}
+	_, err := NewDataset(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package fastrand64
+
+import (
+	"math"
+	"time"
+)
+
+// DecaySampler accepts items with a probability that halves for every halfLife of age,
+// ie fresh items are always kept and old items are thinned out. Threadsafe
+type DecaySampler struct {
+	rng      *ThreadsafePoolRNG
+	halfLife float64
+}
+
+// DecaySampler returns a sampler drawing its decisions from this pool, halfLife must be > 0
+func (s *ThreadsafePoolRNG) DecaySampler(halfLife time.Duration) *DecaySampler {
+	if halfLife <= 0 {
+		panic("DecaySampler halfLife must be > 0")
+	}
+	return &DecaySampler{rng: s, halfLife: float64(halfLife)}
+}
+
+// Probability returns the acceptance probability for an item of the given age, 1 for ages <= 0
+func (d *DecaySampler) Probability(age time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(age) / d.halfLife)
+}
+
+// Sample decides whether to keep an item of the given age
+func (d *DecaySampler) Sample(age time.Duration) bool {
+	p := d.Probability(age)
+	if p >= 1 {
+		return true
+	}
+	rngPool := d.rng.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := unitFloat64(r)
+	rngPool.Put(r)
+	return x < p
+}
+
+// SampleTime decides whether to keep an item created at ts, aged against the wall clock
+func (d *DecaySampler) SampleTime(ts time.Time) bool {
+	return d.Sample(time.Since(ts))
+}
@@ -0,0 +1,34 @@
+package fastrand64
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecaySampler_Probability(t *testing.T) {
+	d := NewSyncPoolXoshiro256ssRNG().DecaySampler(time.Hour)
+	assert.Equal(t, 1.0, d.Probability(-time.Minute))
+	assert.Equal(t, 1.0, d.Probability(0))
+	assert.InDelta(t, 0.5, d.Probability(time.Hour), 1e-12)
+	assert.InDelta(t, 0.125, d.Probability(3*time.Hour), 1e-12)
+}
+
+func Test_DecaySampler_Sample(t *testing.T) {
+	d := NewSyncPoolXoshiro256ssRNG().DecaySampler(time.Minute)
+	const n = 20000
+	kept := 0
+	for i := 0; i < n; i++ {
+		if d.Sample(2 * time.Minute) {
+			kept++
+		}
+	}
+	assert.InDelta(t, 0.25, float64(kept)/n, 0.02)
+	assert.True(t, d.SampleTime(time.Now().Add(time.Second)))
+}
+
+func Test_DecaySampler_BadHalfLife(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	assert.Panics(t, func() { rng.DecaySampler(0) })
+}
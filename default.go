@@ -0,0 +1,32 @@
+package fastrand64
+
+// Default returns the package's shared pool RNG of xoshiro256** generators, seeded from
+// crypto/rand. It is created on first use, race free and whatever the init order, so code that
+// just wants random numbers can call Uint64 and friends from anywhere without setting one up.
+func Default() *ThreadsafePoolRNG {
+	return defaultRNG()
+}
+
+func newDefaultRNG() *ThreadsafePoolRNG {
+	rng, err := FromConfig(Config{})
+	if err != nil {
+		// crypto/rand failing means the OS has no entropy source, nothing sensible can go on
+		panic("fastrand64: seeding the default RNG: " + err.Error())
+	}
+	return rng
+}
+
+// Uint64 returns pseudorandom uint64 from the Default RNG. Threadsafe
+func Uint64() uint64 {
+	return defaultRNG().Uint64()
+}
+
+// Uint32 returns pseudorandom uint32 from the Default RNG. Threadsafe
+func Uint32() uint32 {
+	return defaultRNG().Uint32()
+}
+
+// Uint32n returns pseudorandom uint32 in the range [0..maxN) from the Default RNG. Threadsafe
+func Uint32n(maxN int) uint32 {
+	return defaultRNG().Uint32n(maxN)
+}
@@ -0,0 +1,8 @@
+//go:build go1.21
+// +build go1.21
+
+package fastrand64
+
+import "sync"
+
+var defaultRNG = sync.OnceValue(newDefaultRNG)
@@ -0,0 +1,17 @@
+//go:build !go1.21
+// +build !go1.21
+
+package fastrand64
+
+import "sync"
+
+// before go1.21 there is no sync.OnceValue
+var (
+	defaultOnce sync.Once
+	defaultPool *ThreadsafePoolRNG
+)
+
+func defaultRNG() *ThreadsafePoolRNG {
+	defaultOnce.Do(func() { defaultPool = newDefaultRNG() })
+	return defaultPool
+}
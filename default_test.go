@@ -0,0 +1,32 @@
+package fastrand64
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Default(t *testing.T) {
+	// the first calls race each other, run with -race
+	var wg sync.WaitGroup
+	rngs := make([]*ThreadsafePoolRNG, 8)
+	for i := range rngs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			Uint64()
+			rngs[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+	for _, rng := range rngs {
+		assert.True(t, rng == Default())
+	}
+
+	assert.NotEqual(t, Uint64(), Uint64())
+	Uint32()
+	for i := 0; i < 100; i++ {
+		assert.Less(t, Uint32n(10), uint32(10))
+	}
+}
@@ -0,0 +1,138 @@
+package fastrand64
+
+import "math"
+
+// Hypergeometric draws the number of good items in a sample of size sample taken without
+// replacement from good+bad items, from a thread unsafe RNG. sample must be <= good+bad.
+func Hypergeometric(r UnsafeRNG, good uint64, bad uint64, sample uint64) uint64 {
+	total := good + bad
+	if sample > total {
+		panic("Hypergeometric sample must be <= good+bad")
+	}
+	lo := uint64(0)
+	if sample > bad {
+		lo = sample - bad
+	}
+	hi := sample
+	if good < hi {
+		hi = good
+	}
+	g, b, s := float64(good), float64(bad), float64(sample)
+	mode := uint64(math.Floor((s + 1) * (g + 1) / (g + b + 2)))
+	k := float64(mode)
+	logPmf := lchoose(g, k) + lchoose(b, s-k) - lchoose(g+b, s)
+	return modeInversion(unitFloat64(r), mode, math.Exp(logPmf), lo, hi, func(k float64) float64 {
+		return (g - k) * (s - k) / ((k + 1) * (b - s + k + 1))
+	})
+}
+
+// Geometric draws the number of failures before the first success, each trial succeeding with
+// probability p, from a thread unsafe RNG, by inversion so any p costs one uniform. It is the gap
+// to skip when selecting each of a stream of items with probability p, eg for sampling 1% of
+// events without a draw per event. p must be in (0, 1]
+func Geometric(r UnsafeRNG, p float64) uint64 {
+	if !(p > 0 && p <= 1) {
+		panic("Geometric p must be in (0, 1]")
+	}
+	if p == 1 {
+		return 0
+	}
+	x := math.Floor(math.Log(openUnitFloat64(r)) / math.Log1p(-p))
+	if x >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(x)
+}
+
+// Geometric draws the failures before the first success using a single pool checkout. Threadsafe
+func (s *ThreadsafePoolRNG) Geometric(p float64) uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := Geometric(r, p)
+	rngPool.Put(r)
+	return x
+}
+
+// negativeBinomialMixtureVariance is the variance above which NegativeBinomial switches from
+// inversion, which walks O(standard deviation) terms, to the O(1) gamma Poisson mixture
+const negativeBinomialMixtureVariance = 2500
+
+// NegativeBinomial draws the number of failures before n successes, each trial succeeding with
+// probability p, from a thread unsafe RNG. n may be any positive real (the Polya distribution),
+// p must be in (0, 1]. It is also Poisson with a gamma distributed mean, so it suits overdispersed counts, whose
+// variance exceeds their mean. Large variances draw that way, Poisson(Gamma(n, (1-p)/p)).
+func NegativeBinomial(r UnsafeRNG, n float64, p float64) uint64 {
+	if !(n > 0) || math.IsInf(n, 1) {
+		panic("NegativeBinomial n must be finite and > 0")
+	}
+	if !(p > 0 && p <= 1) {
+		panic("NegativeBinomial p must be in (0, 1]")
+	}
+	if p == 1 {
+		return 0
+	}
+	if n*(1-p)/(p*p) > negativeBinomialMixtureVariance {
+		return Poisson(r, standardGamma(r, n)*(1-p)/p)
+	}
+	mode := uint64(0)
+	if n > 1 {
+		mode = uint64(math.Floor((n - 1) * (1 - p) / p))
+	}
+	k := float64(mode)
+	lgKN, _ := math.Lgamma(k + n)
+	lgK, _ := math.Lgamma(k + 1)
+	lgN, _ := math.Lgamma(n)
+	logPmf := lgKN - lgK - lgN + n*math.Log(p) + k*math.Log1p(-p)
+	return modeInversion(unitFloat64(r), mode, math.Exp(logPmf), 0, math.MaxUint64, func(k float64) float64 {
+		return (k + n) / (k + 1) * (1 - p)
+	})
+}
+
+// NegativeBinomial draws the failures before n successes using a single pool checkout. Threadsafe
+func (s *ThreadsafePoolRNG) NegativeBinomial(n float64, p float64) uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := NegativeBinomial(r, n, p)
+	rngPool.Put(r)
+	return x
+}
+
+// modeInversion inverts the cdf of a unimodal discrete distribution on [lo..hi] starting at the
+// mode and walking outward alternately down and up, so the expected cost is O(standard deviation).
+// ratio(k) is pmf(k+1)/pmf(k).
+func modeInversion(u float64, mode uint64, pmfMode float64, lo uint64, hi uint64, ratio func(k float64) float64) uint64 {
+	u -= pmfMode
+	if u <= 0 {
+		return mode
+	}
+	down, up := mode, mode
+	pDown, pUp := pmfMode, pmfMode
+	for {
+		if up < hi && pUp > 0 {
+			pUp *= ratio(float64(up))
+			up++
+			if u -= pUp; u <= 0 {
+				return up
+			}
+		}
+		if down > lo && pDown > 0 {
+			pDown /= ratio(float64(down - 1))
+			down--
+			if u -= pDown; u <= 0 {
+				return down
+			}
+		}
+		if (up >= hi || pUp == 0) && (down <= lo || pDown == 0) {
+			// only rounding error left in u
+			return mode
+		}
+	}
+}
+
+// lchoose returns log(n choose k)
+func lchoose(n float64, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
@@ -0,0 +1,93 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Hypergeometric(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for _, c := range []struct{ good, bad, sample uint64 }{
+		{10, 20, 5},
+		{500, 300, 400},
+		{1000000, 3000000, 10000},
+		{5, 5, 10},
+	} {
+		xs := make([]float64, 50000)
+		for i := range xs {
+			x := Hypergeometric(r, c.good, c.bad, c.sample)
+			assert.LessOrEqual(t, x, c.good)
+			assert.LessOrEqual(t, c.sample-x, c.bad)
+			xs[i] = float64(x)
+		}
+		g, b, s := float64(c.good), float64(c.bad), float64(c.sample)
+		n := g + b
+		mean, variance := meanAndVariance(xs)
+		expectedVariance := s * g * b * (n - s) / (n * n * (n - 1))
+		assert.InDelta(t, s*g/n, mean, 0.02*mean+0.01, c)
+		assert.InDelta(t, expectedVariance, variance, 0.05*expectedVariance+0.01, c)
+	}
+	assert.Panics(t, func() { Hypergeometric(r, 1, 1, 3) })
+}
+
+func Test_NegativeBinomial(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(2)
+	for _, c := range []struct{ n, p float64 }{
+		{1, 0.5},
+		{3.5, 0.2},
+		{50, 0.9},
+		{100, 0.1},
+		{0.5, 0.3},
+		// the gamma Poisson mixture
+		{20, 0.001},
+	} {
+		xs := make([]float64, 50000)
+		for i := range xs {
+			xs[i] = float64(NegativeBinomial(r, c.n, c.p))
+		}
+		mean, variance := meanAndVariance(xs)
+		expectedMean := c.n * (1 - c.p) / c.p
+		expectedVariance := expectedMean / c.p
+		// 5 standard errors of the mean
+		assert.InDelta(t, expectedMean, mean, 5*math.Sqrt(expectedVariance/float64(len(xs))), c)
+		assert.InEpsilon(t, expectedVariance, variance, 0.1, c)
+	}
+	assert.Equal(t, uint64(0), NegativeBinomial(r, 3, 1))
+	for _, n := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		assert.Panics(t, func() { NegativeBinomial(r, n, 0.5) }, n)
+	}
+	assert.Panics(t, func() { NegativeBinomial(r, 3, math.NaN()) })
+	// p <= 0 would reach the gamma Poisson mixture with an infinite or negative mean
+	assert.Panics(t, func() { NegativeBinomial(r, 3, 0) })
+	assert.Panics(t, func() { NegativeBinomial(r, 3, -0.5) })
+	assert.Panics(t, func() { NewSyncPoolXoshiro256ssRNG().NegativeBinomial(0, 0.5) })
+	assert.Equal(t, uint64(0), NewSyncPoolXoshiro256ssRNG().NegativeBinomial(3, 1))
+}
+
+func Test_Geometric(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(3)
+	for _, p := range []float64{0.9, 0.5, 0.1, 0.001} {
+		xs := make([]float64, 50000)
+		zeros := 0
+		for i := range xs {
+			x := Geometric(r, p)
+			if x == 0 {
+				zeros++
+			}
+			xs[i] = float64(x)
+		}
+		mean, variance := meanAndVariance(xs)
+		expectedMean := (1 - p) / p
+		expectedVariance := expectedMean / p
+		assert.InDelta(t, expectedMean, mean, 5*math.Sqrt(expectedVariance/float64(len(xs))), p)
+		assert.InEpsilon(t, expectedVariance, variance, 0.1, p)
+		// P(0) is p
+		assert.InDelta(t, p, float64(zeros)/float64(len(xs)), 5*math.Sqrt(p*(1-p)/float64(len(xs))), p)
+	}
+	assert.Equal(t, uint64(0), Geometric(r, 1))
+	assert.Equal(t, uint64(0), NewSyncPoolXoshiro256ssRNG().Geometric(1))
+	assert.Panics(t, func() { Geometric(r, 0) })
+	assert.Panics(t, func() { Geometric(r, math.NaN()) })
+}
@@ -0,0 +1,154 @@
+package fastrand64
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Distribution is a continuous distribution with fixed, already validated parameters,
+// that can draw samples from any thread unsafe RNG.
+//
+// Quantile is the inverse CDF, it returns NaN for p outside [0, 1]. Feeding it stratified or
+// antithetic uniforms gives variance reduction, and feeding it correlated uniforms gives copulas.
+type Distribution interface {
+	Sample(r UnsafeRNG) float64
+	Quantile(p float64) float64
+}
+
+// SampleDist draws one sample of d using a pool checkout
+func (s *ThreadsafePoolRNG) SampleDist(d Distribution) float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := d.Sample(r)
+	rngPool.Put(r)
+	return x
+}
+
+// FillDist fills dst with samples of d using a single pool checkout
+func (s *ThreadsafePoolRNG) FillDist(d Distribution, dst []float64) []float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	for i := range dst {
+		dst[i] = d.Sample(r)
+	}
+	rngPool.Put(r)
+	return dst
+}
+
+// distributions build each named distribution from its constructor's arguments, params is how
+// many it takes, -1 for any number
+var distributions = map[string]struct {
+	params int
+	new    func(p []float64) (Distribution, error)
+}{
+	"stable":        {4, func(p []float64) (Distribution, error) { return NewStable(p[0], p[1], p[2], p[3]) }},
+	"levy":          {2, func(p []float64) (Distribution, error) { return NewLevy(p[0], p[1]) }},
+	"vonmises":      {2, func(p []float64) (Distribution, error) { return NewVonMises(p[0], p[1]) }},
+	"wrappednormal": {2, func(p []float64) (Distribution, error) { return NewWrappedNormal(p[0], p[1]) }},
+	"gumbel":        {2, func(p []float64) (Distribution, error) { return NewGumbel(p[0], p[1]) }},
+	"frechet":       {3, func(p []float64) (Distribution, error) { return NewFrechet(p[0], p[1], p[2]) }},
+	"gev":           {3, func(p []float64) (Distribution, error) { return NewGEV(p[0], p[1], p[2]) }},
+	"lognormal":     {2, func(p []float64) (Distribution, error) { return NewLogNormal(p[0], p[1]) }},
+	"gamma":         {2, func(p []float64) (Distribution, error) { return NewGamma(p[0], p[1]) }},
+	"beta":          {2, func(p []float64) (Distribution, error) { return NewBeta(p[0], p[1]) }},
+	"pareto":        {2, func(p []float64) (Distribution, error) { return NewPareto(p[0], p[1]) }},
+	"weibull":       {2, func(p []float64) (Distribution, error) { return NewWeibull(p[0], p[1]) }},
+	"empirical":     {-1, func(p []float64) (Distribution, error) { return NewEmpirical(p) }},
+	"histogram": {-1, func(p []float64) (Distribution, error) {
+		if len(p)%2 == 0 {
+			return nil, errors.New("histogram needs n+1 bucket bounds followed by n counts")
+		}
+		return NewHistogramDist(p[:len(p)/2+1], p[len(p)/2+1:])
+	}},
+}
+
+// NewDistribution constructs a distribution by name from its constructor's arguments in order,
+// eg NewDistribution("gamma", shape, scale), for config driven code. The params of "empirical" are
+// its samples, and of "histogram" the bucket bounds followed by the bucket counts
+func NewDistribution(name string, params ...float64) (Distribution, error) {
+	d, ok := distributions[name]
+	if !ok {
+		return nil, fmt.Errorf("no distribution named %q", name)
+	}
+	if d.params >= 0 && len(params) != d.params {
+		return nil, fmt.Errorf("distribution %s takes %d params, got %d", name, d.params, len(params))
+	}
+	return d.new(params)
+}
+
+// DistributionNames lists the names NewDistribution accepts, sorted
+func DistributionNames() []string {
+	var names []string
+	for name := range distributions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// invertCDF finds x in [lo, hi] with cdf(x) = p by bisection, first widening lo and hi
+// geometrically while they do not bracket p, so distributions with infinite support can pass a guess
+func invertCDF(cdf func(float64) float64, p float64, lo float64, hi float64) float64 {
+	for step := hi - lo; cdf(lo) > p; step *= 2 {
+		lo -= step
+	}
+	for step := hi - lo; cdf(hi) < p; step *= 2 {
+		hi += step
+	}
+	for i := 0; i < 200 && hi-lo > 1e-13*math.Max(1, math.Abs(lo)); i++ {
+		mid := lo + (hi-lo)/2
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// integrate returns the integral of f over [a, b] by adaptive Simpson quadrature
+func integrate(f func(float64) float64, a float64, b float64) float64 {
+	fa, fm, fb := f(a), f((a+b)/2), f(b)
+	return adaptiveSimpson(f, a, b, fa, fm, fb, (b-a)/6*(fa+4*fm+fb), 1e-11, 24)
+}
+
+func adaptiveSimpson(f func(float64) float64, a, b, fa, fm, fb, whole, eps float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := f((a+m)/2), f((m+b)/2)
+	left := (m - a) / 6 * (fa + 4*lm + fm)
+	right := (b - m) / 6 * (fm + 4*rm + fb)
+	if depth <= 0 || math.Abs(left+right-whole) <= 15*eps {
+		return left + right + (left+right-whole)/15
+	}
+	return adaptiveSimpson(f, a, m, fa, lm, fm, left, eps/2, depth-1) +
+		adaptiveSimpson(f, m, b, fm, rm, fb, right, eps/2, depth-1)
+}
+
+// normalQuantile is the standard normal inverse CDF
+func normalQuantile(p float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// openUnitFloat64 returns a pseudorandom float64 in the open range (0..1), for inverse CDFs with
+// infinities at both ends
+func openUnitFloat64(r UnsafeRNG) float64 {
+	return (float64(r.Uint64()>>11) + 0.5) * (1.0 / (1 << 53))
+}
+
+// normal draws one standard normal with the polar method, discarding the second value of the pair
+func normal(r UnsafeRNG) float64 {
+	for {
+		u := 2*unitFloat64(r) - 1
+		v := 2*unitFloat64(r) - 1
+		if q := u*u + v*v; q > 0 && q < 1 {
+			return u * math.Sqrt(-2*math.Log(q)/q)
+		}
+	}
+}
+
+// exponential draws one Exp(1)
+func exponential(r UnsafeRNG) float64 {
+	return -math.Log(1 - unitFloat64(r))
+}
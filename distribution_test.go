@@ -0,0 +1,105 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDistributions(t *testing.T) map[string]Distribution {
+	dists := map[string]Distribution{}
+	add := func(name string, d Distribution, err error) {
+		assert.NoError(t, err, name)
+		dists[name] = d
+	}
+	stable, err := NewStable(1.5, 0.5, 1, 0)
+	add("stable", stable, err)
+	skewed, err := NewStable(0.7, -0.3, 2, 1)
+	add("stable skewed", skewed, err)
+	levy, err := NewLevy(0, 1)
+	add("levy", levy, err)
+	vonMises, err := NewVonMises(1, 2)
+	add("von mises", vonMises, err)
+	wrapped, err := NewWrappedNormal(-2, 2)
+	add("wrapped normal", wrapped, err)
+	gumbel, err := NewGumbel(1, 2)
+	add("gumbel", gumbel, err)
+	frechet, err := NewFrechet(3, 1, 0)
+	add("frechet", frechet, err)
+	gev, err := NewGEV(0, 1, -0.2)
+	add("gev", gev, err)
+	empirical, err := NewEmpirical([]float64{3, 1, 4, 1.5, 5, 9, 2, 6})
+	add("empirical", empirical, err)
+	histogram, err := NewHistogramDist([]float64{0, 1, 10}, []float64{3, 1})
+	add("histogram", histogram, err)
+	lognormal, err := NewLogNormal(1, 0.5)
+	add("lognormal", lognormal, err)
+	gamma, err := NewGamma(0.7, 2)
+	add("gamma", gamma, err)
+	beta, err := NewBeta(2.5, 0.8)
+	add("beta", beta, err)
+	pareto, err := NewPareto(1.5, 2)
+	add("pareto", pareto, err)
+	weibull, err := NewWeibull(1.5, 2)
+	add("weibull", weibull, err)
+	return dists
+}
+
+func Test_Distribution_Quantile(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	ps := []float64{0.05, 0.25, 0.5, 0.75, 0.95}
+	for name, d := range testDistributions(t) {
+		for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+			assert.True(t, math.IsNaN(d.Quantile(p)), name)
+		}
+		qs := make([]float64, len(ps))
+		for j, p := range ps {
+			qs[j] = d.Quantile(p)
+			if j > 0 {
+				assert.Greater(t, qs[j], qs[j-1], name)
+			}
+		}
+
+		// probability integral transform, the fraction of samples below Quantile(p) is about p.
+		// Circular distributions sample in [-pi, pi) but quantiles are on the circle cut opposite mu
+		const n = 20000
+		below := make([]int, len(ps))
+		for i := 0; i < n; i++ {
+			x := d.Sample(rng)
+			switch c := d.(type) {
+			case *VonMises:
+				x = c.mu + wrapAngle(x-c.mu)
+			case *WrappedNormal:
+				x = c.mu + wrapAngle(x-c.mu)
+			}
+			for j, q := range qs {
+				if x <= q {
+					below[j]++
+				}
+			}
+		}
+		for j, p := range ps {
+			assert.InDelta(t, p, float64(below[j])/n, 0.015, name)
+		}
+	}
+}
+
+func Test_NewDistribution(t *testing.T) {
+	d, err := NewDistribution("gamma", 2, 3)
+	assert.NoError(t, err)
+	expected, _ := NewGamma(2, 3)
+	assert.Equal(t, expected.Quantile(0.3), d.Quantile(0.3))
+
+	d, err = NewDistribution("histogram", 0, 10, 20, 1, 3)
+	assert.NoError(t, err)
+	assert.InDelta(t, 10, d.Quantile(0.25), 1e-9)
+
+	_, err = NewDistribution("histogram", 0, 10, 1, 3)
+	assert.Error(t, err)
+	_, err = NewDistribution("gamma", 2)
+	assert.Error(t, err)
+	_, err = NewDistribution("nope")
+	assert.Error(t, err)
+	assert.Contains(t, DistributionNames(), "stable")
+}
@@ -0,0 +1,47 @@
+package fastrand64
+
+import "math"
+
+// PerturbFlow mixes a flow hash with a salt into a per-flow pseudorandom value. The same flow and
+// salt always give the same value, a new salt (eg rng.Uint64() at startup) reshuffles every flow.
+func PerturbFlow(flowHash uint64, salt uint64) uint64 {
+	return Splitmix64(flowHash ^ salt)
+}
+
+// NextHopTable is a weighted next-hop lookup table for ECMP style forwarding. Select is a single
+// table lookup, and since buckets are filled by weighted rendezvous hashing, changing one hop's
+// weight only moves flows to or from that hop. Safe for concurrent Select calls.
+type NextHopTable struct {
+	salt  uint64
+	mask  uint64
+	table []int
+}
+
+// NewNextHopTable builds a table of 2**tableBits buckets shared out in proportion to weights.
+// Hops with weight <= 0 are never selected, at least one weight must be positive.
+func NewNextHopTable(weights []float64, tableBits uint, salt uint64) *NextHopTable {
+	t := &NextHopTable{salt: salt, mask: 1<<tableBits - 1, table: make([]int, 1<<tableBits)}
+	for bucket := range t.table {
+		best, bestScore := -1, 0.0
+		for hop, w := range weights {
+			if w <= 0 {
+				continue
+			}
+			h := Splitmix64(Splitmix64(salt+uint64(bucket)) + uint64(hop))
+			u := (float64(h>>11) + 0.5) * (1.0 / (1 << 53))
+			if score := w / -math.Log(u); best < 0 || score > bestScore {
+				best, bestScore = hop, score
+			}
+		}
+		if best < 0 {
+			panic("NewNextHopTable needs at least one positive weight")
+		}
+		t.table[bucket] = best
+	}
+	return t
+}
+
+// Select returns the next hop index for a flow, stable for the lifetime of the table
+func (t *NextHopTable) Select(flowHash uint64) int {
+	return t.table[PerturbFlow(flowHash, t.salt)&t.mask]
+}
@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PerturbFlow(t *testing.T) {
+	assert.Equal(t, PerturbFlow(1234, 99), PerturbFlow(1234, 99))
+	assert.NotEqual(t, PerturbFlow(1234, 99), PerturbFlow(1234, 100))
+	assert.NotEqual(t, PerturbFlow(1234, 99), PerturbFlow(1235, 99))
+}
+
+func Test_NextHopTable_Weights(t *testing.T) {
+	table := NewNextHopTable([]float64{1, 2, 0, 1}, 12, 42)
+	counts := make([]int, 4)
+	for flow := uint64(0); flow < 100000; flow++ {
+		hop := table.Select(flow)
+		assert.Equal(t, hop, table.Select(flow))
+		counts[hop]++
+	}
+	assert.Equal(t, 0, counts[2])
+	assert.InDelta(t, 0.25, float64(counts[0])/100000, 0.02)
+	assert.InDelta(t, 0.50, float64(counts[1])/100000, 0.02)
+	assert.InDelta(t, 0.25, float64(counts[3])/100000, 0.02)
+}
+
+func Test_NextHopTable_MinimalDisruption(t *testing.T) {
+	before := NewNextHopTable([]float64{1, 1, 1, 1}, 10, 7)
+	after := NewNextHopTable([]float64{1, 1, 0, 1}, 10, 7)
+	moved := 0
+	for flow := uint64(0); flow < 10000; flow++ {
+		b, a := before.Select(flow), after.Select(flow)
+		if b != 2 {
+			// flows not on the drained hop stay pinned
+			assert.Equal(t, b, a)
+		} else {
+			moved++
+		}
+	}
+	assert.InDelta(t, 2500, moved, 250)
+}
+
+func Test_NextHopTable_NoWeights(t *testing.T) {
+	assert.Panics(t, func() { NewNextHopTable([]float64{0, -1}, 4, 0) })
+}
+
+func Benchmark_NextHopTable_Select(b *testing.B) {
+	table := NewNextHopTable([]float64{1, 2, 3, 4}, 12, 42)
+	var r int
+	for i := 0; i < b.N; i++ {
+		r = table.Select(uint64(i))
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,81 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Empirical resamples observed data, for example production latencies or payload sizes in a load
+// test. By default it samples the piecewise linear inverse CDF through the sorted observations, so
+// values stay within [min, max] of the data. Smoothed returns a kernel density variant.
+type Empirical struct {
+	sorted    []float64
+	bandwidth float64
+}
+
+// NewEmpirical copies and sorts samples, which must be non empty and finite
+func NewEmpirical(samples []float64) (*Empirical, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("Empirical needs at least one sample")
+	}
+	sorted := make([]float64, len(samples))
+	for i, x := range samples {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, errors.New("Empirical samples must be finite")
+		}
+		sorted[i] = x
+	}
+	sort.Float64s(sorted)
+	return &Empirical{sorted: sorted}, nil
+}
+
+// Smoothed returns a copy that samples a gaussian kernel density estimate of the data, a random
+// observation plus bandwidth * N(0, 1). bandwidth <= 0 picks Silverman's rule of thumb.
+// Smoothed samples can fall outside the observed range.
+func (d *Empirical) Smoothed(bandwidth float64) *Empirical {
+	if !(bandwidth > 0) {
+		bandwidth = d.silvermanBandwidth()
+	}
+	return &Empirical{sorted: d.sorted, bandwidth: bandwidth}
+}
+
+// silvermanBandwidth is 0.9 * min(stddev, IQR/1.34) * n^(-1/5)
+func (d *Empirical) silvermanBandwidth() float64 {
+	n := float64(len(d.sorted))
+	mean := 0.0
+	for _, x := range d.sorted {
+		mean += x
+	}
+	mean /= n
+	variance := 0.0
+	for _, x := range d.sorted {
+		variance += (x - mean) * (x - mean)
+	}
+	spread := math.Sqrt(variance / n)
+	if iqr := (d.Quantile(0.75) - d.Quantile(0.25)) / 1.34; iqr > 0 && iqr < spread {
+		spread = iqr
+	}
+	return 0.9 * spread * math.Pow(n, -0.2)
+}
+
+// Quantile returns the linearly interpolated empirical quantile of the data, it ignores smoothing
+func (d *Empirical) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	h := p * float64(len(d.sorted)-1)
+	i := int(h)
+	if i >= len(d.sorted)-1 {
+		return d.sorted[len(d.sorted)-1]
+	}
+	return d.sorted[i] + (h-float64(i))*(d.sorted[i+1]-d.sorted[i])
+}
+
+// Sample draws by inversion of the empirical CDF, or from the kernel density when smoothed
+func (d *Empirical) Sample(r UnsafeRNG) float64 {
+	if d.bandwidth > 0 {
+		return d.sorted[intn(r, len(d.sorted))] + d.bandwidth*normal(r)
+	}
+	return d.Quantile(unitFloat64(r))
+}
@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Empirical(t *testing.T) {
+	d, err := NewEmpirical([]float64{5, 1, 3, 2, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, d.Quantile(0))
+	assert.Equal(t, 3.0, d.Quantile(0.5))
+	assert.Equal(t, 3.5, d.Quantile(0.625))
+	assert.Equal(t, 5.0, d.Quantile(1))
+	assert.True(t, math.IsNaN(d.Quantile(-0.1)))
+
+	xs := NewSyncPoolXoshiro256ssRNG().FillDist(d, make([]float64, 100000))
+	for _, x := range xs {
+		assert.True(t, x >= 1 && x <= 5)
+	}
+	mean, _ := meanAndVariance(xs)
+	assert.InDelta(t, 3, mean, 0.02)
+
+	single, err := NewEmpirical([]float64{7})
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, single.Sample(NewUnsafeXoshiro256ssRNG(1)))
+}
+
+func Test_Empirical_Smoothed(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	gumbel, _ := NewGumbel(10, 2)
+	data := rng.FillDist(gumbel, make([]float64, 5000))
+	d, err := NewEmpirical(data)
+	assert.NoError(t, err)
+
+	smooth := d.Smoothed(0)
+	assert.Greater(t, smooth.bandwidth, 0.0)
+	xs := rng.FillDist(smooth, make([]float64, 100000))
+	// kernel smoothing keeps the mean and inflates the variance by bandwidth^2
+	dataMean, dataVariance := meanAndVariance(data)
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, dataMean, mean, 0.05)
+	assert.InEpsilon(t, dataVariance+smooth.bandwidth*smooth.bandwidth, variance, 0.05)
+
+	assert.Equal(t, 0.5, d.Smoothed(0.5).bandwidth)
+}
+
+func Test_Empirical_Errors(t *testing.T) {
+	for _, samples := range [][]float64{nil, {1, math.NaN()}, {math.Inf(1)}} {
+		d, err := NewEmpirical(samples)
+		assert.Error(t, err)
+		assert.Nil(t, d)
+	}
+}
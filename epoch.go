@@ -0,0 +1,51 @@
+package fastrand64
+
+import "time"
+
+// EpochRNG rotates to a fresh deterministic generator every epoch, eg every hour, so sampling
+// decisions are reproducible within an epoch, and can be replayed later with ForEpoch, but change
+// from one epoch to the next. Epoch e's stream is xoshiro256** seeded from master.Child(e), so it
+// depends only on the master sequence and the epoch number. It calls epoch on every draw. It is
+// unsafe to call UnsafeRNG methods from concurrent goroutines, and generators sharing a master
+// share their streams, give each goroutine its own master, eg from master.Spawn.
+type EpochRNG struct {
+	master  SeedSeq
+	epoch   func() uint64
+	current uint64
+	rng     *UnsafeXoshiro256ssRNG // nil before the first draw
+}
+
+// NewEpochRNG makes a generator that rotates whenever epoch's value changes, see TimeEpoch
+func NewEpochRNG(master SeedSeq, epoch func() uint64) *EpochRNG {
+	return &EpochRNG{master: master, epoch: epoch}
+}
+
+// TimeEpoch numbers the periods since the Unix epoch, eg TimeEpoch(time.Hour) for hourly rotation
+func TimeEpoch(period time.Duration) func() uint64 {
+	if period <= 0 {
+		panic("TimeEpoch period must be > 0")
+	}
+	return func() uint64 {
+		return uint64(time.Now().UnixNano() / int64(period))
+	}
+}
+
+// Uint64 draws from the current epoch's generator, starting a new one when the epoch changes, (not thread safe)
+func (e *EpochRNG) Uint64() uint64 {
+	if epoch := e.epoch(); e.rng == nil || epoch != e.current {
+		e.current = epoch
+		e.rng = e.ForEpoch(epoch)
+	}
+	return e.rng.Uint64()
+}
+
+// Epoch returns the epoch of the latest draw, 0 before the first
+func (e *EpochRNG) Epoch() uint64 {
+	return e.current
+}
+
+// ForEpoch returns a new generator at the start of epoch's stream, to replay the draws of an
+// earlier epoch. It doesn't change e
+func (e *EpochRNG) ForEpoch(epoch uint64) *UnsafeXoshiro256ssRNG {
+	return e.master.Child(epoch).NewXoshiro256ssRNG()
+}
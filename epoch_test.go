@@ -0,0 +1,38 @@
+package fastrand64
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EpochRNG(t *testing.T) {
+	master := NewSeedSeq([]byte("experiment"))
+	epoch := uint64(5)
+	rng := NewEpochRNG(master, func() uint64 { return epoch })
+	assert.Equal(t, uint64(0), rng.Epoch())
+
+	first := []uint64{rng.Uint64(), rng.Uint64(), rng.Uint64()}
+	assert.Equal(t, uint64(5), rng.Epoch())
+	replay := rng.ForEpoch(5)
+	assert.Equal(t, first, []uint64{replay.Uint64(), replay.Uint64(), replay.Uint64()})
+	assert.Equal(t, master.Child(5).NewXoshiro256ssRNG().Uint64(), first[0])
+
+	// a new epoch starts a new stream, and going back restarts the old one
+	epoch = 6
+	assert.Equal(t, rng.ForEpoch(6).Uint64(), rng.Uint64())
+	assert.NotEqual(t, first[0], rng.ForEpoch(6).Uint64())
+	epoch = 5
+	assert.Equal(t, first[0], rng.Uint64())
+
+	// the same master and epoch reproduce, a different master doesn't
+	assert.Equal(t, first[0], NewEpochRNG(master, func() uint64 { return 5 }).Uint64())
+	assert.NotEqual(t, first[0], NewEpochRNG(NewSeedSeq([]byte("other")), func() uint64 { return 5 }).Uint64())
+}
+
+func Test_TimeEpoch(t *testing.T) {
+	hourly := TimeEpoch(time.Hour)
+	assert.Equal(t, uint64(time.Now().Unix()/3600), hourly())
+	assert.Panics(t, func() { TimeEpoch(0) })
+}
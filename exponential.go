@@ -0,0 +1,77 @@
+package fastrand64
+
+import "math"
+
+// The ziggurat tables for the standard exponential, Marsaglia and Tsang's 256 layers, with the
+// layer from the low 8 bits of a Uint64 and the position from the top 56, like the normal's
+const (
+	zigguratExpR     = 7.69711747013104972 // where the base strip's tail starts
+	zigguratExpV     = 3.949659822581572e-3
+	zigguratExpScale = 1 << 56
+)
+
+var zigguratExpK, zigguratExpW, zigguratExpF = zigguratExpTables()
+
+// zigguratExpTables is Marsaglia and Tsang's zigset for the exponential
+func zigguratExpTables() (k [256]uint64, w [256]float64, f [256]float64) {
+	de, te := zigguratExpR, zigguratExpR
+	q := zigguratExpV / math.Exp(-de)
+	k[0] = uint64(de / q * zigguratExpScale)
+	k[1] = 0
+	w[0] = q / zigguratExpScale
+	w[255] = de / zigguratExpScale
+	f[0] = 1
+	f[255] = math.Exp(-de)
+	for i := 254; i >= 1; i-- {
+		de = -math.Log(zigguratExpV/de + math.Exp(-de))
+		k[i+1] = uint64(de / te * zigguratExpScale)
+		te = de
+		f[i] = math.Exp(-de)
+		w[i] = de / zigguratExpScale
+	}
+	return k, w, f
+}
+
+// expFloat64 draws one Exp(1) with the ziggurat
+func expFloat64(r UnsafeRNG) float64 {
+	for {
+		u := r.Uint64()
+		i := u & 255
+		j := u >> 8
+		x := float64(j) * zigguratExpW[i]
+		if j < zigguratExpK[i] {
+			return x
+		}
+		if i == 0 {
+			// the tail beyond R is R plus another Exp(1), the memoryless property
+			return zigguratExpR - math.Log(openUnitFloat64(r))
+		}
+		if zigguratExpF[i]+unitFloat64(r)*(zigguratExpF[i-1]-zigguratExpF[i]) < math.Exp(-x) {
+			return x
+		}
+	}
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate 1, mean 1, eg the gaps between
+// arrivals of a Poisson process, see Exp for other rates. It uses the ziggurat. Threadsafe
+func (s *ThreadsafePoolRNG) ExpFloat64() float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := expFloat64(r)
+	rngPool.Put(r)
+	return x
+}
+
+// Exp returns an exponentially distributed float64 with rate lambda, mean 1/lambda, eg the time to
+// the next arrival when lambda arrive per second. It panics unless lambda is finite and > 0. Threadsafe
+func (s *ThreadsafePoolRNG) Exp(lambda float64) float64 {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		panic("Exp lambda must be finite and > 0")
+	}
+	return s.ExpFloat64() / lambda
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate 1 from the Default RNG. Threadsafe
+func ExpFloat64() float64 {
+	return defaultRNG().ExpFloat64()
+}
@@ -0,0 +1,45 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExpFloat64(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	n := 1000000
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = expFloat64(r)
+		assert.True(t, xs[i] >= 0)
+	}
+	mean, variance := meanAndVariance(xs)
+	// Exp(1) has mean 1 and variance 1, the sample variance has variance (mu4 - 1)/n = 8/n
+	assert.InDelta(t, 1, mean, 5/math.Sqrt(float64(n)))
+	assert.InDelta(t, 1, variance, 5*math.Sqrt(8/float64(n)))
+
+	// the CDF, through the rectangles, the wedges and the tail
+	for _, x := range []float64{0.01, 0.1, 0.5, 1, 2, 4, zigguratExpR, 9} {
+		p := 1 - math.Exp(-x)
+		below := 0
+		for _, v := range xs {
+			if v < x {
+				below++
+			}
+		}
+		assert.InDelta(t, p, float64(below)/float64(n), 5*math.Sqrt(p*(1-p)/float64(n))+1e-6, x)
+	}
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var sum float64
+	for i := 0; i < 100000; i++ {
+		sum += rng.Exp(4)
+	}
+	assert.InDelta(t, 0.25, sum/100000, 5*0.25/math.Sqrt(100000))
+	assert.True(t, ExpFloat64() >= 0)
+	for _, lambda := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		assert.Panics(t, func() { rng.Exp(lambda) }, lambda)
+	}
+}
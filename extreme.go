@@ -0,0 +1,99 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// Gumbel is the type I extreme value distribution with location mu and scale beta, the limit of
+// maxima of light tailed samples. Its mean is mu + beta*EulerGamma
+type Gumbel struct {
+	mu, beta float64
+}
+
+// NewGumbel validates beta > 0
+func NewGumbel(mu float64, beta float64) (*Gumbel, error) {
+	if !(beta > 0) || math.IsInf(beta, 1) {
+		return nil, errors.New("Gumbel beta must be finite and > 0")
+	}
+	return &Gumbel{mu: mu, beta: beta}, nil
+}
+
+// Quantile returns the inverse CDF, mu - beta*ln(-ln p)
+func (d *Gumbel) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return d.mu - d.beta*math.Log(-math.Log(p))
+}
+
+// Sample draws by inversion
+func (d *Gumbel) Sample(r UnsafeRNG) float64 {
+	return d.Quantile(openUnitFloat64(r))
+}
+
+// Frechet is the type II extreme value distribution with shape alpha, scale s and minimum m, the
+// limit of maxima of heavy tailed samples. The mean is finite only for alpha > 1
+type Frechet struct {
+	alpha, s, m float64
+}
+
+// NewFrechet validates alpha > 0 and s > 0
+func NewFrechet(alpha float64, s float64, m float64) (*Frechet, error) {
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		return nil, errors.New("Frechet alpha must be finite and > 0")
+	}
+	if !(s > 0) || math.IsInf(s, 1) {
+		return nil, errors.New("Frechet scale must be finite and > 0")
+	}
+	return &Frechet{alpha: alpha, s: s, m: m}, nil
+}
+
+// Quantile returns the inverse CDF, m + s*(-ln p)^(-1/alpha)
+func (d *Frechet) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return d.m + d.s*math.Pow(-math.Log(p), -1/d.alpha)
+}
+
+// Sample draws by inversion
+func (d *Frechet) Sample(r UnsafeRNG) float64 {
+	return d.Quantile(openUnitFloat64(r))
+}
+
+// GEV is the generalized extreme value distribution with location mu, scale sigma and shape xi.
+// xi = 0 is Gumbel, xi > 0 is Fréchet like with a lower bound, xi < 0 is reversed Weibull with an
+// upper bound mu - sigma/xi
+type GEV struct {
+	mu, sigma, xi float64
+}
+
+// NewGEV validates sigma > 0 and a finite xi
+func NewGEV(mu float64, sigma float64, xi float64) (*GEV, error) {
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("GEV sigma must be finite and > 0")
+	}
+	if math.IsNaN(xi) || math.IsInf(xi, 0) {
+		return nil, errors.New("GEV xi must be finite")
+	}
+	return &GEV{mu: mu, sigma: sigma, xi: xi}, nil
+}
+
+// Quantile returns the inverse CDF, mu + sigma*((-ln p)^-xi - 1)/xi
+func (d *GEV) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	y := -math.Log(p)
+	if math.Abs(d.xi) < 1e-12 {
+		return d.mu - d.sigma*math.Log(y)
+	}
+	// expm1 keeps precision for xi near 0
+	return d.mu + d.sigma*math.Expm1(-d.xi*math.Log(y))/d.xi
+}
+
+// Sample draws by inversion
+func (d *GEV) Sample(r UnsafeRNG) float64 {
+	return d.Quantile(openUnitFloat64(r))
+}
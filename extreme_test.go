@@ -0,0 +1,82 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eulerGamma is the Euler-Mascheroni constant
+const eulerGamma = 0.5772156649015329
+
+func Test_Gumbel(t *testing.T) {
+	d, err := NewGumbel(1, 2)
+	assert.NoError(t, err)
+	mean, variance := meanAndVariance(NewSyncPoolXoshiro256ssRNG().FillDist(d, make([]float64, 200000)))
+	assert.InDelta(t, 1+2*eulerGamma, mean, 0.03)
+	assert.InEpsilon(t, math.Pi*math.Pi/6*4, variance, 0.03)
+
+	assert.InDelta(t, 1-2*math.Log(math.Ln2), d.Quantile(0.5), 1e-12)
+	assert.True(t, math.IsInf(d.Quantile(0), -1))
+	assert.True(t, math.IsInf(d.Quantile(1), 1))
+	assert.True(t, math.IsNaN(d.Quantile(1.5)))
+
+	_, err = NewGumbel(0, 0)
+	assert.Error(t, err)
+}
+
+func Test_Frechet(t *testing.T) {
+	d, err := NewFrechet(3, 2, 1)
+	assert.NoError(t, err)
+	// the mean is m + s*Gamma(1 - 1/alpha)
+	r := NewUnsafeXoshiro256ssRNG(2)
+	xs := make([]float64, 200000)
+	for i := range xs {
+		xs[i] = d.Sample(r)
+	}
+	mean, _ := meanAndVariance(xs)
+	assert.InEpsilon(t, 1+2*math.Gamma(1-1.0/3), mean, 0.02)
+	assert.Equal(t, 1.0, d.Quantile(0))
+	assert.InDeltaSlice(t, []float64{d.Quantile(0.1), d.Quantile(0.5), d.Quantile(0.9)},
+		sampleQuantiles(d, 100000, 0.1, 0.5, 0.9), 0.05)
+
+	_, err = NewFrechet(0, 1, 0)
+	assert.Error(t, err)
+	_, err = NewFrechet(1, -1, 0)
+	assert.Error(t, err)
+}
+
+func Test_GEV(t *testing.T) {
+	// xi = 0 matches Gumbel, xi > 0 matches Frechet with alpha = 1/xi, s = sigma/xi, m = mu - s
+	gev, _ := NewGEV(1, 2, 0)
+	gumbel, _ := NewGumbel(1, 2)
+	gev2, _ := NewGEV(1, 2, 0.25)
+	frechet, _ := NewFrechet(4, 8, -7)
+	for _, p := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		assert.InDelta(t, gumbel.Quantile(p), gev.Quantile(p), 1e-12)
+		assert.InDelta(t, frechet.Quantile(p), gev2.Quantile(p), 1e-9)
+	}
+
+	// xi < 0 is bounded above by mu - sigma/xi
+	d, err := NewGEV(0, 1, -0.5)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2, d.Quantile(1), 1e-12)
+	for _, x := range NewSyncPoolXoshiro256ssRNG().FillDist(d, make([]float64, 10000)) {
+		assert.LessOrEqual(t, x, 2.0)
+	}
+	assert.InDeltaSlice(t, []float64{d.Quantile(0.1), d.Quantile(0.5), d.Quantile(0.9)},
+		sampleQuantiles(d, 100000, 0.1, 0.5, 0.9), 0.03)
+
+	_, err = NewGEV(0, 1, math.NaN())
+	assert.Error(t, err)
+}
+
+func Test_GEV_Deterministic(t *testing.T) {
+	d, _ := NewGEV(0, 1, 0.1)
+	r1 := NewUnsafeXoshiro256ssRNG(42)
+	r2 := NewUnsafeXoshiro256ssRNG(42)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, d.Sample(r1), d.Sample(r2))
+	}
+}
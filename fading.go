@@ -0,0 +1,50 @@
+package fastrand64
+
+import "math"
+
+// RayleighFading fills dst with complex channel gains h ~ CN(0, omega) from a thread unsafe RNG,
+// so |h| is Rayleigh distributed and E[|h|^2] = omega
+func RayleighFading(r UnsafeRNG, dst []complex128, omega float64) []complex128 {
+	return fillFading(r, dst, 0, math.Sqrt(omega/2))
+}
+
+// RicianFading fills dst with complex channel gains with Rician factor k (line of sight to scattered
+// power ratio) and E[|h|^2] = omega. The line of sight component has phase 0, k = 0 is Rayleigh.
+func RicianFading(r UnsafeRNG, dst []complex128, k float64, omega float64) []complex128 {
+	return fillFading(r, dst, math.Sqrt(k*omega/(k+1)), math.Sqrt(omega/(2*(k+1))))
+}
+
+// fillFading sets dst[i] = los + sigma*(x + iy) for standard normal x, y, drawing normals in batches
+func fillFading(r UnsafeRNG, dst []complex128, los float64, sigma float64) []complex128 {
+	var scratch [256]float64
+	for i := 0; i < len(dst); {
+		n := len(dst) - i
+		if n > len(scratch)/2 {
+			n = len(scratch) / 2
+		}
+		GaussianNoise(r, scratch[:2*n], sigma)
+		for j := 0; j < n; j++ {
+			dst[i+j] = complex(los+scratch[2*j], scratch[2*j+1])
+		}
+		i += n
+	}
+	return dst
+}
+
+// RayleighFading fills dst with CN(0, omega) channel gains using a single pool checkout
+func (s *ThreadsafePoolRNG) RayleighFading(dst []complex128, omega float64) []complex128 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	RayleighFading(r, dst, omega)
+	rngPool.Put(r)
+	return dst
+}
+
+// RicianFading fills dst with Rician channel gains using a single pool checkout
+func (s *ThreadsafePoolRNG) RicianFading(dst []complex128, k float64, omega float64) []complex128 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	RicianFading(r, dst, k, omega)
+	rngPool.Put(r)
+	return dst
+}
@@ -0,0 +1,38 @@
+package fastrand64
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_RayleighFading(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	hs := rng.RayleighFading(make([]complex128, 100001), 2)
+	power, magnitude, mean := 0.0, 0.0, complex(0, 0)
+	for _, h := range hs {
+		power += real(h)*real(h) + imag(h)*imag(h)
+		magnitude += cmplx.Abs(h)
+		mean += h
+	}
+	n := float64(len(hs))
+	assert.InDelta(t, 2, power/n, 0.05)
+	assert.InDelta(t, math.Sqrt(math.Pi*2)/2, magnitude/n, 0.02)
+	assert.InDelta(t, 0, cmplx.Abs(mean/complex(n, 0)), 0.02)
+}
+
+func Test_SafeRNG_RicianFading(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	hs := rng.RicianFading(make([]complex128, 100000), 3, 1)
+	power, mean := 0.0, complex(0, 0)
+	for _, h := range hs {
+		power += real(h)*real(h) + imag(h)*imag(h)
+		mean += h
+	}
+	n := float64(len(hs))
+	assert.InDelta(t, 1, power/n, 0.02)
+	assert.InDelta(t, math.Sqrt(0.75), real(mean)/n, 0.01)
+	assert.InDelta(t, 0, imag(mean)/n, 0.01)
+}
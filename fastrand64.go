@@ -22,14 +22,24 @@
 package fastrand64
 
 import (
+	"math/bits"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ThreadsafePoolRNG core type for the pool backed threadsafe RNG
 type ThreadsafePoolRNG struct {
+	source atomic.Value // *poolSource, replaced whole by SwapSource
+}
+
+// poolSource is the generators of one factory, callers check out of and back into the same
+// poolSource so generators from a swapped out factory are never reused
+type poolSource struct {
 	rngPool sync.Pool
+	seeded  *seededPool  // nil unless made by NewSyncPoolSeededRNG, see Snapshot
+	account *drawAccount // nil unless EnableAccounting was called
 }
 
 // UnsafeRNG is the interface for an unsafe RNG used by the Pool RNG as a source of randomness
@@ -37,27 +47,91 @@ type UnsafeRNG interface {
 	Uint64() uint64
 }
 
+// UnsafeRNG32 is an UnsafeRNG whose native output is 32 bits, so its Uint32 costs about half a
+// Uint64, which matters on 386, ARM32 and TinyGo targets. The pool's Uint32 and Uint32n use it
+// when the pooled generator has it.
+type UnsafeRNG32 interface {
+	UnsafeRNG
+	Uint32() uint32
+}
+
 // NewSyncPoolRNG Wraps a sync.Pool around a thread unsafe RNG, thus making it efficiently thread safe
 func NewSyncPoolRNG(fn func() UnsafeRNG) *ThreadsafePoolRNG {
 	s := &ThreadsafePoolRNG{}
-	s.rngPool = sync.Pool{New: func() interface{} { return fn() }}
+	s.SwapSource(fn)
 	return s
 }
 
+// SwapSource atomically replaces the generator factory, eg to switch algorithms in a long running
+// service after a quality issue is found. Idle generators from the old factory are dropped, and
+// ones checked out at the time of the swap are dropped when they are returned, so after the
+// in flight calls finish every draw comes from the new factory. Threadsafe
+func (s *ThreadsafePoolRNG) SwapSource(factory func() UnsafeRNG) {
+	s.swapSource(factory, nil)
+}
+
+func (s *ThreadsafePoolRNG) swapSource(factory func() UnsafeRNG, seeded *seededPool) {
+	src := &poolSource{seeded: seeded}
+	if old, ok := s.source.Load().(*poolSource); ok && old.account != nil {
+		// accounting carries on across swaps
+		src.account = old.account
+		factory = src.account.counted(factory)
+	}
+	src.rngPool.New = func() interface{} { return factory() }
+	s.source.Store(src)
+}
+
+// pool returns the current generator pool, check generators back into the pool they came from
+func (s *ThreadsafePoolRNG) pool() *sync.Pool {
+	return &s.source.Load().(*poolSource).rngPool
+}
+
 // NewSyncPoolXoshiro256ssRNG conveniently allocations a thread safe pooled back xoshiro256** generator
 // this uses NewSyncPoolRNG internally
 func NewSyncPoolXoshiro256ssRNG() *ThreadsafePoolRNG {
-	rand.Seed(time.Now().UnixNano())
+	seed := time.Now().UnixNano()
+	noteSeed(seed)
+	rand.Seed(seed)
 	return NewSyncPoolRNG(func() UnsafeRNG {
 		return NewUnsafeXoshiro256ssRNG(int64(rand.Uint64()))
 	})
 }
 
+// NewSyncPoolXoshiro256ppRNG conveniently allocations a thread safe pooled back xoshiro256++ generator
+// this uses NewSyncPoolRNG internally
+func NewSyncPoolXoshiro256ppRNG() *ThreadsafePoolRNG {
+	seed := time.Now().UnixNano()
+	noteSeed(seed)
+	rand.Seed(seed)
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		return NewUnsafeXoshiro256ppRNG(int64(rand.Uint64()))
+	})
+}
+
+// NewSyncPoolXoshiro512ssRNG conveniently allocations a thread safe pooled back xoshiro512** generator.
+// Every generator the pool allocates is a Jump further along one randomly seeded sequence, so the
+// streams are guaranteed not to overlap
+func NewSyncPoolXoshiro512ssRNG() *ThreadsafePoolRNG {
+	seed := time.Now().UnixNano()
+	noteSeed(seed)
+	rand.Seed(seed)
+	base := NewUnsafeXoshiro512ssRNG(int64(rand.Uint64()))
+	var mu sync.Mutex
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		mu.Lock()
+		r := *base
+		base.Jump()
+		mu.Unlock()
+		return &r
+	})
+}
+
 // Uint64 returns pseudorandom uint64. Threadsafe
 func (s *ThreadsafePoolRNG) Uint64() uint64 {
-	r := s.rngPool.Get().(UnsafeRNG)
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
 	x := r.Uint64()
-	s.rngPool.Put(r)
+	rngPool.Put(r)
 	return x
 }
 
@@ -75,23 +149,34 @@ func (s *ThreadsafePoolRNG) Seed(seed int64) {
 // Bytes allocates a []byte filled with random bytes and returns it. This is convenient
 // but caller does the allocation pattern is better way since it can reduce allocation count/GC
 func (s *ThreadsafePoolRNG) Bytes(n int) []byte {
-	r := s.rngPool.Get().(UnsafeRNG)
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
 	bytes := make([]byte, n)
 	result := Bytes(r, bytes)
-	s.rngPool.Put(r)
+	rngPool.Put(r)
 	return result
 }
 
 // Read fills a []byte array with random bytes from a thread safe pool backed RNG
 func (s *ThreadsafePoolRNG) Read(p []byte) []byte {
-	r := s.rngPool.Get().(UnsafeRNG)
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
 	Bytes(r, p)
-	s.rngPool.Put(r)
+	rngPool.Put(r)
 	return p
 }
 
+// bulkRNG is a generator with its own faster fill, Bytes uses it
+type bulkRNG interface {
+	fillBytes(bytes []byte)
+}
+
 // Bytes fills a []byte array with random bytes from a thread unsafe RNG
 func Bytes(r UnsafeRNG, bytes []byte) []byte {
+	if b, ok := r.(bulkRNG); ok {
+		b.fillBytes(bytes)
+		return bytes
+	}
 	n := len(bytes)
 
 	/*
@@ -144,15 +229,56 @@ func Bytes(r UnsafeRNG, bytes []byte) []byte {
 	return bytes
 }
 
+// Uint32 returns pseudorandom uint32, natively from an UnsafeRNG32. Threadsafe
+func (s *ThreadsafePoolRNG) Uint32() uint32 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := uint32From(r)
+	rngPool.Put(r)
+	return x
+}
+
+// uint32From returns a pseudorandom uint32 from a thread unsafe RNG, without the second half of a
+// Uint64 when it is an UnsafeRNG32
+func uint32From(r UnsafeRNG) uint32 {
+	if r32, ok := r.(UnsafeRNG32); ok {
+		return r32.Uint32()
+	}
+	return uint32(r.Uint64())
+}
+
 // Uint32n returns pseudorandom Uint32n in the range [0..maxN).
 //
 // It is safe calling this function from concurrent goroutines.
 func (s *ThreadsafePoolRNG) Uint32n(maxN int) uint32 {
-	x := s.Uint64() & 0x00000000FFFFFFFF
+	x := uint64(s.Uint32())
 	// See http://lemire.me/blog/2016/06/27/a-fast-alternative-to-the-modulo-reduction/
 	return uint32((x * uint64(maxN)) >> 32)
 }
 
+// uint64n returns an unbiased pseudorandom uint64 in the range [0..n) from a thread unsafe RNG, n must be > 0
+// See https://lemire.me/blog/2016/06/30/fast-random-shuffling/
+func uint64n(r UnsafeRNG, n uint64) uint64 {
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// intn returns an unbiased pseudorandom int in the range [0..n) from a thread unsafe RNG, n must be > 0
+func intn(r UnsafeRNG, n int) int {
+	return int(uint64n(r, uint64(n)))
+}
+
+// unitFloat64 returns a pseudorandom float64 in the range [0..1) built from the top 53 bits of a Uint64
+func unitFloat64(r UnsafeRNG) float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
 // UnsafeXoshiro256ssRNG It is unsafe to call UnsafeRNG methods from concurrent goroutines.
 //
 // UnsafeXoshiro256** is a pseudorandom number generator.
@@ -217,13 +343,898 @@ func (r *UnsafeXoshiro256ssRNG) Seed(seed int64) {
 
 // NewUnsafeXoshiro256ssRNG creates a new Thread unsafe PRNG generator
 func NewUnsafeXoshiro256ssRNG(seed int64) *UnsafeXoshiro256ssRNG {
+	noteSeed(seed)
 	r := &UnsafeXoshiro256ssRNG{}
 	r.Seed(seed)
 	return r
 }
 
+// newXoshiro256ssFromState sets all 256 bits of state directly, falling back to Seed(0) for the
+// all zero state which is a fixed point of the generator
+func newXoshiro256ssFromState(state []uint64) *UnsafeXoshiro256ssRNG {
+	r := &UnsafeXoshiro256ssRNG{s0: state[0], s1: state[1], s2: state[2], s3: state[3]}
+	if r.s0|r.s1|r.s2|r.s3 == 0 {
+		r.Seed(0)
+	}
+	return r
+}
+
+// UnsafeXoshiro256ppRNG is the xoshiro256++ generator, recommended by Vigna as an all purpose
+// alternative to xoshiro256** with slightly different failure modes. It shares the xoshiro256 state
+// transition and only differs in the output scrambler.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://prng.di.unimi.it/
+type UnsafeXoshiro256ppRNG struct {
+	s0 uint64
+	s1 uint64
+	s2 uint64
+	s3 uint64
+}
+
+// Uint64 generates a random Uin64, (not thread safe)
+func (r *UnsafeXoshiro256ppRNG) Uint64() uint64 {
+	result := rol64(r.s0+r.s3, 23) + r.s0
+	t := r.s1 << 17
+
+	r.s2 ^= r.s0
+	r.s3 ^= r.s1
+	r.s1 ^= r.s2
+	r.s0 ^= r.s3
+
+	r.s2 ^= t
+	r.s3 = rol64(r.s3, 45)
+
+	return result
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed the 256 bit starting state for the RNG
+func (r *UnsafeXoshiro256ppRNG) Seed(seed int64) {
+	var ss UnsafeXoshiro256ssRNG
+	ss.Seed(seed)
+	r.s0, r.s1, r.s2, r.s3 = ss.s0, ss.s1, ss.s2, ss.s3
+}
+
+// NewUnsafeXoshiro256ppRNG creates a new Thread unsafe xoshiro256++ generator
+func NewUnsafeXoshiro256ppRNG(seed int64) *UnsafeXoshiro256ppRNG {
+	noteSeed(seed)
+	r := &UnsafeXoshiro256ppRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeXoroshiro128ppRNG is the xoroshiro128++ generator, with half the state of xoshiro256 for
+// users who pool thousands of generators. It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://prng.di.unimi.it/
+type UnsafeXoroshiro128ppRNG struct {
+	s0 uint64
+	s1 uint64
+}
+
+// Uint64 generates a random Uin64, (not thread safe)
+func (r *UnsafeXoroshiro128ppRNG) Uint64() uint64 {
+	s0, s1 := r.s0, r.s1
+	result := rol64(s0+s1, 17) + s0
+
+	s1 ^= s0
+	r.s0 = rol64(s0, 49) ^ s1 ^ (s1 << 21)
+	r.s1 = rol64(s1, 28)
+
+	return result
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed the 128 bit starting state for the RNG
+func (r *UnsafeXoroshiro128ppRNG) Seed(seed int64) {
+	i := 0
+	for r.s0 = 0; r.s0 == 0; i++ {
+		r.s0 = Splitmix64(uint64(seed) + uint64(i))
+	}
+	for r.s1 = 0; r.s1 == 0; i++ {
+		r.s1 = Splitmix64(uint64(seed) + uint64(i))
+	}
+}
+
+// NewUnsafeXoroshiro128ppRNG creates a new Thread unsafe xoroshiro128++ generator
+func NewUnsafeXoroshiro128ppRNG(seed int64) *UnsafeXoroshiro128ppRNG {
+	noteSeed(seed)
+	r := &UnsafeXoroshiro128ppRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeXoroshiro64ssRNG is the xoroshiro64** generator, 32 bit native with 64 bits of state and
+// no 64 bit multiply, for 386, ARM32 and TinyGo targets where the 64 bit generators are slow. It is
+// an UnsafeRNG32. It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://prng.di.unimi.it/
+type UnsafeXoroshiro64ssRNG struct {
+	s0 uint32
+	s1 uint32
+}
+
+// Uint32 generates a random uint32, (not thread safe)
+func (r *UnsafeXoroshiro64ssRNG) Uint32() uint32 {
+	s0, s1 := r.s0, r.s1
+	result := bits.RotateLeft32(s0*0x9E3779BB, 5) * 5
+
+	s1 ^= s0
+	r.s0 = bits.RotateLeft32(s0, 26) ^ s1 ^ (s1 << 9)
+	r.s1 = bits.RotateLeft32(s1, 13)
+
+	return result
+}
+
+// Uint64 generates a random uint64 from two successive Uint32s, high word first, (not thread safe)
+func (r *UnsafeXoroshiro64ssRNG) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to seed the 64 bit starting state for the RNG
+func (r *UnsafeXoroshiro64ssRNG) Seed(seed int64) {
+	x := uint64(0)
+	for i := 0; x == 0; i++ {
+		x = Splitmix64(uint64(seed) + uint64(i))
+	}
+	r.s0, r.s1 = uint32(x), uint32(x>>32)
+}
+
+// NewUnsafeXoroshiro64ssRNG creates a new Thread unsafe xoroshiro64** generator
+func NewUnsafeXoroshiro64ssRNG(seed int64) *UnsafeXoroshiro64ssRNG {
+	noteSeed(seed)
+	r := &UnsafeXoroshiro64ssRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeXoshiro512ssRNG is the xoshiro512** generator, with a 2^512-1 period and a Jump function
+// for Monte Carlo users who need very large numbers of non overlapping parallel streams.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://prng.di.unimi.it/
+type UnsafeXoshiro512ssRNG struct {
+	s [8]uint64
+}
+
+// Uint64 generates a random Uin64, (not thread safe)
+func (r *UnsafeXoshiro512ssRNG) Uint64() uint64 {
+	s := &r.s
+	result := rol64(s[1]*5, 7) * 9
+	t := s[1] << 11
+
+	s[2] ^= s[0]
+	s[5] ^= s[1]
+	s[1] ^= s[2]
+	s[7] ^= s[3]
+	s[3] ^= s[4]
+	s[4] ^= s[5]
+	s[0] ^= s[6]
+	s[6] ^= s[7]
+
+	s[6] ^= t
+	s[7] = rol64(s[7], 21)
+
+	return result
+}
+
+// xoshiro512Jump is the jump polynomial for 2^256 steps
+var xoshiro512Jump = [8]uint64{
+	0x33ed89b6e7a353f9, 0x760083d7955323be, 0x2837f2fbb5f22fae, 0x4b8c5674d309511c,
+	0xb11ac47a7ba28c25, 0xf1be7667092bcc1c, 0x53851efdb6df0aaf, 0x1ebbc8b23eaf25db,
+}
+
+// xoshiro512LongJump is the jump polynomial for 2^384 steps
+var xoshiro512LongJump = [8]uint64{
+	0x11467fef8f921d28, 0xa2a819f2e79c8ea8, 0xa8299fc284b3959a, 0xb4d347340ca63ee1,
+	0x1cb0940bedbff6ce, 0xd956c5c4fa1f8e17, 0x915e38fd4eda93bc, 0x5b3ccdfa5d7daca5,
+}
+
+// Jump advances the generator by 2^256 steps, so 2^256 calls of Jump give non overlapping streams
+func (r *UnsafeXoshiro512ssRNG) Jump() {
+	r.jump(&xoshiro512Jump)
+}
+
+// LongJump advances the generator by 2^384 steps, so it can hand out 2^128 starting points each
+// with room for 2^128 Jumps, eg a LongJump per machine and a Jump per thread
+func (r *UnsafeXoshiro512ssRNG) LongJump() {
+	r.jump(&xoshiro512LongJump)
+}
+
+func (r *UnsafeXoshiro512ssRNG) jump(poly *[8]uint64) {
+	var t [8]uint64
+	for _, jump := range poly {
+		for b := uint(0); b < 64; b++ {
+			if jump&(1<<b) != 0 {
+				for i := range t {
+					t[i] ^= r.s[i]
+				}
+			}
+			r.Uint64()
+		}
+	}
+	r.s = t
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed the 512 bit starting state for the RNG
+func (r *UnsafeXoshiro512ssRNG) Seed(seed int64) {
+	i := 0
+	for j := range r.s {
+		for r.s[j] = 0; r.s[j] == 0; i++ {
+			r.s[j] = Splitmix64(uint64(seed) + uint64(i))
+		}
+	}
+}
+
+// NewUnsafeXoshiro512ssRNG creates a new Thread unsafe xoshiro512** generator
+func NewUnsafeXoshiro512ssRNG(seed int64) *UnsafeXoshiro512ssRNG {
+	noteSeed(seed)
+	r := &UnsafeXoshiro512ssRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeSplitmix64RNG is the stateful splitmix64 generator, a counter stepped by the golden gamma and
+// mixed by Splitmix64. Being counter based it is trivially jumpable and index addressable, so it is
+// useful as a cheap seeder and as a stateless source. It is unsafe to call UnsafeRNG methods
+// from concurrent goroutines.
+type UnsafeSplitmix64RNG struct {
+	state uint64
+}
+
+// splitmix64Gamma is the splitmix64 counter increment, 2^64 divided by the golden ratio
+const splitmix64Gamma = 0x9E3779B97F4A7C15
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeSplitmix64RNG) Uint64() uint64 {
+	x := Splitmix64(r.state)
+	r.state += splitmix64Gamma
+	return x
+}
+
+// Seed sets the counter directly, so the first Uint64 is Splitmix64(uint64(seed))
+func (r *UnsafeSplitmix64RNG) Seed(seed int64) {
+	r.state = uint64(seed)
+}
+
+// At returns the i-th value the generator will produce, without changing its state
+func (r *UnsafeSplitmix64RNG) At(i uint64) uint64 {
+	return Splitmix64(r.state + i*splitmix64Gamma)
+}
+
+// Jump skips the next n values in constant time
+func (r *UnsafeSplitmix64RNG) Jump(n uint64) {
+	r.state += n * splitmix64Gamma
+}
+
+// NewUnsafeSplitmix64RNG creates a new Thread unsafe splitmix64 generator
+func NewUnsafeSplitmix64RNG(seed int64) *UnsafeSplitmix64RNG {
+	noteSeed(seed)
+	r := &UnsafeSplitmix64RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeSquaresRNG is Widynski's Squares counter based generator, each output is a keyed hash of
+// a 64 bit counter built from 5 rounds of squaring, so any position of the stream can be generated
+// directly with At and parallel workers given disjoint counter ranges reproduce exactly. It is unsafe
+// to call UnsafeRNG methods from concurrent goroutines.
+// See https://arxiv.org/abs/2004.06278
+type UnsafeSquaresRNG struct {
+	ctr uint64
+	key uint64
+}
+
+// squares64 is the 64 bit output Squares function
+func squares64(ctr uint64, key uint64) uint64 {
+	x := ctr * key
+	y := x
+	z := y + key
+	x = x*x + y
+	x = x>>32 | x<<32
+	x = x*x + z
+	x = x>>32 | x<<32
+	x = x*x + y
+	x = x>>32 | x<<32
+	x = x*x + z
+	t := x
+	x = x>>32 | x<<32
+	return t ^ (x*x+y)>>32
+}
+
+// squaresKey derives a key from x following the rules of Widynski's key generator, the upper and
+// the lower 8 hex digits are each distinct and non zero, the lower half doesn't start with the digit
+// the upper half ends with, and the key is odd. Keys with repeated or zero digits make poor streams
+func squaresKey(x uint64) uint64 {
+	r := NewUnsafeSplitmix64RNG(int64(x))
+	var key, prev uint64
+	for half := 0; half < 2; half++ {
+		var used uint16
+		for i := 0; i < 8; i++ {
+			var d uint64
+			for {
+				d = 1 + uint64n(r, 15)
+				if used&(1<<d) != 0 || d == prev || (half == 1 && i == 7 && d&1 == 0) {
+					continue
+				}
+				break
+			}
+			used |= 1 << d
+			key = key<<4 | d
+			prev = d
+		}
+	}
+	return key
+}
+
+// SetState sets the key and the counter of the next Uint64, the key is used as is so it should come
+// from Widynski's key generator or Seed
+func (r *UnsafeSquaresRNG) SetState(key uint64, ctr uint64) {
+	r.key, r.ctr = key, ctr
+}
+
+// Seed derives a key from the seed and starts the counter at 0
+func (r *UnsafeSquaresRNG) Seed(seed int64) {
+	r.SetState(squaresKey(uint64(seed)), 0)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeSquaresRNG) Uint64() uint64 {
+	x := squares64(r.ctr, r.key)
+	r.ctr++
+	return x
+}
+
+// At returns the value for counter, which is the value Uint64 returns after SetState(key, counter).
+// It doesn't change the generator's state
+func (r *UnsafeSquaresRNG) At(counter uint64) uint64 {
+	return squares64(counter, r.key)
+}
+
+// NewUnsafeSquaresRNG creates a new Thread unsafe Squares generator
+func NewUnsafeSquaresRNG(seed int64) *UnsafeSquaresRNG {
+	noteSeed(seed)
+	r := &UnsafeSquaresRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeRomuDuoJrRNG is RomuDuoJr, the fastest of Mark Overton's Romu family of nonlinear
+// multiply-rotate generators, 128 bits of state. Romu has no guaranteed period, but the odds of a
+// short cycle are negligible for reasonable stream lengths. It is unsafe to call UnsafeRNG methods
+// from concurrent goroutines.
+// See https://www.romu-random.org/
+type UnsafeRomuDuoJrRNG struct {
+	x, y uint64
+}
+
+// romuMul is the multiplier shared by the Romu generators
+const romuMul = 15241094284759029579
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeRomuDuoJrRNG) Uint64() uint64 {
+	xp := r.x
+	r.x = romuMul * r.y
+	r.y = rol64(r.y-xp, 27)
+	return xp
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed a non zero state
+func (r *UnsafeRomuDuoJrRNG) Seed(seed int64) {
+	var ss UnsafeXoshiro256ssRNG
+	ss.Seed(seed)
+	r.x, r.y = ss.s0, ss.s1
+}
+
+// NewUnsafeRomuDuoJrRNG creates a new Thread unsafe RomuDuoJr generator
+func NewUnsafeRomuDuoJrRNG(seed int64) *UnsafeRomuDuoJrRNG {
+	noteSeed(seed)
+	r := &UnsafeRomuDuoJrRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeRomuTrioRNG is RomuTrio, the 192 bit state member of the Romu family, recommended over
+// RomuDuoJr for larger jobs. It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://www.romu-random.org/
+type UnsafeRomuTrioRNG struct {
+	x, y, z uint64
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeRomuTrioRNG) Uint64() uint64 {
+	xp, yp, zp := r.x, r.y, r.z
+	r.x = romuMul * zp
+	r.y = rol64(yp-xp, 12)
+	r.z = rol64(zp-yp, 44)
+	return xp
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed a non zero state
+func (r *UnsafeRomuTrioRNG) Seed(seed int64) {
+	var ss UnsafeXoshiro256ssRNG
+	ss.Seed(seed)
+	r.x, r.y, r.z = ss.s0, ss.s1, ss.s2
+}
+
+// NewUnsafeRomuTrioRNG creates a new Thread unsafe RomuTrio generator
+func NewUnsafeRomuTrioRNG(seed int64) *UnsafeRomuTrioRNG {
+	noteSeed(seed)
+	r := &UnsafeRomuTrioRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafePcg32RNG is the PCG32 (pcg32_srandom_r, XSH-RR output) generator, 64 bits of state and a
+// selectable stream. It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://www.pcg-random.org/
+type UnsafePcg32RNG struct {
+	state uint64
+	inc   uint64
+}
+
+// SetState seeds the generator exactly like the reference pcg32_srandom_r(initstate, initseq),
+// generators with different initseq produce different streams
+func (r *UnsafePcg32RNG) SetState(initstate uint64, initseq uint64) {
+	r.state = 0
+	r.inc = (initseq << 1) | 1
+	r.Uint32()
+	r.state += initstate
+	r.Uint32()
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick both the state and the stream
+func (r *UnsafePcg32RNG) Seed(seed int64) {
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(0)),
+		Splitmix64(uint64(seed)+uint64(1)),
+	)
+}
+
+// Uint32 generates a random uint32, (not thread safe)
+func (r *UnsafePcg32RNG) Uint32() uint32 {
+	oldstate := r.state
+	r.state = oldstate*6364136223846793005 + r.inc
+	// the xorshift result must be truncated to 32 bits before the rotate, as in the reference
+	xorshifted := uint32(((oldstate >> 18) ^ oldstate) >> 27)
+	rot := uint32(oldstate >> 59)
+	return (xorshifted >> rot) | (xorshifted << ((-rot) & 31))
+}
+
+// Uint64 generates a random uint64 from two successive Uint32s, high word first, (not thread safe)
+func (r *UnsafePcg32RNG) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// NewUnsafePcg32RNG creates a new Thread unsafe PCG32 generator
+func NewUnsafePcg32RNG(seed int64) *UnsafePcg32RNG {
+	noteSeed(seed)
+	r := &UnsafePcg32RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafePcg64RNG is the PCG64 (pcg_setseq_128, XSL-RR output) generator, 128 bits of state and a
+// selectable stream, statistically stronger than xoshiro. It is unsafe to call UnsafeRNG methods
+// from concurrent goroutines.
+// See https://www.pcg-random.org/
+type UnsafePcg64RNG struct {
+	hi, lo       uint64 // state
+	incHi, incLo uint64
+}
+
+// pcg128MulHi and pcg128MulLo are the halves of PCG_DEFAULT_MULTIPLIER_128
+const (
+	pcg128MulHi = 0x2360ED051FC65DA4
+	pcg128MulLo = 0x4385DF649FCCF645
+)
+
+// step advances the 128 bit LCG, state = state*mul + inc
+func (r *UnsafePcg64RNG) step() {
+	hi, lo := bits.Mul64(r.lo, pcg128MulLo)
+	hi += r.hi*pcg128MulLo + r.lo*pcg128MulHi
+	var carry uint64
+	r.lo, carry = bits.Add64(lo, r.incLo, 0)
+	r.hi, _ = bits.Add64(hi, r.incHi, carry)
+}
+
+// SetState seeds the generator exactly like the reference pcg64_srandom_r(initstate, initseq) with
+// 128 bit arguments whose high halves are 0, generators with different initseq produce different streams
+func (r *UnsafePcg64RNG) SetState(initstate uint64, initseq uint64) {
+	r.hi, r.lo = 0, 0
+	r.incHi, r.incLo = initseq>>63, initseq<<1|1
+	r.step()
+	var carry uint64
+	r.lo, carry = bits.Add64(r.lo, initstate, 0)
+	r.hi += carry
+	r.step()
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick both the state and the stream
+func (r *UnsafePcg64RNG) Seed(seed int64) {
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(0)),
+		Splitmix64(uint64(seed)+uint64(1)),
+	)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafePcg64RNG) Uint64() uint64 {
+	r.step()
+	return bits.RotateLeft64(r.hi^r.lo, -int(r.hi>>58))
+}
+
+// NewUnsafePcg64RNG creates a new Thread unsafe PCG64 generator
+func NewUnsafePcg64RNG(seed int64) *UnsafePcg64RNG {
+	noteSeed(seed)
+	r := &UnsafePcg64RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafePcg64DxsmRNG is PCG64-DXSM, NumPy's default bit generator: a 128 bit LCG with a cheap
+// 64 bit multiplier and the DXSM output function applied to the state before each step. Setting the
+// same state as a NumPy PCG64DXSM gives the same stream. It is unsafe to call UnsafeRNG methods
+// from concurrent goroutines.
+type UnsafePcg64DxsmRNG struct {
+	hi, lo       uint64 // state
+	incHi, incLo uint64
+}
+
+// pcgCheapMul is PCG_CHEAP_MULTIPLIER_128
+const pcgCheapMul = 0xda942042e4dd58b5
+
+// step advances the 128 bit LCG, state = state*pcgCheapMul + inc
+func (r *UnsafePcg64DxsmRNG) step() {
+	hi, lo := bits.Mul64(r.lo, pcgCheapMul)
+	hi += r.hi * pcgCheapMul
+	var carry uint64
+	r.lo, carry = bits.Add64(lo, r.incLo, 0)
+	r.hi, _ = bits.Add64(hi, r.incHi, carry)
+}
+
+// SetState seeds the generator like NumPy's pcg_cm_srandom_r(seed, stream) with 128 bit arguments
+// whose high halves are 0, generators with different stream produce different streams
+func (r *UnsafePcg64DxsmRNG) SetState(seed uint64, stream uint64) {
+	r.hi, r.lo = 0, 0
+	r.incHi, r.incLo = stream>>63, stream<<1|1
+	r.step()
+	var carry uint64
+	r.lo, carry = bits.Add64(r.lo, seed, 0)
+	r.hi += carry
+	r.step()
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick both the state and the stream
+func (r *UnsafePcg64DxsmRNG) Seed(seed int64) {
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(0)),
+		Splitmix64(uint64(seed)+uint64(1)),
+	)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafePcg64DxsmRNG) Uint64() uint64 {
+	hi, lo := r.hi, r.lo|1
+	hi ^= hi >> 32
+	hi *= pcgCheapMul
+	hi ^= hi >> 48
+	hi *= lo
+	r.step()
+	return hi
+}
+
+// NewUnsafePcg64DxsmRNG creates a new Thread unsafe PCG64-DXSM generator
+func NewUnsafePcg64DxsmRNG(seed int64) *UnsafePcg64DxsmRNG {
+	noteSeed(seed)
+	r := &UnsafePcg64DxsmRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeLehmer128RNG is the 128 bit Lehmer multiplicative congruential generator popularised by
+// Lemire, state *= multiplier and return the high 64 bits, which is a single bits.Mul64 plus a multiply
+// add on amd64. The state must be odd, so seeding always sets the low bit, giving a period of 2^126.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+type UnsafeLehmer128RNG struct {
+	hi, lo uint64 // state
+}
+
+// lehmer128Mul is the multiplier from Lemire's lehmer64, the same constant as pcgCheapMul
+const lehmer128Mul = 0xda942042e4dd58b5
+
+// SetState sets the 128 bit state, forcing it odd since an even state has a shorter period and a
+// zero state would only ever produce zeros
+func (r *UnsafeLehmer128RNG) SetState(hi uint64, lo uint64) {
+	r.hi, r.lo = hi, lo|1
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to seed both halves of the state
+func (r *UnsafeLehmer128RNG) Seed(seed int64) {
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(0)),
+		Splitmix64(uint64(seed)+uint64(1)),
+	)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeLehmer128RNG) Uint64() uint64 {
+	hi, lo := bits.Mul64(r.lo, lehmer128Mul)
+	r.hi, r.lo = hi+r.hi*lehmer128Mul, lo
+	return r.hi
+}
+
+// NewUnsafeLehmer128RNG creates a new Thread unsafe Lehmer128 generator
+func NewUnsafeLehmer128RNG(seed int64) *UnsafeLehmer128RNG {
+	noteSeed(seed)
+	r := &UnsafeLehmer128RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafePhilox4x64RNG is the Philox4x64-10 counter based generator from Random123, the same as
+// NumPy's Philox bit generator. Each 256 bit counter value is encrypted under a 128 bit key into 4
+// outputs, so distinct keys give independent streams. It is unsafe to call UnsafeRNG methods from
+// concurrent goroutines.
+// See https://www.thesalmons.org/john/random123/
+type UnsafePhilox4x64RNG struct {
+	ctr       [4]uint64
+	key       [2]uint64
+	buffer    [4]uint64
+	bufferPos int
+}
+
+// the Philox4x64 round multipliers and Weyl key increments
+const (
+	philoxM0 = 0xD2E7470EE14C6C93
+	philoxM1 = 0xCA5A826395121157
+	philoxW0 = 0x9E3779B97F4A7C15
+	philoxW1 = 0xBB67AE8584CAA73B
+)
+
+// philox4x64 encrypts ctr under key with 10 rounds
+func philox4x64(ctr [4]uint64, key [2]uint64) [4]uint64 {
+	for i := 0; i < 10; i++ {
+		if i > 0 {
+			key[0] += philoxW0
+			key[1] += philoxW1
+		}
+		hi0, lo0 := bits.Mul64(philoxM0, ctr[0])
+		hi1, lo1 := bits.Mul64(philoxM1, ctr[2])
+		ctr = [4]uint64{hi1 ^ ctr[1] ^ key[0], lo1, hi0 ^ ctr[3] ^ key[1], lo0}
+	}
+	return ctr
+}
+
+// SetState sets the counter and key, the next Uint64 increments the counter and encrypts it
+func (r *UnsafePhilox4x64RNG) SetState(ctr [4]uint64, key [2]uint64) {
+	r.ctr, r.key = ctr, key
+	r.bufferPos = len(r.buffer)
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick the key, the counter starts at 0
+func (r *UnsafePhilox4x64RNG) Seed(seed int64) {
+	r.SetState([4]uint64{}, [2]uint64{
+		Splitmix64(uint64(seed) + uint64(0)),
+		Splitmix64(uint64(seed) + uint64(1)),
+	})
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafePhilox4x64RNG) Uint64() uint64 {
+	if r.bufferPos < len(r.buffer) {
+		x := r.buffer[r.bufferPos]
+		r.bufferPos++
+		return x
+	}
+	// increment the 256 bit counter, carrying into the next word on wrap around
+	for i := range r.ctr {
+		r.ctr[i]++
+		if r.ctr[i] != 0 {
+			break
+		}
+	}
+	r.buffer = philox4x64(r.ctr, r.key)
+	r.bufferPos = 1
+	return r.buffer[0]
+}
+
+// NewUnsafePhilox4x64RNG creates a new Thread unsafe Philox4x64-10 generator
+func NewUnsafePhilox4x64RNG(seed int64) *UnsafePhilox4x64RNG {
+	noteSeed(seed)
+	r := &UnsafePhilox4x64RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafePcg32x2RNG combines two PCG32 generators on distinct streams into a 64 bit generator,
+// the high word comes from the first and the low word from the second.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+type UnsafePcg32x2RNG struct {
+	gen0 UnsafePcg32RNG
+	gen1 UnsafePcg32RNG
+}
+
+// SetState seeds both halves like pcg32_srandom_r. PCG drops the top bit of the stream selector, so
+// if seq1 and seq2 would select the same stream, seq2 is inverted to keep the halves distinct
+func (r *UnsafePcg32x2RNG) SetState(seed1 uint64, seq1 uint64, seed2 uint64, seq2 uint64) {
+	mask := ^uint64(0) >> 1
+	// The stream for each of the two generators *must* be distinct
+	if (seq1 & mask) == (seq2 & mask) {
+		seq2 = ^seq2
+	}
+	r.gen0.SetState(seed1, seq1)
+	r.gen1.SetState(seed2, seq2)
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick both states and both streams
+func (r *UnsafePcg32x2RNG) Seed(seed int64) {
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(0)),
+		Splitmix64(uint64(seed)+uint64(1)),
+		Splitmix64(uint64(seed)+uint64(2)),
+		Splitmix64(uint64(seed)+uint64(3)),
+	)
+}
+
+// Uint64 generates a random uint64, one Uint32 from each half, (not thread safe)
+func (r *UnsafePcg32x2RNG) Uint64() uint64 {
+	return (uint64(r.gen0.Uint32()) << 32) | uint64(r.gen1.Uint32())
+}
+
+// NewUnsafePcg32x2RNG creates a new Thread unsafe PCG32x2 generator
+func NewUnsafePcg32x2RNG(seed int64) *UnsafePcg32x2RNG {
+	noteSeed(seed)
+	r := &UnsafePcg32x2RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeJsf64RNG is Bob Jenkins' small fast 64 bit generator (jsf64), 256 bits of state.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See http://burtleburtle.net/bob/rand/smallprng.html
+type UnsafeJsf64RNG struct {
+	a uint64
+	b uint64
+	c uint64
+	d uint64
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (x *UnsafeJsf64RNG) Uint64() uint64 {
+	e := x.a - rol64(x.b, 7)
+	x.a = x.b ^ rol64(x.c, 13)
+	x.b = x.c + rol64(x.d, 37)
+	x.c = x.d + e
+	x.d = e + x.a
+	return x.d
+}
+
+// Seed matches the reference raninit, the seed is used directly and mixed by 20 warm up rounds
+func (x *UnsafeJsf64RNG) Seed(seed int64) {
+	x.a = 0xf1ea5eed
+	x.b = uint64(seed)
+	x.c = uint64(seed)
+	x.d = uint64(seed)
+	for i := 0; i < 20; i++ {
+		x.Uint64()
+	}
+}
+
+// NewUnsafeJsf64RNG creates a new Thread unsafe JSF64 generator
+func NewUnsafeJsf64RNG(seed int64) *UnsafeJsf64RNG {
+	noteSeed(seed)
+	r := &UnsafeJsf64RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeKiss64RNG is George Marsaglia's 2009 KISS64, the sum of a multiply with carry, a
+// xorshift and a congruential generator, a structurally different workhorse for checking results
+// don't depend on the generator. It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://www.thecodingforums.com/threads/64-bit-kiss-rngs.673657/
+type UnsafeKiss64RNG struct {
+	x, c uint64 // multiply with carry
+	y    uint64 // xorshift, never 0
+	z    uint64 // congruential
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeKiss64RNG) Uint64() uint64 {
+	t := r.x<<58 + r.c
+	var carry uint64
+	r.c = r.x >> 6
+	r.x, carry = bits.Add64(r.x, t, 0)
+	r.c += carry
+
+	r.y ^= r.y << 13
+	r.y ^= r.y >> 17
+	r.y ^= r.y << 43
+
+	r.z = 6906969069*r.z + 1234567
+
+	return r.x + r.y + r.z
+}
+
+// SetState sets the four words of the reference state, Marsaglia's example uses
+// x=1234567890987654321, c=123456123456123456, y=362436362436362436, z=1066149217761810.
+// The carry c must be < 2^58 and y must not be 0
+func (r *UnsafeKiss64RNG) SetState(x uint64, c uint64, y uint64, z uint64) {
+	r.x, r.c, r.y, r.z = x, c, y, z
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick a valid state
+func (r *UnsafeKiss64RNG) Seed(seed int64) {
+	i := 0
+	var y uint64
+	for ; y == 0; i++ {
+		y = Splitmix64(uint64(seed) + uint64(i))
+	}
+	r.SetState(
+		Splitmix64(uint64(seed)+uint64(i)),
+		Splitmix64(uint64(seed)+uint64(i+1))>>6,
+		y,
+		Splitmix64(uint64(seed)+uint64(i+2)),
+	)
+}
+
+// NewUnsafeKiss64RNG creates a new Thread unsafe KISS64 generator
+func NewUnsafeKiss64RNG(seed int64) *UnsafeKiss64RNG {
+	noteSeed(seed)
+	r := &UnsafeKiss64RNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeGjrandRNG is David Blackman's gjrand "small fast" 64 bit generator, 256 bits of chaotic
+// state with a counter that guarantees a period of at least 2^64, it passes BigCrush and PractRand.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See http://gjrand.sourceforge.net/
+type UnsafeGjrandRNG struct {
+	a uint64
+	b uint64
+	c uint64
+	d uint64 // the counter
+}
+
+// gjrandIncrement steps the counter d
+const gjrandIncrement = 0x55aa96a5
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeGjrandRNG) Uint64() uint64 {
+	r.b += r.c
+	r.a = rol64(r.a, 32)
+	r.c ^= r.b
+	r.d += gjrandIncrement
+	r.a += r.b
+	r.c = rol64(r.c, 23)
+	r.b ^= r.a
+	r.a += r.c
+	r.b = rol64(r.b, 19)
+	r.c += r.a
+	r.b += r.d
+	return r.a
+}
+
+// Seed matches the reference gjrand_init64, the seed is used directly and mixed by 14 warm up rounds
+func (r *UnsafeGjrandRNG) Seed(seed int64) {
+	r.a = uint64(seed)
+	r.b = 0
+	r.c = 2000001
+	r.d = 0
+	for i := 0; i < 14; i++ {
+		r.Uint64()
+	}
+}
+
+// NewUnsafeGjrandRNG creates a new Thread unsafe gjrand generator
+func NewUnsafeGjrandRNG(seed int64) *UnsafeGjrandRNG {
+	noteSeed(seed)
+	r := &UnsafeGjrandRNG{}
+	r.Seed(seed)
+	return r
+}
+
 // NewUnsafeRandRNG creates a new Thread unsafe PRNG generator using the native golang 64bit RNG generator
 // (thus avoiding using any global state)
 func NewUnsafeRandRNG(seed int64) *rand.Rand {
+	noteSeed(seed)
 	return rand.New(rand.NewSource(seed).(rand.Source64))
 }
@@ -3,6 +3,7 @@ package fastrand64
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -42,6 +43,32 @@ func Test_SafeRNG_Seed(t *testing.T) {
 	assert.Panics(t, func() { rng.Seed(0) })
 }
 
+func Test_SafeRNG_SwapSource(t *testing.T) {
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	rng.Uint64()
+
+	// a generator checked out during the swap is dropped when it is returned
+	rngPool := rng.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	rng.SwapSource(func() UnsafeRNG { return NewUnsafeSplitmix64RNG(7) })
+	rngPool.Put(r)
+	assert.Equal(t, NewUnsafeSplitmix64RNG(7).Uint64(), rng.Uint64())
+
+	// swapping while other goroutines draw is safe
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			rng.Uint64()
+		}
+		done <- true
+	}()
+	for i := 0; i < 10; i++ {
+		seed := int64(i)
+		rng.SwapSource(func() UnsafeRNG { return NewUnsafeXoshiro256ppRNG(seed) })
+	}
+	<-done
+}
+
 func Test_SafeRNG_Int63(t *testing.T) {
 	rng1 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
 	rng2 := NewUnsafeRandRNG(1)
@@ -96,6 +123,418 @@ func Test_UnsafeXoshiro256ssRNG_UInt64(t *testing.T) {
 	assert.Equal(t, r, endian.HostToNetUint64(uint64(0xebd96366a670fd50)))
 }
 
+func Test_UnsafeXoshiro256ppRNG_Uint64(t *testing.T) {
+	// reference xoshiro256plusplus.c output for the state {1, 2, 3, 4}
+	rng := &UnsafeXoshiro256ppRNG{s0: 1, s1: 2, s2: 3, s3: 4}
+	expected := []uint64{41943041, 58720359, 3588806011781223, 3591011842654386, 0x8012a2019ac433cd, 0x8a69978acdee33ba}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	// seeding matches xoshiro256**, so both see the same state and differ only by the scrambler
+	pp := NewUnsafeXoshiro256ppRNG(7)
+	ss := NewUnsafeXoshiro256ssRNG(7)
+	assert.Equal(t, rol64(ss.s0+ss.s3, 23)+ss.s0, pp.Uint64())
+}
+
+func Test_SafeRNG_Xoshiro256pp(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ppRNG()
+	for i := 0; i < 4096; i++ {
+		assert.Less(t, rng.Uint32n(10), uint32(10))
+	}
+}
+
+func Test_UnsafeXoroshiro128ppRNG_Uint64(t *testing.T) {
+	// reference xoroshiro128plusplus.c output for the state {1, 2}
+	rng := &UnsafeXoroshiro128ppRNG{s0: 1, s1: 2}
+	expected := []uint64{393217, 669327710093319, 0x180acc04718606d3, 0x9e226d35036fc4c7, 0x849bc9ac6b960be4, 0x31c5870fc130361b}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	rng1 := NewUnsafeXoroshiro128ppRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoroshiro128ppRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeXoroshiro64ssRNG_Uint32(t *testing.T) {
+	// reference xoroshiro64starstar.c output for the state {1, 2}
+	rng := &UnsafeXoroshiro64ssRNG{s0: 1, s1: 2}
+	expected := []uint32{0xe2ac153f, 0x30817eaa, 0x607a3436, 0xb030543b, 0xc1e30385, 0x435a2fa5}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint32())
+	}
+	rng = &UnsafeXoroshiro64ssRNG{s0: 1, s1: 2}
+	assert.Equal(t, uint64(0xe2ac153f30817eaa), rng.Uint64())
+
+	rng1 := NewUnsafeXoroshiro64ssRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoroshiro64ssRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint32(), rng2.Uint32())
+	}
+}
+
+func Test_SafeRNG_Uint32(t *testing.T) {
+	// an UnsafeRNG32 is drawn from natively, anything else is truncated
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePcg32RNG(1) })
+	assert.Equal(t, NewUnsafePcg32RNG(1).Uint32(), rng.Uint32())
+	rng = NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	assert.Equal(t, uint32(NewUnsafeXoshiro256ssRNG(1).Uint64()), rng.Uint32())
+}
+
+func Test_UnsafeXoshiro512ssRNG_Uint64(t *testing.T) {
+	// reference xoshiro512starstar.c output for the state {1, 2, 3, 4, 5, 6, 7, 8}
+	rng := &UnsafeXoshiro512ssRNG{s: [8]uint64{1, 2, 3, 4, 5, 6, 7, 8}}
+	expected := []uint64{0x2d00, 0, 0x5a00, 0x1692480, 0x21c0004380, 0x4380002d2d00000}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	rng1 := NewUnsafeXoshiro512ssRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro512ssRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeXoshiro512ssRNG_Jump(t *testing.T) {
+	// checked against the 2^256th and 2^384th powers of the generator's transition matrix over GF(2)
+	rng := &UnsafeXoshiro512ssRNG{s: [8]uint64{1, 2, 3, 4, 5, 6, 7, 8}}
+	rng.Jump()
+	assert.Equal(t, [8]uint64{
+		0x362505100e9f7d7c, 0x63fab37a35129580, 0xac6a00ec8dc639a2, 0xded17b8d82675240,
+		0x72579e2a291b4b08, 0xc67538b8bc1fb96d, 0x381684e2d1d18563, 0xcf5958f38a851658,
+	}, rng.s)
+
+	rng = &UnsafeXoshiro512ssRNG{s: [8]uint64{1, 2, 3, 4, 5, 6, 7, 8}}
+	rng.LongJump()
+	assert.Equal(t, [8]uint64{
+		0xa766c0ec8f9c96c5, 0x0cf7521dd61419a3, 0x4b0e7c88390a9998, 0x39193514ee3f4af7,
+		0xe6877a13751bef91, 0x698aa22d907d105b, 0xbe534af9e5fc065e, 0xdbbe821716eea766,
+	}, rng.s)
+}
+
+func Test_SafeRNG_Xoshiro512ss(t *testing.T) {
+	rng := NewSyncPoolXoshiro512ssRNG()
+	for i := 0; i < 4096; i++ {
+		assert.Less(t, rng.Uint32n(10), uint32(10))
+	}
+}
+
+func Test_UnsafeSquaresRNG_Uint64(t *testing.T) {
+	// from a port of the reference squares64, there are no published vectors
+	rng := &UnsafeSquaresRNG{}
+	rng.SetState(0x9b3f1e6c52d7a48f, 0)
+	expected := []uint64{11418062586660802377, 17656225905815888872, 16006497435824237172, 14924080122126998956}
+	for i, x := range expected {
+		assert.Equal(t, x, rng.At(uint64(i)))
+		assert.Equal(t, x, rng.Uint64())
+	}
+	assert.Equal(t, uint64(18238071411997359160), rng.At(1<<40))
+	assert.Equal(t, expected[3], rng.At(3))
+
+	rng1 := NewUnsafeSquaresRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeSquaresRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeSquaresRNG(1).Uint64(), NewUnsafeSquaresRNG(2).Uint64())
+}
+
+func Test_squaresKey(t *testing.T) {
+	for seed := uint64(0); seed < 1000; seed++ {
+		key := squaresKey(seed)
+		assert.Equal(t, uint64(1), key&1)
+		for half := 0; half < 2; half++ {
+			digits := map[uint64]bool{}
+			for i := 0; i < 8; i++ {
+				d := key >> (4 * uint(8*half+i)) & 0xF
+				assert.NotEqual(t, uint64(0), d)
+				digits[d] = true
+			}
+			assert.Len(t, digits, 8)
+		}
+		assert.NotEqual(t, key>>28&0xF, key>>32&0xF)
+	}
+}
+
+func Test_UnsafeSplitmix64RNG_Uint64(t *testing.T) {
+	// reference splitmix64.c output seeded with 1234567
+	rng := NewUnsafeSplitmix64RNG(1234567)
+	expected := []uint64{6457827717110365317, 3203168211198807973, 9817491932198370423, 4593380528125082431, 16408922859458223821}
+	for i, x := range expected {
+		assert.Equal(t, x, NewUnsafeSplitmix64RNG(1234567).At(uint64(i)))
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	jumped := NewUnsafeSplitmix64RNG(1234567)
+	jumped.Jump(3)
+	assert.Equal(t, expected[3], jumped.Uint64())
+	assert.Equal(t, Splitmix64(42), NewUnsafeSplitmix64RNG(42).Uint64())
+}
+
+func Test_UnsafeRomuRNG_Uint64(t *testing.T) {
+	// from the reference romuDuoJr_random and romuTrio_random, Romu outputs the state before each step
+	duo := &UnsafeRomuDuoJrRNG{x: 1, y: 2}
+	for _, x := range []uint64{0x1, 0xa7067d009e98ae96, 0x27a62ba58000000, 0xbbf058bed6b89bbd, 0x7ffdbd09495c0baa, 0xcaaeabff73471d2f} {
+		assert.Equal(t, x, duo.Uint64())
+	}
+	trio := &UnsafeRomuTrioRNG{x: 1, y: 2, z: 3}
+	for _, x := range []uint64{0x1, 0x7a89bb80ede505e1, 0xc574b00000000000, 0x61cc0dd6fbb3a8b5, 0x995c06dc2702cb77, 0xd865c9526c9df272} {
+		assert.Equal(t, x, trio.Uint64())
+	}
+
+	rng1 := NewUnsafeRomuTrioRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRomuTrioRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeRomuDuoJrRNG(1).Uint64(), NewUnsafeRomuDuoJrRNG(2).Uint64())
+}
+
+func Test_UnsafePcg32RNG_Uint32(t *testing.T) {
+	// pcg32-demo from the reference C implementation, pcg32_srandom_r(&rng, 42u, 54u)
+	rng := &UnsafePcg32RNG{}
+	rng.SetState(42, 54)
+	expected := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint32())
+	}
+}
+
+func Test_UnsafePcg32RNG_Uint64(t *testing.T) {
+	rng := &UnsafePcg32RNG{}
+	rng.SetState(42, 54)
+	assert.Equal(t, uint64(0xa15c02b77b47f409), rng.Uint64())
+
+	rng1 := NewUnsafePcg32RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePcg32RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafePcg32x2RNG_Uint64(t *testing.T) {
+	// the high words are the pcg32-demo stream, the low words a second reference pcg32 stream
+	rng := &UnsafePcg32x2RNG{}
+	rng.SetState(42, 54, 42, 55)
+	lo := &UnsafePcg32RNG{}
+	lo.SetState(42, 55)
+	expected := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	for _, hi := range expected {
+		assert.Equal(t, uint64(hi)<<32|uint64(lo.Uint32()), rng.Uint64())
+	}
+
+	rng1 := NewUnsafePcg32x2RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePcg32x2RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafePcg32x2RNG_StreamCollision(t *testing.T) {
+	// equal selectors, and selectors differing only in the top bit PCG drops, both pick one stream
+	for _, seq2 := range []uint64{54, 54 | 1<<63} {
+		rng := &UnsafePcg32x2RNG{}
+		rng.SetState(42, 54, 42, seq2)
+		assert.NotEqual(t, rng.gen0.inc, rng.gen1.inc)
+
+		same := 0
+		for i := 0; i < 64; i++ {
+			x := rng.Uint64()
+			if uint32(x>>32) == uint32(x) {
+				same++
+			}
+		}
+		assert.Less(t, same, 2)
+	}
+
+	for seed := int64(0); seed < 256; seed++ {
+		rng := NewUnsafePcg32x2RNG(seed)
+		assert.NotEqual(t, rng.gen0.inc, rng.gen1.inc)
+	}
+}
+
+func Test_UnsafeJsf64RNG_Uint64(t *testing.T) {
+	// raninit(seed) followed by ranval() from the reference jsf64
+	vectors := map[int64][]uint64{
+		0:  {0x4b39c42db38fcdf5, 0xaee2c9e919833f29, 0x30611cd75d0254ce, 0x7fcfd4f0c54692bb},
+		1:  {0xae735ca10d060948, 0x8e16aa0268563732, 0x8f061cf1eaa2da64, 0xe15ae6dde013cc82},
+		-1: {0xa8e6401bfdc94959, 0xc67c7d34b4ee9963, 0x459c60a6f88a1cd0, 0xedab3b4210d37021},
+	}
+	for seed, expected := range vectors {
+		rng := NewUnsafeJsf64RNG(seed)
+		for _, x := range expected {
+			assert.Equal(t, x, rng.Uint64())
+		}
+	}
+
+	rng1 := NewUnsafeJsf64RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeJsf64RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeGjrandRNG_Uint64(t *testing.T) {
+	// gjrand_init64(seed) followed by the reference 64 bit crank
+	vectors := map[int64][]uint64{
+		0:  {0x86dd37480304e81b, 0xc7ef986ab4be370a, 0x45dcb5505df61ae6, 0x7454e66ae26f0c03},
+		1:  {0xfe92a78cbeb04642, 0xb743ff7ee4c42780, 0x29bd4c64666e67bf, 0x27a6544fcbba2ebe},
+		-1: {0x0754861b8fdc17c2, 0x2e04e571fe3b9125, 0x838f08cd9e5dbfd5, 0x612c816df893a90d},
+	}
+	for seed, expected := range vectors {
+		rng := NewUnsafeGjrandRNG(seed)
+		for _, x := range expected {
+			assert.Equal(t, x, rng.Uint64())
+		}
+	}
+
+	rng1 := NewUnsafeGjrandRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeGjrandRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeKiss64RNG_Uint64(t *testing.T) {
+	// Marsaglia's example state and check value, the 10^8th output
+	rng := &UnsafeKiss64RNG{}
+	rng.SetState(1234567890987654321, 123456123456123456, 362436362436362436, 1066149217761810)
+	expected := []uint64{0x7bf856948de350b4, 0x4f3f0ffc2151f23b, 0xfe8db07360509101, 0xc680b96777f2d4da}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+	// the check value takes 10^8 draws, too slow for -short
+	if !testing.Short() {
+		var x uint64
+		for i := len(expected); i < 100000000; i++ {
+			x = rng.Uint64()
+		}
+		assert.Equal(t, uint64(1666297717051644203), x)
+	}
+
+	rng1 := NewUnsafeKiss64RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeKiss64RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeLehmer128RNG_Uint64(t *testing.T) {
+	// the high words of 1*m^k mod 2^128
+	rng := &UnsafeLehmer128RNG{}
+	rng.SetState(0, 1)
+	expected := []uint64{0, 13447920729462039988, 15814042893181868240, 6573358403997055337, 8776109462712445299}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	// a zero state is made odd rather than stuck at zero
+	rng.SetState(0, 0)
+	assert.Equal(t, uint64(1), rng.lo)
+	rng.Uint64()
+	assert.NotEqual(t, uint64(0), rng.Uint64())
+
+	rng1 := NewUnsafeLehmer128RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeLehmer128RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeLehmer128RNG(1).Uint64(), NewUnsafeLehmer128RNG(2).Uint64())
+}
+
+func Test_UnsafePhilox4x64RNG_Uint64(t *testing.T) {
+	// Random123 kat_vectors for philox4x64 with 10 rounds
+	assert.Equal(t, [4]uint64{0x16554d9eca36314c, 0xdb20fe9d672d0fdc, 0xd7e772cee186176b, 0x7e68b68aec7ba23b},
+		philox4x64([4]uint64{}, [2]uint64{}))
+	ones := ^uint64(0)
+	assert.Equal(t, [4]uint64{0x87b092c3013fe90b, 0x438c3c67be8d0224, 0x9cc7d7c69cd777b6, 0xa09caebf594f0ba0},
+		philox4x64([4]uint64{ones, ones, ones, ones}, [2]uint64{ones, ones}))
+	assert.Equal(t, [4]uint64{0xa528f45403e61d95, 0x38c72dbd566e9788, 0xa5a1610e72fd18b5, 0x57bd43b5e52b7fe6},
+		philox4x64([4]uint64{0x243f6a8885a308d3, 0x13198a2e03707344, 0xa4093822299f31d0, 0x082efa98ec4e6c89},
+			[2]uint64{0x452821e638d01377, 0xbe5466cf34e90c6c}))
+
+	// the counter is incremented before use, carrying through all 4 words
+	rng := &UnsafePhilox4x64RNG{}
+	rng.SetState([4]uint64{ones, ones, ones, ones}, [2]uint64{})
+	for _, x := range philox4x64([4]uint64{}, [2]uint64{}) {
+		assert.Equal(t, x, rng.Uint64())
+	}
+	assert.Equal(t, philox4x64([4]uint64{1}, [2]uint64{})[0], rng.Uint64())
+
+	rng1 := NewUnsafePhilox4x64RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePhilox4x64RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafePhilox4x64RNG(1).Uint64(), NewUnsafePhilox4x64RNG(2).Uint64())
+}
+
+func Test_UnsafePcg64RNG_Uint64(t *testing.T) {
+	// pcg64-demo from the reference C implementation, pcg64_srandom_r(&rng, 42u, 54u)
+	rng := &UnsafePcg64RNG{}
+	rng.SetState(42, 54)
+	expected := []uint64{0x86b1da1d72062b68, 0x1304aa46c9853d39, 0xa3670e9e0dd50358, 0xf9090e529a7dae00, 0xc85b9fd837996f2c, 0x606121f8e3919196}
+	for _, x := range expected {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	rng1 := NewUnsafePcg64RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePcg64RNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+
+	// the top bit of initseq reaches the high half of the increment
+	other := &UnsafePcg64RNG{}
+	other.SetState(42, 54|1<<63)
+	assert.NotEqual(t, expected[0], other.Uint64())
+}
+
+func Test_UnsafePcg64DxsmRNG_Uint64(t *testing.T) {
+	// NOT NumPy output: these come from a line by line Python port of pcg_cm_srandom_r and
+	// pcg_cm_random_r in NumPy's pcg64.h, so they only pin the Go code to that port. The DXSM output
+	// is checked against math/rand/v2 in pcg64dxsm_go122_test.go and the step against math/big below
+	vectors := []struct {
+		seed, stream uint64
+		expected     []uint64
+	}{
+		{42, 54, []uint64{0xf0847c9518bddb90, 0x8e7d5f5514ba8aaa, 0x86fbd36f8028f6fd, 0x8d14b6edbe9f740a, 0xa85b2896c7cad55d, 0x8ca3894a1d9227bb}},
+		{0, 0, []uint64{0x0, 0x5238ea76d1f0df4a, 0x1a3c4747022e48a4, 0x340b0228e6afc056, 0x81bb52f8baaa203a, 0xfd17a4a4b0a1ce3}},
+		{^uint64(0), ^uint64(0), []uint64{0x95dd4e52f0c02c27, 0x6728f4e51a19abe5, 0x522b356209e0d6cc, 0x1da241a93a030558, 0x2a78bfd930723e5c, 0x87583bc45cac521}},
+	}
+	for _, v := range vectors {
+		rng := &UnsafePcg64DxsmRNG{}
+		rng.SetState(v.seed, v.stream)
+		for _, x := range v.expected {
+			assert.Equal(t, x, rng.Uint64())
+		}
+	}
+
+	// the step is state*PCG_CHEAP_MULTIPLIER_128 + inc mod 2^128, redone with math/big
+	rng := NewUnsafePcg64DxsmRNG(7)
+	mod := new(big.Int).Lsh(big.NewInt(1), 128)
+	u128 := func(hi uint64, lo uint64) *big.Int {
+		x := new(big.Int).SetUint64(hi)
+		return x.Lsh(x, 64).Or(x, new(big.Int).SetUint64(lo))
+	}
+	for i := 0; i < 100; i++ {
+		want := new(big.Int).Mul(u128(rng.hi, rng.lo), new(big.Int).SetUint64(pcgCheapMul))
+		want.Add(want, u128(rng.incHi, rng.incLo)).Mod(want, mod)
+		rng.Uint64()
+		assert.Equal(t, 0, want.Cmp(u128(rng.hi, rng.lo)))
+	}
+
+	rng1 := NewUnsafePcg64DxsmRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafePcg64DxsmRNG(1) })
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
 func Test_NewUnsafeRandRNG_UInt64(t *testing.T) {
 	rng := NewUnsafeRandRNG(1)
 	r := rng.Uint64()
@@ -117,6 +556,51 @@ func Benchmark_UnsafeXoshiro256ssRNG(b *testing.B) {
 	BenchSink = &r
 }
 
+func Benchmark_UnsafeXoshiro256ppRNG(b *testing.B) {
+	rng := NewUnsafeXoshiro256ppRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafeXoroshiro64ssRNG_Uint32(b *testing.B) {
+	rng := NewUnsafeXoroshiro64ssRNG(time.Now().UnixNano())
+	var r uint32
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint32()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafeXoroshiro128ppRNG(b *testing.B) {
+	rng := NewUnsafeXoroshiro128ppRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafeXoshiro512ssRNG(b *testing.B) {
+	rng := NewUnsafeXoshiro512ssRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafeSplitmix64RNG(b *testing.B) {
+	rng := NewUnsafeSplitmix64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
 func Benchmark_UnsafeRandRNG(b *testing.B) {
 	rng := NewUnsafeRandRNG(time.Now().UnixNano())
 	var r uint64
@@ -135,6 +619,24 @@ func Benchmark_UnsafePcg32RNG(b *testing.B) {
 	BenchSink = &r
 }
 
+func Benchmark_UnsafePcg64RNG(b *testing.B) {
+	rng := NewUnsafePcg64RNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_UnsafePcg64DxsmRNG(b *testing.B) {
+	rng := NewUnsafePcg64DxsmRNG(time.Now().UnixNano())
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
+
 func Benchmark_FastModulo(b *testing.B) {
 	maxN := uint64(10)
 	rng := NewUnsafeRandRNG(time.Now().UnixNano())
@@ -307,32 +809,3 @@ func Benchmark_SyncPoolBytes_Parallel_1024bytes(b *testing.B) {
 		BenchSink = &bytes
 	})
 }
-
-/*
-goos: windows
-goarch: amd64
-pkg: github.com/villenny/fastrand64-go
-Benchmark_UnsafeXoshiro256ssRNG-8                      	455017189	         2.68 ns/op	       0 B/op	       0 allocs/op
-Benchmark_UnsafeRandRNG-8                              	157644103	         7.52 ns/op	       0 B/op	       0 allocs/op
-Benchmark_UnsafePcg32RNG-8                             	553564772	         2.24 ns/op	       0 B/op	       0 allocs/op
-Benchmark_FastModulo-8                                 	162549952	         7.43 ns/op	       0 B/op	       0 allocs/op
-Benchmark_Modulo-8                                     	150343704	         7.79 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolXoshiro256ssRNG_Uint32n_Serial-8     	35329238	        34.5 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolXoshiro256ssRNG_Uint32n_Parallel-8   	147113551	         8.37 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolXoshiro256ssRNG_Uint64_Serial-8      	34326316	        33.8 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolUnsafeRandRNG_Uint64_Serial-8        	32465599	        37.3 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolXoshiro256ssRNG_Uint64_Parallel-8    	146641885	         8.36 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolUnsafeRandRNG_Uint64_Parallel-8      	126181107	         8.91 ns/op	       0 B/op	       0 allocs/op
-Benchmark_Rand_Int31n_Serial-8                         	70664302	        17.3 ns/op	       0 B/op	       0 allocs/op
-Benchmark_Rand_Int31n_Parallel-8                       	12513151	        94.4 ns/op	       0 B/op	       0 allocs/op
-Benchmark_ValyalaFastrand_Int31n_Serial-8              	50053598	        24.1 ns/op	       0 B/op	       0 allocs/op
-Benchmark_ValyalaFastrand_Int31n_Parallel-8            	158120858	         7.97 ns/op	       0 B/op	       0 allocs/op
-Benchmark_Rand_Uint64_Serial-8                         	85800085	        14.8 ns/op	       0 B/op	       0 allocs/op
-Benchmark_Rand_Uint64_Parallel-8                       	12012588	        87.1 ns/op	       0 B/op	       0 allocs/op
-Benchmark_SyncPoolBytes_Serial_64bytes-8               	 8964448	       144 ns/op	      64 B/op	       1 allocs/op
-Benchmark_SyncPoolBytes_Serial_1024bytes-8             	 1000000	      1311 ns/op	    1024 B/op	       1 allocs/op
-Benchmark_SyncPoolBytes_Serial_1Mbytes-8               	     945	   1625825 ns/op	 1048923 B/op	       1 allocs/op
-Benchmark_SyncPoolBytes_Parallel_1024bytes-8           	 2053411	       577 ns/op	    1024 B/op	       1 allocs/op
-PASS
-ok  	github.com/villenny/fastrand64-go	32.704s
-*/
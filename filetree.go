@@ -0,0 +1,104 @@
+package fastrand64
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// FileTreeOptions configures GenerateFileTree
+type FileTreeOptions struct {
+	Seed int64
+	// Depth is how many levels of subdirectories to create below the root
+	Depth int
+	// each directory gets a uniform [0..MaxDirsPerDir] subdirectories and [0..MaxFilesPerDir] files
+	MaxDirsPerDir  int
+	MaxFilesPerDir int
+	// file sizes are log-uniform in [MinFileSize..MaxFileSize], like real file systems skew small
+	MinFileSize int64
+	MaxFileSize int64
+}
+
+// FileTreeStats summarizes what GenerateFileTree created
+type FileTreeStats struct {
+	Dirs  int
+	Files int
+	Bytes int64
+}
+
+// GenerateFileTree creates a reproducible tree of directories and random files under root, the same
+// options (including Seed) always produce the same names, sizes and contents
+func GenerateFileTree(root string, opts FileTreeOptions) (FileTreeStats, error) {
+	var stats FileTreeStats
+	err := generateFileTreeDir(NewUnsafeXoshiro256ssRNG(opts.Seed), root, 0, &opts, &stats)
+	return stats, err
+}
+
+func generateFileTreeDir(r UnsafeRNG, dir string, depth int, opts *FileTreeOptions, stats *FileTreeStats) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	stats.Dirs++
+
+	files := intn(r, opts.MaxFilesPerDir+1)
+	for i := 0; i < files; i++ {
+		name := filepath.Join(dir, randomFileName(r, i)+".dat")
+		size := logUniformSize(r, opts.MinFileSize, opts.MaxFileSize)
+		// each file has its own stream so names and sizes don't depend on content length
+		content := NewUnsafeXoshiro256ssRNG(int64(r.Uint64()))
+		if err := writeRandomFile(name, content, size); err != nil {
+			return err
+		}
+		stats.Files++
+		stats.Bytes += size
+	}
+
+	if depth >= opts.Depth {
+		return nil
+	}
+	dirs := intn(r, opts.MaxDirsPerDir+1)
+	for i := 0; i < dirs; i++ {
+		sub := NewUnsafeXoshiro256ssRNG(int64(r.Uint64()))
+		if err := generateFileTreeDir(sub, filepath.Join(dir, randomFileName(r, i)), depth+1, opts, stats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomFileName returns 4-12 random lowercase letters, suffixed with i so siblings never collide
+func randomFileName(r UnsafeRNG, i int) string {
+	name := make([]byte, 4+intn(r, 9), 16)
+	for j := range name {
+		name[j] = byte('a' + intn(r, 26))
+	}
+	return string(strconv.AppendInt(append(name, '-'), int64(i), 10))
+}
+
+func logUniformSize(r UnsafeRNG, min int64, max int64) int64 {
+	if max <= min {
+		return min
+	}
+	lo, hi := math.Log(float64(min)+1), math.Log(float64(max)+1)
+	size := int64(math.Exp(lo+unitFloat64(r)*(hi-lo))) - 1
+	if size < min {
+		return min
+	}
+	if size > max {
+		return max
+	}
+	return size
+}
+
+func writeRandomFile(name string, r UnsafeRNG, size int64) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err = WriteRandom(f, r, size); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
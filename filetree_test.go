@@ -0,0 +1,64 @@
+package fastrand64
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hashFileTree maps every relative path under root to a digest of its contents ("" for dirs)
+func hashFileTree(t *testing.T, root string) map[string]string {
+	result := map[string]string{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		if info.IsDir() {
+			result[rel] = ""
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		sum := sha256.Sum256(b)
+		result[rel] = string(sum[:])
+		return err
+	})
+	assert.NoError(t, err)
+	return result
+}
+
+func Test_GenerateFileTree(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "filetree")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	opts := FileTreeOptions{Seed: 5, Depth: 2, MaxDirsPerDir: 3, MaxFilesPerDir: 4, MinFileSize: 0, MaxFileSize: 100000}
+	stats1, err := GenerateFileTree(filepath.Join(tmp, "a"), opts)
+	assert.NoError(t, err)
+	stats2, err := GenerateFileTree(filepath.Join(tmp, "b"), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, stats1, stats2)
+	assert.Equal(t, hashFileTree(t, filepath.Join(tmp, "a")), hashFileTree(t, filepath.Join(tmp, "b")))
+
+	tree := hashFileTree(t, filepath.Join(tmp, "a"))
+	assert.Equal(t, stats1.Dirs+stats1.Files, len(tree))
+
+	opts.Seed = 6
+	_, err = GenerateFileTree(filepath.Join(tmp, "c"), opts)
+	assert.NoError(t, err)
+	assert.NotEqual(t, tree, hashFileTree(t, filepath.Join(tmp, "c")))
+}
+
+func Test_GenerateFileTree_Sizes(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 1000; i++ {
+		size := logUniformSize(r, 10, 1000)
+		assert.GreaterOrEqual(t, size, int64(10))
+		assert.LessOrEqual(t, size, int64(1000))
+	}
+	assert.Equal(t, int64(7), logUniformSize(r, 7, 7))
+}
@@ -0,0 +1,106 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// FitLogNormal returns the maximum likelihood LogNormal for positive samples, the mean and
+// standard deviation of their logs
+func FitLogNormal(samples []float64) (*LogNormal, error) {
+	if err := checkFitSamples(samples, 2); err != nil {
+		return nil, err
+	}
+	mu := 0.0
+	for _, x := range samples {
+		mu += math.Log(x)
+	}
+	mu /= float64(len(samples))
+	variance := 0.0
+	for _, x := range samples {
+		variance += (math.Log(x) - mu) * (math.Log(x) - mu)
+	}
+	return NewLogNormal(mu, math.Sqrt(variance/float64(len(samples))))
+}
+
+// FitGamma returns the maximum likelihood Gamma for positive samples. The shape starts from
+// Minka's closed form approximation and is polished by Newton's method, the scale is mean/shape
+// See: Minka, "Estimating a Gamma distribution"
+func FitGamma(samples []float64) (*Gamma, error) {
+	if err := checkFitSamples(samples, 2); err != nil {
+		return nil, err
+	}
+	n := float64(len(samples))
+	mean, meanLog := 0.0, 0.0
+	for _, x := range samples {
+		mean += x
+		meanLog += math.Log(x)
+	}
+	mean /= n
+	meanLog /= n
+	s := math.Log(mean) - meanLog
+	if !(s > 0) {
+		return nil, errors.New("FitGamma needs samples that are not all equal")
+	}
+	k := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s)
+	for i := 0; i < 20; i++ {
+		step := (math.Log(k) - digamma(k) - s) / (1/k - trigamma(k))
+		k -= step
+		if math.Abs(step) < 1e-12*k {
+			break
+		}
+	}
+	return NewGamma(k, mean/k)
+}
+
+// FitPareto returns the maximum likelihood Pareto for positive samples, xm is the smallest sample
+// and alpha is n / sum(log(x/xm))
+func FitPareto(samples []float64) (*Pareto, error) {
+	if err := checkFitSamples(samples, 2); err != nil {
+		return nil, err
+	}
+	xm := samples[0]
+	for _, x := range samples {
+		xm = math.Min(xm, x)
+	}
+	sum := 0.0
+	for _, x := range samples {
+		sum += math.Log(x / xm)
+	}
+	if !(sum > 0) {
+		return nil, errors.New("FitPareto needs samples that are not all equal")
+	}
+	return NewPareto(float64(len(samples))/sum, xm)
+}
+
+func checkFitSamples(samples []float64, min int) error {
+	if len(samples) < min {
+		return errors.New("fitting needs more samples")
+	}
+	for _, x := range samples {
+		if !(x > 0) || math.IsInf(x, 1) {
+			return errors.New("fitting needs finite positive samples")
+		}
+	}
+	return nil
+}
+
+// digamma is the derivative of log Gamma, by recurrence up to 10 then its asymptotic series
+func digamma(x float64) float64 {
+	result := 0.0
+	for ; x < 10; x++ {
+		result -= 1 / x
+	}
+	x2 := 1 / (x * x)
+	return result + math.Log(x) - 0.5/x - x2*(1.0/12-x2*(1.0/120-x2*(1.0/252-x2*(1.0/240))))
+}
+
+// trigamma is the derivative of digamma, by recurrence up to 10 then its asymptotic series
+func trigamma(x float64) float64 {
+	result := 0.0
+	for ; x < 10; x++ {
+		result += 1 / (x * x)
+	}
+	x2 := 1 / (x * x)
+	return result + 1/x + x2/2 + x2/x*(1.0/6-x2*(1.0/30-x2*(1.0/42-x2*(1.0/30-x2*(5.0/66)))))
+}
@@ -0,0 +1,53 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FitLogNormal(t *testing.T) {
+	truth, _ := NewLogNormal(3, 0.8)
+	d, err := FitLogNormal(NewSyncPoolXoshiro256ssRNG().FillDist(truth, make([]float64, 50000)))
+	assert.NoError(t, err)
+	assert.InDelta(t, 3, d.mu, 0.02)
+	assert.InDelta(t, 0.8, d.sigma, 0.02)
+}
+
+func Test_FitGamma(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, c := range []struct{ shape, scale float64 }{{0.5, 4}, {2, 1}, {30, 0.1}} {
+		truth, _ := NewGamma(c.shape, c.scale)
+		d, err := FitGamma(rng.FillDist(truth, make([]float64, 50000)))
+		assert.NoError(t, err)
+		assert.InEpsilon(t, c.shape, d.shape, 0.05, c)
+		assert.InEpsilon(t, c.scale, d.scale, 0.05, c)
+	}
+}
+
+func Test_FitPareto(t *testing.T) {
+	truth, _ := NewPareto(2.5, 5)
+	d, err := FitPareto(NewSyncPoolXoshiro256ssRNG().FillDist(truth, make([]float64, 50000)))
+	assert.NoError(t, err)
+	assert.InDelta(t, 5, d.xm, 0.01)
+	assert.InEpsilon(t, 2.5, d.alpha, 0.03)
+}
+
+func Test_Fit_Errors(t *testing.T) {
+	for _, samples := range [][]float64{nil, {1}, {1, -1}, {0, 1}, {1, math.Inf(1)}, {2, 2, 2}} {
+		_, err := FitLogNormal(samples)
+		assert.Error(t, err, samples)
+		_, err = FitGamma(samples)
+		assert.Error(t, err, samples)
+		_, err = FitPareto(samples)
+		assert.Error(t, err, samples)
+	}
+}
+
+func Test_digamma(t *testing.T) {
+	assert.InDelta(t, -eulerGamma, digamma(1), 1e-12)
+	assert.InDelta(t, -eulerGamma-2*math.Ln2, digamma(0.5), 1e-12)
+	assert.InDelta(t, math.Pi*math.Pi/6, trigamma(1), 1e-12)
+	assert.InDelta(t, math.Pi*math.Pi/2, trigamma(0.5), 1e-12)
+}
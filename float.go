@@ -0,0 +1,184 @@
+package fastrand64
+
+// Float64 returns a pseudorandom float64 in the range [0..1), the top 53 bits of a Uint64 scaled
+// down so every value is a multiple of 2^-53. Threadsafe
+func (s *ThreadsafePoolRNG) Float64() float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := unitFloat64(r)
+	rngPool.Put(r)
+	return x
+}
+
+// Float32 returns a pseudorandom float32 in the range [0..1), the top 24 bits of a Uint64 scaled
+// down, without going through a float64. Threadsafe
+func (s *ThreadsafePoolRNG) Float32() float32 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := unitFloat32(r)
+	rngPool.Put(r)
+	return x
+}
+
+// Float32n returns a pseudorandom float32 in the range [0..max), eg a coordinate or an angle, all
+// 2^24 values are evenly spaced by max/2^24 and the largest still rounds below max. Threadsafe
+func (s *ThreadsafePoolRNG) Float32n(max float32) float32 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := float32(r.Uint64()>>40) * (max * (1.0 / (1 << 24)))
+	rngPool.Put(r)
+	return x
+}
+
+// unitFloat32 returns a pseudorandom float32 in the range [0..1) built from the top 24 bits of a Uint64
+func unitFloat32(r UnsafeRNG) float32 {
+	return float32(r.Uint64()>>40) * (1.0 / (1 << 24))
+}
+
+// Float32 returns pseudorandom float32 in the range [0..1) from the Default RNG. Threadsafe
+func Float32() float32 {
+	return defaultRNG().Float32()
+}
+
+// Float32n returns pseudorandom float32 in the range [0..max) from the Default RNG. Threadsafe
+func Float32n(max float32) float32 {
+	return defaultRNG().Float32n(max)
+}
+
+// Float64 returns pseudorandom float64 in the range [0..1) from the Default RNG. Threadsafe
+func Float64() float64 {
+	return defaultRNG().Float64()
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1). Threadsafe
+func (r RuntimeRNG) Float64() float64 {
+	return unitFloat64(r)
+}
+
+// The unsafe generators' Float64 methods are the same construction as unitFloat64, written out
+// so the generator's Uint64 is a direct call and can inline
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeXoshiro256ssRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeXoshiro256ppRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeXoroshiro128ppRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeXoroshiro64ssRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeXoshiro512ssRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeSplitmix64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeSquaresRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeRomuDuoJrRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeRomuTrioRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafePcg32RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafePcg64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafePcg64DxsmRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeLehmer128RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafePhilox4x64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafePcg32x2RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeJsf64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeKiss64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeGjrandRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeIsaac64RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeShishuaRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeSipHashRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeAesCtrRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeChaCha8RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeRdrandRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0..1), (not thread safe)
+func (r *UnsafeCryptoRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
@@ -0,0 +1,79 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Float64(t *testing.T) {
+	// every generator agrees with the top 53 bits of its Uint64
+	for _, a := range Algorithms() {
+		rng, expected := a.New(7), a.New(7)
+		f, ok := rng.(interface{ Float64() float64 })
+		if !assert.True(t, ok, a.Name) {
+			continue
+		}
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, float64(expected.Uint64()>>11)/(1<<53), f.Float64(), a.Name)
+		}
+	}
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var sum float64
+	n := 100000
+	for i := 0; i < n; i++ {
+		x := rng.Float64()
+		assert.True(t, x >= 0 && x < 1)
+		sum += x
+	}
+	// the mean of U[0,1) has a standard error of 1/sqrt(12n)
+	assert.InDelta(t, 0.5, sum/float64(n), 5/math.Sqrt(12*float64(n)))
+
+	for i := 0; i < 100; i++ {
+		x := Float64()
+		assert.True(t, x >= 0 && x < 1)
+		x = NewRuntimeRNG().Float64()
+		assert.True(t, x >= 0 && x < 1)
+		x = NewUnsafeCryptoRNG().Float64()
+		assert.True(t, x >= 0 && x < 1)
+	}
+
+	// the largest value is the float just below 1, and never rounds up to it
+	edge := NewSyncPoolRNG(func() UnsafeRNG { return &counterRNG{x: math.MaxUint64 - 1} })
+	assert.Equal(t, 1-1.0/(1<<53), edge.Float64())
+	assert.Equal(t, 0.0, edge.Float64())
+}
+
+func Test_Float32(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var sum float64
+	n := 100000
+	for i := 0; i < n; i++ {
+		x := rng.Float32()
+		assert.True(t, x >= 0 && x < 1)
+		sum += float64(x)
+	}
+	assert.InDelta(t, 0.5, sum/float64(n), 5/math.Sqrt(12*float64(n)))
+
+	expected := NewUnsafeXoshiro256ssRNG(3)
+	same := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(3) })
+	assert.Equal(t, float32(expected.Uint64()>>40)/(1<<24), same.Float32())
+	assert.Equal(t, float32(expected.Uint64()>>40)*10/(1<<24), same.Float32n(10))
+
+	// the largest value stays below max, including maxima whose mantissa is all ones
+	for _, max := range []float32{1, 10, 1.5, math.Nextafter32(2, 0), math.MaxFloat32, 1e-30} {
+		edge := NewSyncPoolRNG(func() UnsafeRNG { return &counterRNG{x: math.MaxUint64 - 1} })
+		assert.Less(t, edge.Float32n(max), max, max)
+		assert.Equal(t, float32(0), edge.Float32n(max))
+	}
+	edge := NewSyncPoolRNG(func() UnsafeRNG { return &counterRNG{x: math.MaxUint64 - 1} })
+	assert.Equal(t, 1-float32(1.0)/(1<<24), edge.Float32())
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, Float32() < 1)
+		x := Float32n(360)
+		assert.True(t, x >= 0 && x < 360)
+	}
+}
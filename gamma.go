@@ -0,0 +1,119 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// Gamma is the gamma distribution with shape k and scale theta, mean k*theta and variance k*theta^2
+type Gamma struct {
+	shape, scale float64
+}
+
+// NewGamma validates shape > 0 and scale > 0
+func NewGamma(shape float64, scale float64) (*Gamma, error) {
+	if !(shape > 0) || math.IsInf(shape, 1) {
+		return nil, errors.New("Gamma shape must be finite and > 0")
+	}
+	if !(scale > 0) || math.IsInf(scale, 1) {
+		return nil, errors.New("Gamma scale must be finite and > 0")
+	}
+	return &Gamma{shape: shape, scale: scale}, nil
+}
+
+// Sample draws with the Marsaglia-Tsang method, boosting shapes below 1 by U^(1/shape)
+// See: Marsaglia and Tsang, "A simple method for generating gamma variables"
+func (d *Gamma) Sample(r UnsafeRNG) float64 {
+	return d.scale * standardGamma(r, d.shape)
+}
+
+// Gamma draws from a gamma distribution with shape k and scale theta using a single pool checkout,
+// it panics unless shape and scale are finite and > 0, as NewGamma validates. Threadsafe
+func (s *ThreadsafePoolRNG) Gamma(shape float64, scale float64) float64 {
+	d, err := NewGamma(shape, scale)
+	if err != nil {
+		panic(err.Error())
+	}
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := d.Sample(r)
+	rngPool.Put(r)
+	return x
+}
+
+func standardGamma(r UnsafeRNG, shape float64) float64 {
+	if shape < 1 {
+		return standardGamma(r, shape+1) * math.Pow(openUnitFloat64(r), 1/shape)
+	}
+	dd := shape - 1.0/3
+	c := 1 / math.Sqrt(9*dd)
+	for {
+		x := normal(r)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := openUnitFloat64(r)
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+dd*(1-v+math.Log(v)) {
+			return dd * v
+		}
+	}
+}
+
+// Quantile returns the inverse CDF, numerically inverting the regularized incomplete gamma function
+func (d *Gamma) Quantile(p float64) float64 {
+	switch {
+	case !(p >= 0 && p <= 1):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return math.Inf(1)
+	}
+	cdf := func(x float64) float64 { return gammaP(d.shape, x) }
+	return d.scale * invertCDF(cdf, p, 0, d.shape+1)
+}
+
+// gammaP is the regularized lower incomplete gamma function P(a, x), by its series below a+1 and
+// its continued fraction above
+func gammaP(a float64, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - lg)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000 && math.Abs(term) > math.Abs(sum)*1e-16; n++ {
+			term *= x / (a + n)
+			sum += term
+		}
+		return math.Min(1, prefix*sum)
+	}
+	// modified Lentz for the continued fraction of Q(a, x)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	dd := 1 / b
+	h := dd
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		dd = an*dd + b
+		if math.Abs(dd) < tiny {
+			dd = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		dd = 1 / dd
+		delta := dd * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-16 {
+			break
+		}
+	}
+	return math.Max(0, 1-prefix*h)
+}
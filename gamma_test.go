@@ -0,0 +1,39 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Gamma(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, c := range []struct{ shape, scale float64 }{{0.3, 1}, {1, 2}, {4.5, 0.5}, {100, 3}} {
+		d, err := NewGamma(c.shape, c.scale)
+		assert.NoError(t, err)
+		mean, variance := meanAndVariance(rng.FillDist(d, make([]float64, 200000)))
+		assert.InEpsilon(t, c.shape*c.scale, mean, 0.02, c)
+		assert.InEpsilon(t, c.shape*c.scale*c.scale, variance, 0.04, c)
+	}
+	assert.Greater(t, rng.Gamma(2, 3), 0.0)
+
+	for _, params := range [][2]float64{{0, 1}, {1, 0}, {-1, 1}, {2, -3}, {math.Inf(1), 1}, {math.NaN(), 1}} {
+		_, err := NewGamma(params[0], params[1])
+		assert.Error(t, err)
+		assert.Panics(t, func() { rng.Gamma(params[0], params[1]) }, params)
+	}
+}
+
+func Test_gammaP(t *testing.T) {
+	for _, x := range []float64{0.01, 0.5, 1, 2, 5, 30} {
+		assert.InDelta(t, 1-math.Exp(-x), gammaP(1, x), 1e-14)
+		assert.InDelta(t, math.Erf(math.Sqrt(x)), gammaP(0.5, x), 1e-14)
+		// P(3, x) = 1 - e^-x (1 + x + x^2/2)
+		assert.InDelta(t, 1-math.Exp(-x)*(1+x+x*x/2), gammaP(3, x), 1e-14)
+	}
+	assert.Equal(t, 0.0, gammaP(2, 0))
+
+	d, _ := NewGamma(3, 2)
+	assert.InDelta(t, 0.5, gammaP(3, d.Quantile(0.5)/2), 1e-12)
+}
@@ -0,0 +1,326 @@
+package fastrand64
+
+import (
+	"fmt"
+	"math"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FromGrammar compiles a tiny EBNF grammar into a generator of random sentences. The first
+// production is the start rule. Each call of the returned func checks one generator out of the
+// pool, so it is threadsafe.
+//
+// The grammar is a list of productions terminated by "." or ";", for example:
+//
+//	expr   = term { ("+" | "-") term } .
+//	term   = number | "(" expr ")" .
+//	number = /[1-9][0-9]{0,3}/ .
+//
+// with "..." '...' or `...` literals, /.../ regexp terminals (see FromRegexp), ( ) grouping,
+// [ ] optional, { } repetition and | alternation. Once maxDepth nested rules have been expanded,
+// the generator only takes the shortest way out, so output is always finite.
+func (s *ThreadsafePoolRNG) FromGrammar(rules string, maxDepth int) (func() string, error) {
+	g, err := parseGrammar(rules)
+	if err != nil {
+		return nil, err
+	}
+	return func() string {
+		rngPool := s.pool()
+		r := rngPool.Get().(UnsafeRNG)
+		var sb strings.Builder
+		g.gen(r, g.rules[g.start], 0, maxDepth, &sb)
+		rngPool.Put(r)
+		return sb.String()
+	}, nil
+}
+
+type grammarOp int
+
+const (
+	grammarLiteral grammarOp = iota
+	grammarRegexp
+	grammarRef
+	grammarSeq
+	grammarAlt
+	grammarOpt
+	grammarRep
+)
+
+type grammarNode struct {
+	op      grammarOp
+	literal string
+	re      *syntax.Regexp
+	sub     []*grammarNode
+	height  int // fewest nested rule expansions needed to finish this node
+}
+
+type grammar struct {
+	start string
+	rules map[string]*grammarNode
+}
+
+func (g *grammar) gen(r UnsafeRNG, n *grammarNode, depth int, maxDepth int, sb *strings.Builder) {
+	switch n.op {
+	case grammarLiteral:
+		sb.WriteString(n.literal)
+	case grammarRegexp:
+		genRegexp(r, n.re, sb)
+	case grammarRef:
+		g.gen(r, g.rules[n.literal], depth+1, maxDepth, sb)
+	case grammarSeq:
+		for _, sub := range n.sub {
+			g.gen(r, sub, depth, maxDepth, sb)
+		}
+	case grammarAlt:
+		if depth < maxDepth {
+			g.gen(r, n.sub[intn(r, len(n.sub))], depth, maxDepth, sb)
+			return
+		}
+		shortest := n.sub[0]
+		for _, sub := range n.sub[1:] {
+			if sub.height < shortest.height {
+				shortest = sub
+			}
+		}
+		g.gen(r, shortest, depth, maxDepth, sb)
+	case grammarOpt:
+		if depth < maxDepth && r.Uint64()&1 == 1 {
+			g.gen(r, n.sub[0], depth, maxDepth, sb)
+		}
+	case grammarRep:
+		for i := 0; depth < maxDepth && i < regexpMaxRepeat && r.Uint64()&1 == 1; i++ {
+			g.gen(r, n.sub[0], depth, maxDepth, sb)
+		}
+	}
+}
+
+// computeHeights iterates to a fixed point the minimum expansion height of every node
+func (g *grammar) computeHeights() error {
+	var visit func(n *grammarNode) int
+	visit = func(n *grammarNode) int {
+		switch n.op {
+		case grammarRef:
+			if h := g.rules[n.literal].height; h != math.MaxInt32 {
+				n.height = h + 1
+			}
+		case grammarSeq:
+			height := 0
+			for _, sub := range n.sub {
+				if h := visit(sub); h > height {
+					height = h
+				}
+			}
+			n.height = height
+		case grammarAlt:
+			height := math.MaxInt32
+			for _, sub := range n.sub {
+				if h := visit(sub); h < height {
+					height = h
+				}
+			}
+			n.height = height
+		case grammarOpt, grammarRep:
+			visit(n.sub[0])
+			n.height = 0
+		}
+		return n.height
+	}
+
+	for _, rule := range g.rules {
+		rule.height = math.MaxInt32
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, rule := range g.rules {
+			before := rule.height
+			if visit(rule) != before {
+				changed = true
+			}
+		}
+	}
+	for name, rule := range g.rules {
+		if rule.height == math.MaxInt32 {
+			return fmt.Errorf("grammar rule %q can never terminate", name)
+		}
+	}
+	return nil
+}
+
+type grammarParser struct {
+	src  string
+	pos  int
+	refs []string
+}
+
+func parseGrammar(src string) (*grammar, error) {
+	p := &grammarParser{src: src}
+	g := &grammar{rules: map[string]*grammarNode{}}
+	for p.skipSpace(); p.pos < len(p.src); p.skipSpace() {
+		name := p.ident()
+		if name == "" {
+			return nil, p.errorf("expected rule name")
+		}
+		if _, ok := g.rules[name]; ok {
+			return nil, p.errorf("rule %q defined twice", name)
+		}
+		if !p.accept("=") {
+			return nil, p.errorf("expected '=' after %q", name)
+		}
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(".") && !p.accept(";") {
+			return nil, p.errorf("expected '.' or ';' to end rule %q", name)
+		}
+		if g.start == "" {
+			g.start = name
+		}
+		g.rules[name] = n
+	}
+	if g.start == "" {
+		return nil, fmt.Errorf("grammar has no rules")
+	}
+	for _, ref := range p.refs {
+		if _, ok := g.rules[ref]; !ok {
+			return nil, fmt.Errorf("grammar rule %q is not defined", ref)
+		}
+	}
+	return g, g.computeHeights()
+}
+
+func (p *grammarParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("grammar offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *grammarParser) skipSpace() {
+	for p.pos < len(p.src) {
+		if strings.HasPrefix(p.src[p.pos:], "//") {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if !unicode.IsSpace(rune(p.src[p.pos])) {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *grammarParser) accept(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *grammarParser) ident() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := rune(p.src[p.pos])
+		if c != '_' && !unicode.IsLetter(c) && (p.pos == start || !unicode.IsDigit(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *grammarParser) expr() (*grammarNode, error) {
+	var alts []*grammarNode
+	for {
+		seq, err := p.seq()
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, seq)
+		if !p.accept("|") {
+			break
+		}
+	}
+	if len(alts) == 1 {
+		return alts[0], nil
+	}
+	return &grammarNode{op: grammarAlt, sub: alts}, nil
+}
+
+func (p *grammarParser) seq() (*grammarNode, error) {
+	n := &grammarNode{op: grammarSeq}
+	for {
+		f, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			return n, nil
+		}
+		n.sub = append(n.sub, f)
+	}
+}
+
+// factor returns nil, nil when there is no factor at the current position
+func (p *grammarParser) factor() (*grammarNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, nil
+	}
+	switch c := p.src[p.pos]; c {
+	case '"', '\'', '`', '/':
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != c {
+			if p.src[end] == '\\' && c != '`' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, p.errorf("unterminated %c", c)
+		}
+		raw := p.src[p.pos : end+1]
+		p.pos = end + 1
+		switch c {
+		case '/':
+			re, err := syntax.Parse(strings.ReplaceAll(raw[1:len(raw)-1], `\/`, `/`), syntax.Perl)
+			if err != nil {
+				return nil, err
+			}
+			return &grammarNode{op: grammarRegexp, re: re.Simplify()}, nil
+		case '"':
+			s, err := strconv.Unquote(raw)
+			if err != nil {
+				return nil, p.errorf("bad literal %s", raw)
+			}
+			return &grammarNode{op: grammarLiteral, literal: s}, nil
+		default:
+			return &grammarNode{op: grammarLiteral, literal: raw[1 : len(raw)-1]}, nil
+		}
+	case '(', '[', '{':
+		p.pos++
+		sub, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case c == '(' && p.accept(")"):
+			return sub, nil
+		case c == '[' && p.accept("]"):
+			return &grammarNode{op: grammarOpt, sub: []*grammarNode{sub}}, nil
+		case c == '{' && p.accept("}"):
+			return &grammarNode{op: grammarRep, sub: []*grammarNode{sub}}, nil
+		}
+		return nil, p.errorf("unbalanced '%c'", c)
+	}
+	name := p.ident()
+	if name == "" {
+		return nil, nil
+	}
+	p.refs = append(p.refs, name)
+	return &grammarNode{op: grammarRef, literal: name, height: math.MaxInt32}, nil
+}
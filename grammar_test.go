@@ -0,0 +1,65 @@
+package fastrand64
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testArithmeticGrammar = `
+	// tiny arithmetic language
+	expr   = term { ("+" | "-") term } .
+	term   = number | "(" expr ")" | '-' term .
+	number = /[1-9][0-9]{0,3}/ ;
+`
+
+func Test_SafeRNG_FromGrammar(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	gen, err := rng.FromGrammar(testArithmeticGrammar, 6)
+	assert.NoError(t, err)
+
+	tokens := regexp.MustCompile(`^[-+()0-9]+$`)
+	for i := 0; i < 512; i++ {
+		s := gen()
+		assert.Regexp(t, tokens, s)
+
+		depth := 0
+		for _, c := range s {
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			assert.GreaterOrEqual(t, depth, 0)
+		}
+		assert.Equal(t, 0, depth)
+	}
+}
+
+func Test_SafeRNG_FromGrammar_DepthLimit(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	gen, err := rng.FromGrammar(`list = "[" [ list { "," list } ] "]" .`, 0)
+	assert.NoError(t, err)
+	for i := 0; i < 64; i++ {
+		assert.Equal(t, "[]", gen())
+	}
+}
+
+func Test_SafeRNG_FromGrammar_Errors(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, rules := range []string{
+		``,
+		`a = b .`,
+		`a = a "x" .`,
+		`a = "x"`,
+		`a = ( "x" .`,
+		`a = "x" . a = "y" .`,
+		`a = /(/ .`,
+	} {
+		gen, err := rng.FromGrammar(rules, 4)
+		assert.Error(t, err, rules)
+		assert.Nil(t, gen)
+	}
+}
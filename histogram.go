@@ -0,0 +1,71 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// HistogramDist samples values consistent with a recorded histogram, uniformly within each bucket,
+// so its CDF is piecewise linear through the bucket edges.
+//
+// bucketBounds are the len(counts)+1 increasing finite edges of the buckets, and counts are the per
+// bucket counts. Prometheus style cumulative "le" buckets convert by differencing the counts, using
+// 0 (or a known minimum) as the first edge, and dropping or capping the +Inf bucket.
+type HistogramDist struct {
+	bounds []float64
+	cum    []float64 // cum[i] is the fraction of the total in buckets before i
+}
+
+// NewHistogramDist validates and copies the histogram
+func NewHistogramDist(bucketBounds []float64, counts []float64) (*HistogramDist, error) {
+	if len(counts) == 0 || len(bucketBounds) != len(counts)+1 {
+		return nil, errors.New("HistogramDist needs len(bucketBounds) == len(counts)+1 and at least one bucket")
+	}
+	for i, b := range bucketBounds {
+		if math.IsNaN(b) || math.IsInf(b, 0) {
+			return nil, errors.New("HistogramDist bucket bounds must be finite")
+		}
+		if i > 0 && !(b > bucketBounds[i-1]) {
+			return nil, errors.New("HistogramDist bucket bounds must be increasing")
+		}
+	}
+	d := &HistogramDist{bounds: append([]float64(nil), bucketBounds...), cum: make([]float64, len(counts)+1)}
+	for i, c := range counts {
+		if !(c >= 0) || math.IsInf(c, 1) {
+			return nil, errors.New("HistogramDist counts must be finite and >= 0")
+		}
+		d.cum[i+1] = d.cum[i] + c
+	}
+	total := d.cum[len(counts)]
+	if !(total > 0) {
+		return nil, errors.New("HistogramDist needs a positive total count")
+	}
+	for i := range d.cum {
+		d.cum[i] /= total
+	}
+	d.cum[len(counts)] = 1
+	return d, nil
+}
+
+// Quantile returns the inverse of the piecewise linear CDF
+func (d *HistogramDist) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	// first bucket whose upper cumulative fraction reaches p, skipping empty buckets
+	i := sort.SearchFloat64s(d.cum[1:], p)
+	for i < len(d.cum)-2 && d.cum[i+1] == d.cum[i] {
+		i++
+	}
+	lo, hi := d.cum[i], d.cum[i+1]
+	if hi == lo {
+		return d.bounds[i]
+	}
+	return d.bounds[i] + (p-lo)/(hi-lo)*(d.bounds[i+1]-d.bounds[i])
+}
+
+// Sample draws by inversion
+func (d *HistogramDist) Sample(r UnsafeRNG) float64 {
+	return d.Quantile(unitFloat64(r))
+}
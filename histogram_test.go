@@ -0,0 +1,54 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HistogramDist(t *testing.T) {
+	// latency buckets in ms, the middle one is empty
+	d, err := NewHistogramDist([]float64{0, 10, 50, 100, 500}, []float64{50, 30, 0, 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, d.Quantile(0))
+	assert.Equal(t, 5.0, d.Quantile(0.25))
+	assert.Equal(t, 10.0, d.Quantile(0.5))
+	assert.InDelta(t, 30, d.Quantile(0.65), 1e-9)
+	// the CDF is flat across the empty bucket, the quantile is its smallest x
+	assert.Equal(t, 50.0, d.Quantile(0.8))
+	assert.InDelta(t, 300, d.Quantile(0.9), 1e-9)
+	assert.Equal(t, 500.0, d.Quantile(1))
+
+	xs := NewSyncPoolXoshiro256ssRNG().FillDist(d, make([]float64, 100000))
+	buckets := make([]int, 4)
+	for _, x := range xs {
+		switch {
+		case x < 10:
+			buckets[0]++
+		case x < 50:
+			buckets[1]++
+		case x < 100:
+			buckets[2]++
+		default:
+			buckets[3]++
+		}
+	}
+	assert.InDelta(t, 50000, buckets[0], 800)
+	assert.InDelta(t, 30000, buckets[1], 800)
+	assert.Equal(t, 0, buckets[2])
+	assert.InDelta(t, 20000, buckets[3], 800)
+}
+
+func Test_HistogramDist_Errors(t *testing.T) {
+	for _, c := range []struct{ bounds, counts []float64 }{
+		{[]float64{0, 1}, nil},
+		{[]float64{0, 1}, []float64{1, 2}},
+		{[]float64{0, 0}, []float64{1}},
+		{[]float64{0, 1}, []float64{-1}},
+		{[]float64{0, 1}, []float64{0}},
+	} {
+		d, err := NewHistogramDist(c.bounds, c.counts)
+		assert.Error(t, err, c)
+		assert.Nil(t, d)
+	}
+}
@@ -0,0 +1,160 @@
+package fastrand64
+
+import "encoding/binary"
+
+// UnsafeIsaac64RNG is Bob Jenkins' ISAAC64, a generator with 16k bits of state and a long record
+// of resisting cryptanalysis, for when a large security margin matters but a stream cipher is too
+// slow. It makes 256 words a block, and Read copies whole blocks straight out, so filling buffers
+// is much faster than Bytes. It is about 4KB, so pool few of them.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See http://burtleburtle.net/bob/rand/isaacafa.html
+type UnsafeIsaac64RNG struct {
+	rsl        [isaac64Size]uint64 // the current block, consumed from the end like the reference rand()
+	mm         [isaac64Size]uint64
+	aa, bb, cc uint64
+	cnt        int // words of rsl not yet returned
+}
+
+const isaac64Size = 256
+
+// generate makes the next block of results, the reference isaac64()
+func (r *UnsafeIsaac64RNG) generate() {
+	mm := &r.mm
+	a := r.aa
+	r.cc++
+	b := r.bb + r.cc
+	for i := 0; i < isaac64Size; i++ {
+		switch i & 3 {
+		case 0:
+			a = ^(a ^ (a << 21))
+		case 1:
+			a ^= a >> 5
+		case 2:
+			a ^= a << 12
+		case 3:
+			a ^= a >> 33
+		}
+		x := mm[i]
+		a += mm[(i+isaac64Size/2)&(isaac64Size-1)]
+		y := mm[(x>>3)&(isaac64Size-1)] + a + b
+		mm[i] = y
+		b = mm[(y>>(8+3))&(isaac64Size-1)] + x
+		r.rsl[i] = b
+	}
+	r.bb = b
+	r.aa = a
+}
+
+// isaac64Mix is the reference mix() of the eight init words
+func isaac64Mix(s *[8]uint64) {
+	s[0] -= s[4]
+	s[5] ^= s[7] >> 9
+	s[7] += s[0]
+	s[1] -= s[5]
+	s[6] ^= s[0] << 9
+	s[0] += s[1]
+	s[2] -= s[6]
+	s[7] ^= s[1] >> 23
+	s[1] += s[2]
+	s[3] -= s[7]
+	s[0] ^= s[2] << 15
+	s[2] += s[3]
+	s[4] -= s[0]
+	s[1] ^= s[3] >> 14
+	s[3] += s[4]
+	s[5] -= s[1]
+	s[2] ^= s[4] << 20
+	s[4] += s[5]
+	s[6] -= s[2]
+	s[3] ^= s[5] >> 17
+	s[5] += s[6]
+	s[7] -= s[3]
+	s[4] ^= s[6] << 14
+	s[6] += s[7]
+}
+
+// SetState seeds the generator exactly like the reference randinit(ctx, TRUE) with randrsl holding
+// seed followed by zeros, seed can have at most 256 words
+func (r *UnsafeIsaac64RNG) SetState(seed []uint64) {
+	if len(seed) > isaac64Size {
+		panic("UnsafeIsaac64RNG seed can have at most 256 words")
+	}
+	r.rsl = [isaac64Size]uint64{}
+	copy(r.rsl[:], seed)
+	r.aa, r.bb, r.cc = 0, 0, 0
+
+	var s [8]uint64
+	for i := range s {
+		s[i] = 0x9e3779b97f4a7c13 // the golden ratio
+	}
+	for i := 0; i < 4; i++ {
+		isaac64Mix(&s)
+	}
+	for pass := 0; pass < 2; pass++ {
+		src := &r.rsl
+		if pass == 1 {
+			src = &r.mm
+		}
+		for i := 0; i < isaac64Size; i += 8 {
+			for j := range s {
+				s[j] += src[i+j]
+			}
+			isaac64Mix(&s)
+			copy(r.mm[i:i+8], s[:])
+		}
+	}
+	r.generate()
+	r.cnt = isaac64Size
+}
+
+// Seed seeds the generator with the single word seed, like SetState([]uint64{seed})
+func (r *UnsafeIsaac64RNG) Seed(seed int64) {
+	r.SetState([]uint64{uint64(seed)})
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeIsaac64RNG) Uint64() uint64 {
+	if r.cnt == 0 {
+		r.generate()
+		r.cnt = isaac64Size
+	}
+	r.cnt--
+	return r.rsl[r.cnt]
+}
+
+// Read fills p with exactly the bytes Bytes would, and leaves the generator in the same state,
+// copying whole words out of the block instead of calling Uint64 for each. It always returns
+// len(p), nil
+func (r *UnsafeIsaac64RNG) Read(p []byte) (int, error) {
+	i := 0
+	for len(p)-i >= 8 {
+		if r.cnt == 0 {
+			r.generate()
+			r.cnt = isaac64Size
+		}
+		for ; r.cnt > 0 && len(p)-i >= 8; i += 8 {
+			r.cnt--
+			binary.LittleEndian.PutUint64(p[i:], r.rsl[r.cnt])
+		}
+	}
+	// like Bytes, the tail always takes one more word
+	x := r.Uint64()
+	for ; i < len(p); i++ {
+		p[i] = byte(x)
+		x >>= 8
+	}
+	return len(p), nil
+}
+
+// fillBytes makes Bytes use Read, which gives the same bytes
+func (r *UnsafeIsaac64RNG) fillBytes(bytes []byte) {
+	r.Read(bytes)
+}
+
+// NewUnsafeIsaac64RNG creates a new Thread unsafe ISAAC64 generator
+func NewUnsafeIsaac64RNG(seed int64) *UnsafeIsaac64RNG {
+	noteSeed(seed)
+	r := &UnsafeIsaac64RNG{}
+	r.Seed(seed)
+	return r
+}
@@ -0,0 +1,49 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeIsaac64RNG_Uint64(t *testing.T) {
+	// randvect64.txt from Bob Jenkins' site, randinit(ctx, TRUE) on a zero ctx, then two more blocks
+	rng := &UnsafeIsaac64RNG{}
+	rng.SetState(nil)
+	rng.generate()
+	assert.Equal(t, []uint64{0x12a8f216af9418c2, 0xd4490ad526f14431, 0xb49c3b3995091a36, 0x5b45e522e4b1b4ef}, rng.rsl[:4])
+	assert.Equal(t, uint64(0x7f9b6af1ebf78baf), rng.rsl[255])
+	rng.generate()
+	assert.Equal(t, []uint64{0xd20d8c88c8ffe65f, 0x917f1dd5f8886c61, 0x56986e2ef3ed091b, 0x5fa7867caf35e149}, rng.rsl[:4])
+	assert.Equal(t, uint64(0x001f837cc7350524), rng.rsl[255])
+
+	// the reference rand() after randinit with randrsl[0] = 1
+	rng = NewUnsafeIsaac64RNG(1)
+	for _, x := range []uint64{0xe19ed5d2ca98af2d, 0xa7a18d07cab39b52, 0xa0ab0232d180af14, 0x72b36dcf1af5e46f} {
+		assert.Equal(t, x, rng.Uint64())
+	}
+
+	rng1 := NewUnsafeIsaac64RNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeIsaac64RNG(1) })
+	for i := 0; i < 600; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+
+	assert.Panics(t, func() { rng.SetState(make([]uint64, 257)) })
+}
+
+func Test_UnsafeIsaac64RNG_Read(t *testing.T) {
+	for _, n := range []int{0, 5, 8, 2048, 5000, 5003} {
+		rng1 := NewUnsafeIsaac64RNG(7)
+		rng2 := NewUnsafeIsaac64RNG(7)
+		rng1.Uint64()
+		rng2.Uint64()
+		p := make([]byte, n)
+		m, err := rng1.Read(p)
+		assert.NoError(t, err)
+		assert.Equal(t, n, m)
+		// the generic Bytes, through a wrapper that hides the fast path
+		assert.Equal(t, Bytes(struct{ UnsafeRNG }{rng2}, make([]byte, n)), p, n)
+		assert.Equal(t, rng2.Uint64(), rng1.Uint64(), n)
+	}
+}
@@ -0,0 +1,40 @@
+package fastrand64
+
+import "sync"
+
+// NewIsolatedPools makes n pool RNGs, eg one per tenant of a multi tenant service, whose streams
+// are guaranteed never to overlap, so no tenant's randomness is ever a replay of another's and each
+// can be audited for fairness on its own. They are xoshiro512** seeded with all 512 bits from
+// seedSeq, pool i starts i LongJumps (2^384 steps each) along that one sequence, and every
+// generator a pool allocates is a further Jump (2^256 steps) along its pool's part, like
+// NewSyncPoolXoshiro512ssRNG. The same seedSeq always gives the same pools.
+func NewIsolatedPools(n int, seedSeq SeedSeq) []*ThreadsafePoolRNG {
+	if n < 0 {
+		panic("NewIsolatedPools n must be >= 0")
+	}
+	base := &UnsafeXoshiro512ssRNG{}
+	seedSeq.GenerateState(base.s[:])
+	if base.s == ([8]uint64{}) {
+		// the all zero state is a fixed point
+		base.Seed(0)
+	}
+
+	pools := make([]*ThreadsafePoolRNG, n)
+	for i := range pools {
+		pools[i] = newJumpingPool(*base)
+		base.LongJump()
+	}
+	return pools
+}
+
+// newJumpingPool allocates copies of base, jumping it 2^256 steps after each
+func newJumpingPool(base UnsafeXoshiro512ssRNG) *ThreadsafePoolRNG {
+	var mu sync.Mutex
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		mu.Lock()
+		r := base
+		base.Jump()
+		mu.Unlock()
+		return &r
+	})
+}
@@ -0,0 +1,33 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewIsolatedPools(t *testing.T) {
+	seed := NewSeedSeq([]byte("tenants"))
+	pools := NewIsolatedPools(3, seed)
+	assert.Len(t, pools, 3)
+	assert.Empty(t, NewIsolatedPools(0, seed))
+	assert.Panics(t, func() { NewIsolatedPools(-1, seed) })
+
+	// pool i's first generator is i long jumps along the sequence seeded from seedSeq
+	base := &UnsafeXoshiro512ssRNG{}
+	seed.GenerateState(base.s[:])
+	for _, pool := range pools {
+		want := *base
+		for j := 0; j < 8; j++ {
+			assert.Equal(t, want.Uint64(), pool.Uint64())
+		}
+		base.LongJump()
+	}
+
+	// reproducible, and different seeds differ
+	again := NewIsolatedPools(3, seed)
+	other := NewIsolatedPools(3, NewSeedSeq([]byte("other")))
+	first := NewIsolatedPools(3, seed)[2].Uint64()
+	assert.Equal(t, first, again[2].Uint64())
+	assert.NotEqual(t, first, other[2].Uint64())
+}
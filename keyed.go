@@ -0,0 +1,59 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// HashKey reduces arbitrary key bytes to a well mixed 64bit value, FNV-1a followed by Splitmix64.
+// It is fast and stable across versions and platforms, but it is NOT a cryptographic hash.
+func HashKey(key []byte) uint64 {
+	h := uint64(14695981039346656037)
+	for _, b := range key {
+		h ^= uint64(b)
+		h *= 1099511628211
+	}
+	return Splitmix64(h)
+}
+
+// NewKeyedRNG creates a thread unsafe xoshiro256** generator deterministically seeded from key bytes,
+// so the same key always produces the same stream
+func NewKeyedRNG(key []byte) *UnsafeXoshiro256ssRNG {
+	return NewUnsafeXoshiro256ssRNG(int64(HashKey(key)))
+}
+
+// Splay returns base plus a stable per key offset in [0..window), so a fleet of hosts running the
+// same schedule spread out instead of thundering on the boundary. window <= 0 returns base.
+func Splay(base time.Time, window time.Duration, key []byte) time.Time {
+	if window <= 0 {
+		return base
+	}
+	offset, _ := bits.Mul64(HashKey(key), uint64(window))
+	return base.Add(time.Duration(offset))
+}
+
+// ShuffleShard deterministically assigns key a shard of shardSize distinct nodes out of totalNodes,
+// AWS style shuffle sharding. The result is sorted ascending and is stable for a given key.
+func ShuffleShard(key []byte, totalNodes int, shardSize int) []int {
+	if shardSize < 0 || shardSize > totalNodes {
+		panic("ShuffleShard shardSize must be in [0..totalNodes]")
+	}
+	return sampleDistinct(NewKeyedRNG(key), totalNodes, shardSize)
+}
+
+// sampleDistinct picks k distinct ints from [0..n) with Floyd's algorithm, sorted ascending
+func sampleDistinct(r UnsafeRNG, n int, k int) []int {
+	chosen := make(map[int]struct{}, k)
+	result := make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		t := intn(r, j+1)
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		result = append(result, t)
+	}
+	sort.Ints(result)
+	return result
+}
@@ -0,0 +1,73 @@
+package fastrand64
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HashKey(t *testing.T) {
+	assert.Equal(t, HashKey([]byte("tenant-1")), HashKey([]byte("tenant-1")))
+	assert.NotEqual(t, HashKey([]byte("tenant-1")), HashKey([]byte("tenant-2")))
+	assert.Equal(t, Splitmix64(14695981039346656037), HashKey(nil))
+}
+
+func Test_NewKeyedRNG(t *testing.T) {
+	r1 := NewKeyedRNG([]byte("a"))
+	r2 := NewKeyedRNG([]byte("a"))
+	r3 := NewKeyedRNG([]byte("b"))
+	for i := 0; i < 16; i++ {
+		x := r1.Uint64()
+		assert.Equal(t, x, r2.Uint64())
+		assert.NotEqual(t, x, r3.Uint64())
+	}
+}
+
+func Test_ShuffleShard(t *testing.T) {
+	shard := ShuffleShard([]byte("customer-42"), 100, 5)
+	assert.Equal(t, shard, ShuffleShard([]byte("customer-42"), 100, 5))
+	assert.Len(t, shard, 5)
+	for i, node := range shard {
+		assert.GreaterOrEqual(t, node, 0)
+		assert.Less(t, node, 100)
+		if i > 0 {
+			assert.Less(t, shard[i-1], node)
+		}
+	}
+
+	// with 100 nodes and shards of 5 there are ~75M possible shards, so identical shards should not happen
+	fullOverlaps := 0
+	for i := 0; i < 1000; i++ {
+		a := ShuffleShard([]byte(fmt.Sprintf("a%d", i)), 100, 5)
+		b := ShuffleShard([]byte(fmt.Sprintf("b%d", i)), 100, 5)
+		if assert.ObjectsAreEqual(a, b) {
+			fullOverlaps++
+		}
+	}
+	assert.Equal(t, 0, fullOverlaps)
+
+	assert.Equal(t, []int{0, 1, 2}, ShuffleShard([]byte("all"), 3, 3))
+	assert.Empty(t, ShuffleShard([]byte("none"), 3, 0))
+	assert.Panics(t, func() { ShuffleShard([]byte("x"), 3, 4) })
+}
+
+func Test_Splay(t *testing.T) {
+	base := time.Date(2020, 10, 8, 3, 0, 0, 0, time.UTC)
+	at := Splay(base, 10*time.Minute, []byte("host-1"))
+	assert.Equal(t, at, Splay(base, 10*time.Minute, []byte("host-1")))
+	assert.False(t, at.Before(base))
+	assert.True(t, at.Before(base.Add(10*time.Minute)))
+	assert.Equal(t, base, Splay(base, 0, []byte("host-1")))
+
+	// a fleet spreads evenly over the window
+	buckets := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		offset := Splay(base, 10*time.Minute, []byte(fmt.Sprintf("host-%d", i))).Sub(base)
+		buckets[int(offset/time.Minute)]++
+	}
+	for _, n := range buckets {
+		assert.InDelta(t, 1000, n, 150)
+	}
+}
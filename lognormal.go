@@ -0,0 +1,123 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// LogNormal is the distribution of exp(X) for X normal with mean mu and standard deviation sigma,
+// a common model for latencies and payload sizes
+type LogNormal struct {
+	mu, sigma float64
+}
+
+// NewLogNormal validates sigma > 0
+func NewLogNormal(mu float64, sigma float64) (*LogNormal, error) {
+	if math.IsNaN(mu) || math.IsInf(mu, 0) {
+		return nil, errors.New("LogNormal mu must be finite")
+	}
+	if !(sigma > 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("LogNormal sigma must be finite and > 0")
+	}
+	return &LogNormal{mu: mu, sigma: sigma}, nil
+}
+
+// Quantile returns the inverse CDF, exp(mu + sigma*z_p)
+func (d *LogNormal) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return math.Exp(d.mu + d.sigma*normalQuantile(p))
+}
+
+// Sample draws exp(mu + sigma*Z)
+func (d *LogNormal) Sample(r UnsafeRNG) float64 {
+	return math.Exp(d.mu + d.sigma*normal(r))
+}
+
+// Pareto is the Pareto type I distribution with minimum xm and tail index alpha, the mean is finite
+// only for alpha > 1 and the variance only for alpha > 2
+type Pareto struct {
+	xm, alpha float64
+}
+
+// NewPareto validates alpha > 0 and xm > 0, note the tail index comes first, like the pool's Pareto
+func NewPareto(alpha float64, xm float64) (*Pareto, error) {
+	if !(xm > 0) || math.IsInf(xm, 1) {
+		return nil, errors.New("Pareto xm must be finite and > 0")
+	}
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		return nil, errors.New("Pareto alpha must be finite and > 0")
+	}
+	return &Pareto{xm: xm, alpha: alpha}, nil
+}
+
+// Quantile returns the inverse CDF, xm*(1-p)^(-1/alpha)
+func (d *Pareto) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return d.xm * math.Pow(1-p, -1/d.alpha)
+}
+
+// Sample draws by inversion
+func (d *Pareto) Sample(r UnsafeRNG) float64 {
+	return d.Quantile(unitFloat64(r))
+}
+
+// Weibull is the Weibull distribution with shape k and scale lambda, the lifetime of a part whose
+// failure rate grows with age for k > 1, is constant for k = 1 and falls for k < 1
+type Weibull struct {
+	shape, scale float64
+}
+
+// NewWeibull validates shape > 0 and scale > 0
+func NewWeibull(shape float64, scale float64) (*Weibull, error) {
+	if !(shape > 0) || math.IsInf(shape, 1) {
+		return nil, errors.New("Weibull shape must be finite and > 0")
+	}
+	if !(scale > 0) || math.IsInf(scale, 1) {
+		return nil, errors.New("Weibull scale must be finite and > 0")
+	}
+	return &Weibull{shape: shape, scale: scale}, nil
+}
+
+// Quantile returns the inverse CDF, scale*(-ln(1-p))^(1/shape)
+func (d *Weibull) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return d.scale * math.Pow(-math.Log1p(-p), 1/d.shape)
+}
+
+// Sample draws scale*E^(1/shape) for E standard exponential
+func (d *Weibull) Sample(r UnsafeRNG) float64 {
+	return d.scale * math.Pow(expFloat64(r), 1/d.shape)
+}
+
+// LogNormal draws exp(mu + sigma*Z) using a single pool checkout, it panics unless mu is finite
+// and sigma is finite and > 0. Threadsafe
+func (s *ThreadsafePoolRNG) LogNormal(mu float64, sigma float64) float64 {
+	if math.IsNaN(mu) || math.IsInf(mu, 0) || !(sigma > 0) || math.IsInf(sigma, 1) {
+		panic("LogNormal mu must be finite and sigma finite and > 0")
+	}
+	return math.Exp(mu + sigma*s.NormFloat64())
+}
+
+// Weibull draws scale*E^(1/shape) using a single pool checkout, it panics unless shape and scale
+// are finite and > 0. Threadsafe
+func (s *ThreadsafePoolRNG) Weibull(shape float64, scale float64) float64 {
+	if !(shape > 0) || math.IsInf(shape, 1) || !(scale > 0) || math.IsInf(scale, 1) {
+		panic("Weibull shape and scale must be finite and > 0")
+	}
+	return scale * math.Pow(s.ExpFloat64(), 1/shape)
+}
+
+// Pareto draws xm*exp(E/alpha) with tail index alpha and minimum xm, the same order as NewPareto,
+// using a single pool checkout, it panics unless alpha and xm are finite and > 0. Threadsafe
+func (s *ThreadsafePoolRNG) Pareto(alpha float64, xm float64) float64 {
+	if !(alpha > 0) || math.IsInf(alpha, 1) || !(xm > 0) || math.IsInf(xm, 1) {
+		panic("Pareto alpha and xm must be finite and > 0")
+	}
+	return xm * math.Exp(s.ExpFloat64()/alpha)
+}
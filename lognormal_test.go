@@ -0,0 +1,71 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Weibull(t *testing.T) {
+	rng, err := NewSyncPoolSeededRNG("xoshiro256ss", NewSeedSeq([]byte("weibull")))
+	assert.NoError(t, err)
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for _, c := range []struct{ shape, scale float64 }{{0.5, 1}, {1, 2}, {1.5, 2}, {5, 10}} {
+		d, err := NewWeibull(c.shape, c.scale)
+		assert.NoError(t, err)
+		g1, g2 := math.Gamma(1+1/c.shape), math.Gamma(1+2/c.shape)
+		xs := make([]float64, 200000)
+		for i := range xs {
+			xs[i] = d.Sample(r)
+		}
+		mean, variance := meanAndVariance(xs)
+		assert.InEpsilon(t, c.scale*g1, mean, 0.02, c)
+		assert.InEpsilon(t, c.scale*c.scale*(g2-g1*g1), variance, 0.06, c)
+
+		pooled := make([]float64, 200000)
+		for i := range pooled {
+			pooled[i] = rng.Weibull(c.shape, c.scale)
+		}
+		mean, _ = meanAndVariance(pooled)
+		assert.InEpsilon(t, c.scale*g1, mean, 0.02, c)
+	}
+
+	// shape 1 is the exponential
+	d, _ := NewWeibull(1, 2)
+	assert.InDelta(t, -2*math.Log(0.5), d.Quantile(0.5), 1e-12)
+
+	for _, params := range [][2]float64{{0, 1}, {1, 0}, {math.Inf(1), 1}, {1, math.NaN()}} {
+		_, err := NewWeibull(params[0], params[1])
+		assert.Error(t, err)
+		assert.Panics(t, func() { rng.Weibull(params[0], params[1]) }, params)
+		assert.Panics(t, func() { rng.Pareto(params[0], params[1]) }, params)
+	}
+	for _, params := range [][2]float64{{0, 0}, {math.NaN(), 1}, {math.Inf(-1), 1}, {0, math.Inf(1)}} {
+		assert.Panics(t, func() { rng.LogNormal(params[0], params[1]) }, params)
+	}
+}
+
+func Test_ThreadsafePoolRNG_LogNormal_Pareto(t *testing.T) {
+	rng, err := NewSyncPoolSeededRNG("xoshiro256ss", NewSeedSeq([]byte("lognormal pareto")))
+	assert.NoError(t, err)
+	const n = 200000
+	logs, paretos := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		x := rng.LogNormal(1, 0.5)
+		assert.Greater(t, x, 0.0)
+		logs[i] = math.Log(x)
+		paretos[i] = rng.Pareto(3, 2)
+		assert.GreaterOrEqual(t, paretos[i], 2.0)
+	}
+	mean, variance := meanAndVariance(logs)
+	assert.InDelta(t, 1, mean, 5*0.5/math.Sqrt(n))
+	assert.InEpsilon(t, 0.25, variance, 0.02)
+
+	// the median of Pareto(alpha, xm) is xm*2^(1/alpha)
+	sort.Float64s(paretos)
+	assert.InEpsilon(t, 2*math.Pow(2, 1.0/3), paretos[n/2], 0.01)
+	mean, _ = meanAndVariance(paretos)
+	assert.InEpsilon(t, 3, mean, 0.02)
+}
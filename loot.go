@@ -0,0 +1,189 @@
+package fastrand64
+
+import "fmt"
+
+// LootTable is a drop table for games: Rolls weighted picks from Entries plus every Guaranteed
+// entry, where an entry is an item, a nested table rolled in turn, or nothing. Rolling draws only
+// from the generator given, so the same table, seed and pity state always drop the same items and
+// a server can validate a client's drops by rolling again with RollSeed.
+type LootTable struct {
+	Name       string // identifies the table's entries in LootPity, needed for pity entries
+	Rolls      int    // weighted picks from Entries per roll
+	Entries    []LootEntry
+	Guaranteed []LootEntry // dropped on every roll, Weight and Pity are ignored
+}
+
+// LootEntry is one row of a LootTable, it drops Item, or rolls Table, or with neither drops nothing
+type LootEntry struct {
+	Item   string
+	Table  *LootTable
+	Weight float64
+	// Min and Max are the inclusive range of Item's count, both 0 means 1
+	Min, Max int
+	// Pity > 0 guarantees the entry is picked at least once in every Pity picks from its table,
+	// counted in the LootPity passed to Roll, eg Pity: 90 for a rare drop
+	Pity int
+}
+
+// LootDrop is an item a roll dropped, and how many
+type LootDrop struct {
+	Item  string
+	Count int
+}
+
+// LootPity is one player's pity timers, the picks since each pity entry last dropped, keyed by
+// table name and entry. It is plain data so it can be stored with the player
+type LootPity map[string]int
+
+// Validate checks the weights, counts and pity settings, and that nested tables don't cycle
+func (t *LootTable) Validate() error {
+	return t.validate(map[*LootTable]bool{})
+}
+
+func (t *LootTable) validate(rolling map[*LootTable]bool) error {
+	if rolling[t] {
+		return fmt.Errorf("loot table %q contains itself", t.Name)
+	}
+	rolling[t] = true
+	defer delete(rolling, t)
+
+	if t.Rolls < 0 {
+		return fmt.Errorf("loot table %q rolls %d must be >= 0", t.Name, t.Rolls)
+	}
+	total := 0.0
+	for _, e := range t.Entries {
+		if !(e.Weight >= 0) {
+			return fmt.Errorf("loot table %q entry %q weight %v must be >= 0", t.Name, e.key(), e.Weight)
+		}
+		total += e.Weight
+		if e.Pity < 0 || (e.Pity > 0 && t.Name == "") {
+			return fmt.Errorf("loot table %q entry %q pity needs a named table and pity >= 0", t.Name, e.key())
+		}
+	}
+	if t.Rolls > 0 && total <= 0 {
+		return fmt.Errorf("loot table %q rolls entries with no weight", t.Name)
+	}
+	for _, entries := range [][]LootEntry{t.Entries, t.Guaranteed} {
+		for _, e := range entries {
+			if e.Min < 0 || e.Max < e.Min {
+				return fmt.Errorf("loot table %q entry %q count range [%d, %d] is invalid", t.Name, e.key(), e.Min, e.Max)
+			}
+			if e.Table != nil {
+				if e.Item != "" {
+					return fmt.Errorf("loot table %q entry %q has both an item and a table", t.Name, e.Item)
+				}
+				if err := e.Table.validate(rolling); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// key names the entry within its table
+func (e *LootEntry) key() string {
+	if e.Table != nil {
+		return "table:" + e.Table.Name
+	}
+	return e.Item
+}
+
+// Roll rolls the table with a thread unsafe RNG, appending the drops in the order they were
+// made. pity is updated in place, nil ignores the pity timers. The table must be valid
+func (t *LootTable) Roll(r UnsafeRNG, pity LootPity) []LootDrop {
+	return t.roll(r, pity, nil)
+}
+
+// RollSeed rolls the table with a generator seeded from seed, so the drops can be reproduced
+// exactly, eg seed from the player and their roll number, see SeedSeq.Named and Child
+func (t *LootTable) RollSeed(seed SeedSeq, pity LootPity) []LootDrop {
+	return t.Roll(seed.NewXoshiro256ssRNG(), pity)
+}
+
+// RollLoot rolls a loot table using a single pool checkout
+func (s *ThreadsafePoolRNG) RollLoot(t *LootTable, pity LootPity) []LootDrop {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	drops := t.Roll(r, pity)
+	rngPool.Put(r)
+	return drops
+}
+
+func (t *LootTable) roll(r UnsafeRNG, pity LootPity, drops []LootDrop) []LootDrop {
+	for i := range t.Guaranteed {
+		drops = t.Guaranteed[i].drop(r, pity, drops)
+	}
+	for n := 0; n < t.Rolls; n++ {
+		e := t.pick(r, pity)
+		drops = e.drop(r, pity, drops)
+	}
+	return drops
+}
+
+// pick chooses an entry by weight, unless a pity timer has run out, and advances the timers
+func (t *LootTable) pick(r UnsafeRNG, pity LootPity) *LootEntry {
+	picked := -1
+	if pity != nil {
+		for i := range t.Entries {
+			if e := &t.Entries[i]; e.Pity > 0 && pity[t.pityKey(e)] >= e.Pity-1 {
+				picked = i
+				break
+			}
+		}
+	}
+	if picked < 0 {
+		total := 0.0
+		for i := range t.Entries {
+			total += t.Entries[i].Weight
+		}
+		u := unitFloat64(r) * total
+		for i := range t.Entries {
+			if u < t.Entries[i].Weight {
+				picked = i
+				break
+			}
+			u -= t.Entries[i].Weight
+		}
+		if picked < 0 {
+			// rounding ran past the end, take the last entry that can be picked
+			for picked = len(t.Entries) - 1; t.Entries[picked].Weight == 0; picked-- {
+			}
+		}
+	}
+
+	if pity != nil {
+		for i := range t.Entries {
+			if e := &t.Entries[i]; e.Pity > 0 {
+				if i == picked {
+					delete(pity, t.pityKey(e))
+				} else {
+					pity[t.pityKey(e)]++
+				}
+			}
+		}
+	}
+	return &t.Entries[picked]
+}
+
+func (t *LootTable) pityKey(e *LootEntry) string {
+	return t.Name + "/" + e.key()
+}
+
+// drop appends what the entry drops
+func (e *LootEntry) drop(r UnsafeRNG, pity LootPity, drops []LootDrop) []LootDrop {
+	if e.Table != nil {
+		return e.Table.roll(r, pity, drops)
+	}
+	if e.Item == "" {
+		return drops
+	}
+	count := 1
+	if e.Max > 0 {
+		count = e.Min + intn(r, e.Max-e.Min+1)
+	}
+	if count == 0 {
+		return drops
+	}
+	return append(drops, LootDrop{Item: e.Item, Count: count})
+}
@@ -0,0 +1,114 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLootTable() *LootTable {
+	gems := &LootTable{Name: "gems", Rolls: 1, Entries: []LootEntry{
+		{Item: "ruby", Weight: 1},
+		{Item: "emerald", Weight: 3},
+	}}
+	return &LootTable{
+		Name:  "chest",
+		Rolls: 2,
+		Entries: []LootEntry{
+			{Weight: 50}, // nothing
+			{Item: "potion", Weight: 35, Min: 1, Max: 3},
+			{Table: gems, Weight: 14},
+			{Item: "legendary", Weight: 1, Pity: 40},
+		},
+		Guaranteed: []LootEntry{{Item: "gold", Min: 10, Max: 20}},
+	}
+}
+
+func Test_LootTable_Roll(t *testing.T) {
+	table := testLootTable()
+	assert.NoError(t, table.Validate())
+
+	counts := map[string]int{}
+	rolls := 100000
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < rolls; i++ {
+		drops := table.Roll(r, nil)
+		assert.Equal(t, "gold", drops[0].Item)
+		assert.True(t, drops[0].Count >= 10 && drops[0].Count <= 20)
+		for _, d := range drops[1:] {
+			counts[d.Item]++
+			if d.Item == "potion" {
+				assert.True(t, d.Count >= 1 && d.Count <= 3)
+			} else {
+				assert.Equal(t, 1, d.Count)
+			}
+		}
+	}
+	// 2 picks per roll, nested tables roll in turn
+	for item, p := range map[string]float64{"potion": 0.35, "ruby": 0.14 / 4, "emerald": 0.14 * 3 / 4, "legendary": 0.01} {
+		expected := 2 * float64(rolls) * p
+		assert.InDelta(t, expected, float64(counts[item]), 5*math.Sqrt(expected), item)
+	}
+
+	// the same seed and pity state drop the same items
+	seed := NewSeedSeq([]byte("player-7")).Child(3)
+	assert.Equal(t, table.RollSeed(seed, LootPity{}), table.RollSeed(seed, LootPity{}))
+	assert.NotEmpty(t, NewSyncPoolXoshiro256ssRNG().RollLoot(table, nil))
+}
+
+func Test_LootTable_Pity(t *testing.T) {
+	table := &LootTable{Name: "banner", Rolls: 1, Entries: []LootEntry{
+		{Item: "common", Weight: 99},
+		{Item: "legendary", Weight: 1, Pity: 40},
+	}}
+	pity := LootPity{}
+	r := NewUnsafeXoshiro256ssRNG(1)
+	since, longest, legendaries := 0, 0, 0
+	for i := 0; i < 20000; i++ {
+		since++
+		if table.Roll(r, pity)[0].Item == "legendary" {
+			legendaries++
+			if since > longest {
+				longest = since
+			}
+			since = 0
+		}
+		assert.Equal(t, since, pity["banner/legendary"])
+	}
+	assert.Equal(t, 40, longest)
+	// well above the 1% weight alone
+	assert.Greater(t, legendaries, 2*20000/100)
+
+	// without the timers the weight is all there is
+	since, longest = 0, 0
+	for i := 0; i < 20000; i++ {
+		since++
+		if table.Roll(r, nil)[0].Item == "legendary" {
+			if since > longest {
+				longest = since
+			}
+			since = 0
+		}
+	}
+	assert.Greater(t, longest, 40)
+}
+
+func Test_LootTable_Validate(t *testing.T) {
+	loop := &LootTable{Name: "loop", Rolls: 1}
+	loop.Entries = []LootEntry{{Table: loop, Weight: 1}}
+	for _, table := range []*LootTable{
+		loop,
+		{Rolls: -1},
+		{Rolls: 1},
+		{Rolls: 1, Entries: []LootEntry{{Item: "a", Weight: -1}}},
+		{Rolls: 1, Entries: []LootEntry{{Item: "a", Weight: math.NaN()}}},
+		{Rolls: 1, Entries: []LootEntry{{Item: "a", Weight: 1, Pity: 10}}},
+		{Name: "t", Rolls: 1, Entries: []LootEntry{{Item: "a", Weight: 1, Pity: -1}}},
+		{Guaranteed: []LootEntry{{Item: "a", Min: 3, Max: 2}}},
+		{Guaranteed: []LootEntry{{Item: "a", Table: &LootTable{}}}},
+	} {
+		assert.Error(t, table.Validate(), table.Name)
+	}
+	assert.NoError(t, (&LootTable{}).Validate())
+}
@@ -0,0 +1,78 @@
+package fastrand64
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// Lottery deterministically picks a winner index from shared seed material: every participant gets
+// the ticket SHA-256(seed, participant) and the lowest ticket wins, so anyone holding the inputs can
+// verify the result and the order of participants doesn't matter. Returns -1 with no participants.
+//
+// This is for testnets and coordination tools, it is NOT consensus grade: whoever controls the seed
+// or can cheaply add participants can grind for a favorable outcome.
+func Lottery(participants [][]byte, seed []byte) int {
+	winner := -1
+	var best [sha256.Size]byte
+	for i, p := range participants {
+		ticket := lotteryTicket(seed, p)
+		if winner < 0 || lessBytes(ticket[:], best[:]) {
+			winner, best = i, ticket
+		}
+	}
+	return winner
+}
+
+// lotteryTicket length prefixes the seed so (seed, participant) pairs can't collide by shifting bytes
+func lotteryTicket(seed []byte, participant []byte) [sha256.Size]byte {
+	h := sha256.New()
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(seed)))
+	h.Write(n[:])
+	h.Write(seed)
+	h.Write(participant)
+	var ticket [sha256.Size]byte
+	h.Sum(ticket[:0])
+	return ticket
+}
+
+func lessBytes(a []byte, b []byte) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// SelectCommittee deterministically picks k distinct member indices weighted by stake without
+// replacement (Efraimidis-Spirakis: each member gets key log(u)/stake from a generator keyed by seed,
+// highest keys win). The result is in priority order, so the first member can act as leader.
+// Zero stake members are never picked, so fewer than k are returned if fewer have stake.
+// Like Lottery, this is for simulators and research tooling, NOT consensus grade.
+func SelectCommittee(stakes []uint64, k int, seed []byte) []int {
+	r := NewKeyedRNG(seed)
+	type candidate struct {
+		index int
+		key   float64
+	}
+	candidates := make([]candidate, 0, len(stakes))
+	for i, stake := range stakes {
+		// always draw so a member's key doesn't depend on the stakes of earlier members
+		u := (float64(r.Uint64()>>11) + 0.5) * (1.0 / (1 << 53))
+		if stake > 0 {
+			candidates = append(candidates, candidate{i, math.Log(u) / float64(stake)})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool { return candidates[a].key > candidates[b].key })
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	committee := make([]int, k)
+	for i := range committee {
+		committee[i] = candidates[i].index
+	}
+	return committee
+}
@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Lottery(t *testing.T) {
+	participants := [][]byte{[]byte("alice"), []byte("bob"), []byte("carol"), []byte("dave")}
+	winner := Lottery(participants, []byte("round-1"))
+	assert.Equal(t, winner, Lottery(participants, []byte("round-1")))
+
+	// the winner is the same participant whatever order they are listed in
+	reversed := [][]byte{participants[3], participants[2], participants[1], participants[0]}
+	assert.Equal(t, participants[winner], reversed[Lottery(reversed, []byte("round-1"))])
+
+	assert.Equal(t, -1, Lottery(nil, []byte("round-1")))
+	assert.Equal(t, 0, Lottery(participants[:1], nil))
+}
+
+func Test_Lottery_Fair(t *testing.T) {
+	participants := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	wins := make([]int, len(participants))
+	for round := 0; round < 8000; round++ {
+		wins[Lottery(participants, []byte(fmt.Sprintf("round-%d", round)))]++
+	}
+	for _, n := range wins {
+		assert.InDelta(t, 2000, n, 200)
+	}
+}
+
+func Test_SelectCommittee(t *testing.T) {
+	stakes := []uint64{100, 0, 300, 100, 500}
+	committee := SelectCommittee(stakes, 3, []byte("epoch-1"))
+	assert.Equal(t, committee, SelectCommittee(stakes, 3, []byte("epoch-1")))
+	assert.Len(t, committee, 3)
+	assert.NotContains(t, committee, 1)
+	seen := map[int]bool{}
+	for _, m := range committee {
+		assert.False(t, seen[m])
+		seen[m] = true
+	}
+	assert.ElementsMatch(t, []int{0, 2, 3, 4}, SelectCommittee(stakes, 10, []byte("epoch-1")))
+}
+
+func Test_SelectCommittee_Weighted(t *testing.T) {
+	// the leader is picked in proportion to stake
+	stakes := []uint64{1, 2, 3, 4}
+	leaders := make([]int, len(stakes))
+	for epoch := 0; epoch < 10000; epoch++ {
+		leaders[SelectCommittee(stakes, 2, []byte(fmt.Sprintf("epoch-%d", epoch)))[0]]++
+	}
+	for i, stake := range stakes {
+		assert.InDelta(t, float64(stake)/10, float64(leaders[i])/10000, 0.015)
+	}
+}
@@ -0,0 +1,84 @@
+package fastrand64
+
+import "sort"
+
+// RandomPairs pairs up the players [0..n) uniformly at random from a thread unsafe RNG, eg for
+// a tournament round. With odd n the one player not in any pair sits out.
+func RandomPairs(r UnsafeRNG, n int) [][2]int {
+	p := Perm(r, make([]int, n))
+	pairs := make([][2]int, n/2)
+	for i := range pairs {
+		pairs[i] = [2]int{p[2*i], p[2*i+1]}
+	}
+	return pairs
+}
+
+// RandomPairs pairs up the players [0..n) uniformly at random using a single pool checkout
+func (s *ThreadsafePoolRNG) RandomPairs(n int) [][2]int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	pairs := RandomPairs(r, n)
+	rngPool.Put(r)
+	return pairs
+}
+
+// SkillPairs pairs up players whose skills differ by at most band, at random, from a thread unsafe
+// RNG, eg a matchmaking queue. Players are visited in random order and each still unpaired one is
+// paired with a uniformly chosen unpaired player in its band, so no two unmatched players are
+// within band of each other. unmatched is in ascending order, eg to wait for the next round with
+// a wider band. It takes O(n log n) plus the number of players scanned in the bands.
+func SkillPairs(r UnsafeRNG, skills []float64, band float64) (pairs [][2]int, unmatched []int) {
+	n := len(skills)
+	bySkill := make([]int, n)
+	for i := range bySkill {
+		bySkill[i] = i
+	}
+	sort.SliceStable(bySkill, func(a, b int) bool { return skills[bySkill[a]] < skills[bySkill[b]] })
+	rank := make([]int, n)
+	for k, i := range bySkill {
+		rank[i] = k
+	}
+
+	paired := make([]bool, n)
+	for _, p := range Perm(r, make([]int, n)) {
+		if paired[p] {
+			continue
+		}
+		// reservoir sample one unpaired player from the band on both sides of p
+		partner, seen := -1, 0
+		consider := func(q int) {
+			if !paired[q] {
+				seen++
+				if intn(r, seen) == 0 {
+					partner = q
+				}
+			}
+		}
+		for k := rank[p] - 1; k >= 0 && skills[bySkill[k]] >= skills[p]-band; k-- {
+			consider(bySkill[k])
+		}
+		for k := rank[p] + 1; k < n && skills[bySkill[k]] <= skills[p]+band; k++ {
+			consider(bySkill[k])
+		}
+		if partner >= 0 {
+			paired[p], paired[partner] = true, true
+			pairs = append(pairs, [2]int{p, partner})
+		}
+	}
+
+	for i, ok := range paired {
+		if !ok {
+			unmatched = append(unmatched, i)
+		}
+	}
+	return pairs, unmatched
+}
+
+// SkillPairs pairs up players whose skills differ by at most band using a single pool checkout
+func (s *ThreadsafePoolRNG) SkillPairs(skills []float64, band float64) (pairs [][2]int, unmatched []int) {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	pairs, unmatched = SkillPairs(r, skills, band)
+	rngPool.Put(r)
+	return pairs, unmatched
+}
@@ -0,0 +1,87 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RandomPairs(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for _, n := range []int{0, 1, 2, 7, 10} {
+		pairs := RandomPairs(r, n)
+		assert.Len(t, pairs, n/2)
+		var players []int
+		for _, pair := range pairs {
+			players = append(players, pair[0], pair[1])
+		}
+		sort.Ints(players)
+		for i := 1; i < len(players); i++ {
+			assert.NotEqual(t, players[i-1], players[i])
+		}
+	}
+
+	// every partner is equally likely
+	n, rounds := 6, 50000
+	counts := make([]int, n)
+	for i := 0; i < rounds; i++ {
+		for _, pair := range RandomPairs(r, n) {
+			if pair[0] == 0 {
+				counts[pair[1]]++
+			} else if pair[1] == 0 {
+				counts[pair[0]]++
+			}
+		}
+	}
+	assert.Equal(t, 0, counts[0])
+	expected := float64(rounds) / float64(n-1)
+	for _, c := range counts[1:] {
+		assert.InDelta(t, expected, float64(c), 5*math.Sqrt(expected))
+	}
+
+	assert.Len(t, NewSyncPoolXoshiro256ssRNG().RandomPairs(9), 4)
+}
+
+func Test_SkillPairs(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	skills := make([]float64, 200)
+	for i := range skills {
+		skills[i] = 1500 + 300*normal(r)
+	}
+	band := 50.0
+	pairs, unmatched := SkillPairs(r, skills, band)
+	assert.Equal(t, len(skills), 2*len(pairs)+len(unmatched))
+	assert.True(t, sort.IntsAreSorted(unmatched))
+	seen := map[int]bool{}
+	for _, pair := range pairs {
+		assert.LessOrEqual(t, math.Abs(skills[pair[0]]-skills[pair[1]]), band)
+		assert.False(t, seen[pair[0]] || seen[pair[1]])
+		seen[pair[0]], seen[pair[1]] = true, true
+	}
+	// nobody left waiting could have been paired
+	for i, a := range unmatched {
+		for _, b := range unmatched[i+1:] {
+			assert.Greater(t, math.Abs(skills[a]-skills[b]), band)
+		}
+	}
+
+	// the pairing within a band is random
+	counts := map[[2]int]int{}
+	for i := 0; i < 3000; i++ {
+		pairs, _ := SkillPairs(r, []float64{1, 2, 3, 4, 100}, 10)
+		for _, pair := range pairs {
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			counts[pair]++
+		}
+	}
+	assert.Len(t, counts, 6)
+	assert.Zero(t, counts[[2]int{0, 4}])
+
+	pairs, unmatched = NewSyncPoolXoshiro256ssRNG().SkillPairs([]float64{0, 100, 200}, 10)
+	assert.Empty(t, pairs)
+	assert.Equal(t, []int{0, 1, 2}, unmatched)
+}
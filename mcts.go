@@ -0,0 +1,120 @@
+package fastrand64
+
+import "math"
+
+// RolloutRNGs branches n generators from parent in a single allocation, one per rollout of a
+// Monte Carlo tree search, so each rollout, or each worker running rollouts, draws its own
+// stream. Like Branch it is deterministic, the same parent state gives the same rollouts
+func RolloutRNGs(parent *UnsafeXoshiro256ssRNG, n int) []UnsafeXoshiro256ssRNG {
+	rngs := make([]UnsafeXoshiro256ssRNG, n)
+	for i := range rngs {
+		rngs[i].Seed(branchSeed(parent.Uint64()))
+	}
+	return rngs
+}
+
+// DirichletNoise fills dst with a draw from the symmetric Dirichlet(alpha) distribution, len(dst)
+// values that sum to 1, from a thread unsafe RNG. Small alpha concentrates the mass on a few
+// entries, eg AlphaZero's 0.3 for chess. It panics unless alpha is finite and > 0
+func DirichletNoise(r UnsafeRNG, dst []float64, alpha float64) []float64 {
+	if !(alpha > 0) || math.IsInf(alpha, 1) {
+		panic("DirichletNoise alpha must be finite and > 0")
+	}
+	total := 0.0
+	for i := range dst {
+		dst[i] = standardGamma(r, alpha)
+		total += dst[i]
+	}
+	if total == 0 {
+		// every gamma underflowed, which only tiny alpha does, and then the mass is on one entry
+		if len(dst) > 0 {
+			dst[intn(r, len(dst))] = 1
+		}
+		return dst
+	}
+	for i := range dst {
+		dst[i] /= total
+	}
+	return dst
+}
+
+// DirichletNoise fills dst with a symmetric Dirichlet(alpha) draw using a single pool checkout
+func (s *ThreadsafePoolRNG) DirichletNoise(dst []float64, alpha float64) []float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	DirichletNoise(r, dst, alpha)
+	rngPool.Put(r)
+	return dst
+}
+
+// AddDirichletNoise mixes Dirichlet(alpha) noise into the root priors of a search in place,
+// priors[i] = (1-epsilon)*priors[i] + epsilon*noise[i], so self play explores moves the policy
+// rates poorly, eg alpha 0.3 and epsilon 0.25
+func AddDirichletNoise(r UnsafeRNG, priors []float64, alpha float64, epsilon float64) []float64 {
+	noise := DirichletNoise(r, make([]float64, len(priors)), alpha)
+	for i := range priors {
+		priors[i] = (1-epsilon)*priors[i] + epsilon*noise[i]
+	}
+	return priors
+}
+
+// SampleTemperature picks a move index with probability proportional to weights[i]^(1/temperature),
+// eg the root visit counts after a search, from a thread unsafe RNG. Temperature 1 samples in
+// proportion to the weights, towards 0 it sharpens to the best move, and 0 picks the largest
+// weight, breaking ties at random. Weights must be >= 0, if they are all 0 any index may be
+// picked. An empty slice returns -1
+func SampleTemperature(r UnsafeRNG, weights []float64, temperature float64) int {
+	if len(weights) == 0 {
+		return -1
+	}
+	best := 0.0
+	for _, w := range weights {
+		if w > best {
+			best = w
+		}
+	}
+	if best == 0 {
+		return intn(r, len(weights))
+	}
+	if temperature <= 0 {
+		// reservoir sample the ties for the largest
+		picked, ties := -1, 0
+		for i, w := range weights {
+			if w == best {
+				if ties++; intn(r, ties) == 0 {
+					picked = i
+				}
+			}
+		}
+		return picked
+	}
+	// (w/best)^(1/temperature) in [0, 1], so low temperatures can't overflow
+	inv := 1 / temperature
+	total := 0.0
+	for _, w := range weights {
+		total += math.Pow(w/best, inv)
+	}
+	u := unitFloat64(r) * total
+	picked := -1
+	for i, w := range weights {
+		p := math.Pow(w/best, inv)
+		if p > 0 {
+			picked = i
+		}
+		if u < p {
+			return i
+		}
+		u -= p
+	}
+	// rounding ran past the end, take the last index that can be picked
+	return picked
+}
+
+// SampleTemperature picks a move index by temperature using a single pool checkout
+func (s *ThreadsafePoolRNG) SampleTemperature(weights []float64, temperature float64) int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	i := SampleTemperature(r, weights, temperature)
+	rngPool.Put(r)
+	return i
+}
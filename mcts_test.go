@@ -0,0 +1,98 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RolloutRNGs(t *testing.T) {
+	rngs := RolloutRNGs(NewUnsafeXoshiro256ssRNG(1), 64)
+	again := RolloutRNGs(NewUnsafeXoshiro256ssRNG(1), 64)
+	assert.Len(t, rngs, 64)
+	seen := map[uint64]bool{}
+	for i := range rngs {
+		x := rngs[i].Uint64()
+		assert.Equal(t, again[i].Uint64(), x)
+		seen[x] = true
+	}
+	assert.Len(t, seen, 64)
+
+	// the same streams as Branch
+	parent := NewUnsafeXoshiro256ssRNG(1)
+	assert.Equal(t, parent.Branch().Uint64(), RolloutRNGs(NewUnsafeXoshiro256ssRNG(1), 1)[0].Uint64())
+}
+
+func Test_DirichletNoise(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(2)
+	const k, alpha, n = 4, 0.3, 50000
+	var firsts []float64
+	for i := 0; i < n; i++ {
+		noise := DirichletNoise(r, make([]float64, k), alpha)
+		sum := 0.0
+		for _, x := range noise {
+			assert.True(t, x >= 0 && x <= 1, x)
+			sum += x
+		}
+		assert.InDelta(t, 1, sum, 1e-12)
+		firsts = append(firsts, noise[0])
+	}
+	// each entry is Beta(alpha, (k-1)*alpha)
+	a, b := alpha, (k-1)*alpha
+	expectedVariance := a * b / ((a + b) * (a + b) * (a + b + 1))
+	mean, variance := meanAndVariance(firsts)
+	assert.InDelta(t, 1.0/k, mean, 5*math.Sqrt(expectedVariance/n))
+	assert.InEpsilon(t, expectedVariance, variance, 0.05)
+
+	// alpha so small every gamma underflows still sums to 1
+	tiny := DirichletNoise(r, make([]float64, 3), 1e-300)
+	assert.InDelta(t, 1, tiny[0]+tiny[1]+tiny[2], 1e-12)
+	assert.Empty(t, NewSyncPoolXoshiro256ssRNG().DirichletNoise(nil, 0.3))
+	for _, alpha := range []float64{0, -0.3, math.NaN(), math.Inf(1)} {
+		assert.Panics(t, func() { DirichletNoise(r, make([]float64, 3), alpha) }, alpha)
+		assert.Panics(t, func() { NewSyncPoolXoshiro256ssRNG().DirichletNoise(make([]float64, 3), alpha) }, alpha)
+		assert.Panics(t, func() { AddDirichletNoise(r, []float64{0.5, 0.5}, alpha, 0.25) }, alpha)
+	}
+
+	priors := AddDirichletNoise(r, []float64{0.5, 0.5, 0, 0}, 0.3, 0.25)
+	assert.InDelta(t, 1, priors[0]+priors[1]+priors[2]+priors[3], 1e-12)
+	assert.GreaterOrEqual(t, priors[0], 0.375)
+	assert.LessOrEqual(t, priors[2], 0.25)
+}
+
+func Test_SampleTemperature(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(3)
+	visits := []float64{10, 30, 0, 60}
+	for _, c := range []struct {
+		temperature float64
+		expected    []float64
+	}{
+		{1, []float64{0.1, 0.3, 0, 0.6}},
+		// squared visits, 100 : 900 : 3600
+		{0.5, []float64{100.0 / 4600, 900.0 / 4600, 0, 3600.0 / 4600}},
+		{0, []float64{0, 0, 0, 1}},
+		{1e-9, []float64{0, 0, 0, 1}},
+	} {
+		const n = 100000
+		counts := make([]float64, len(visits))
+		for i := 0; i < n; i++ {
+			counts[SampleTemperature(r, visits, c.temperature)]++
+		}
+		for i, p := range c.expected {
+			assert.InDelta(t, p, counts[i]/n, 5*math.Sqrt(p*(1-p)/n)+1e-9, c.temperature)
+		}
+	}
+
+	// ties at temperature 0 are broken at random
+	counts := make([]int, 3)
+	for i := 0; i < 30000; i++ {
+		counts[SampleTemperature(r, []float64{5, 1, 5}, 0)]++
+	}
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 15000, counts[0], 500)
+
+	assert.Equal(t, -1, SampleTemperature(r, nil, 1))
+	assert.Contains(t, []int{0, 1}, SampleTemperature(r, []float64{0, 0}, 1))
+	assert.Equal(t, 1, NewSyncPoolXoshiro256ssRNG().SampleTemperature([]float64{0, 2}, 1))
+}
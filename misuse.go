@@ -0,0 +1,123 @@
+package fastrand64
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Misuse is a suspicious seeding pattern found by the opt in misuse detector: a generator
+// constructed with a seed that looks like a clock reading, the same seed an earlier constructor
+// call got. The classic cause is seeding with time.Now().UnixNano() in a loop or from several
+// goroutines, where a coarse clock hands out the same tick and so the same stream many times.
+type Misuse struct {
+	Seed   int64
+	Count  int    // constructor calls with this seed so far, 2 at the first report
+	Clock  string // the units the seed looks like a time in, "ns", "us", "ms" or "s"
+	Caller string // file:line of the first call outside this package
+}
+
+func (m Misuse) String() string {
+	return fmt.Sprintf("fastrand64: %d generators seeded with the same clock reading %d (%s) at %s",
+		m.Count, m.Seed, m.Clock, m.Caller)
+}
+
+// misuseWindow is how many recent clock like seeds the detector remembers
+const misuseWindow = 1024
+
+// misuseClockSlack is how far from now a seed may be and still look like a clock reading
+const misuseClockSlack = 24 * time.Hour
+
+var (
+	misuseEnabled int32 // read atomically so a disabled detector costs the constructors one load
+	misuseMu      sync.Mutex
+	misuseHandler func(Misuse)
+	misuseSeen    map[int64]int
+	misuseRecent  []int64 // ring of the seeds in misuseSeen, oldest dropped first
+	misuseNext    int
+)
+
+// SetMisuseHandler turns on the misuse detector, handler is called synchronously from the
+// constructor that repeats a clock like seed, each time it repeats. The NewUnsafe...RNG(seed)
+// constructors and the NewSyncPool... convenience pools report their seeds; Seed methods and
+// fixed seeds, which repeat on purpose for reproducibility, are not flagged. A nil handler turns
+// the detector off and forgets the seeds seen. Threadsafe
+func SetMisuseHandler(handler func(Misuse)) {
+	misuseMu.Lock()
+	defer misuseMu.Unlock()
+	misuseHandler = handler
+	misuseSeen = map[int64]int{}
+	misuseRecent = misuseRecent[:0]
+	misuseNext = 0
+	if handler == nil {
+		misuseSeen = nil
+		atomic.StoreInt32(&misuseEnabled, 0)
+	} else {
+		atomic.StoreInt32(&misuseEnabled, 1)
+	}
+}
+
+// noteSeed records a constructor's seed for the misuse detector
+func noteSeed(seed int64) {
+	if atomic.LoadInt32(&misuseEnabled) == 0 {
+		return
+	}
+	clock := seedClock(seed, time.Now())
+	if clock == "" {
+		return
+	}
+
+	misuseMu.Lock()
+	handler := misuseHandler
+	if handler == nil {
+		misuseMu.Unlock()
+		return
+	}
+	count := misuseSeen[seed] + 1
+	if count == 1 {
+		if len(misuseRecent) < misuseWindow {
+			misuseRecent = append(misuseRecent, seed)
+		} else {
+			delete(misuseSeen, misuseRecent[misuseNext])
+			misuseRecent[misuseNext] = seed
+			misuseNext = (misuseNext + 1) % misuseWindow
+		}
+	}
+	misuseSeen[seed] = count
+	misuseMu.Unlock()
+
+	if count > 1 {
+		handler(Misuse{Seed: seed, Count: count, Clock: clock, Caller: misuseCaller()})
+	}
+}
+
+// seedClock returns the units seed is a time near now in, or "" if it isn't one
+func seedClock(seed int64, now time.Time) string {
+	for _, unit := range []struct {
+		name string
+		d    time.Duration
+	}{{"ns", time.Nanosecond}, {"us", time.Microsecond}, {"ms", time.Millisecond}, {"s", time.Second}} {
+		t := now.UnixNano() / int64(unit.d)
+		slack := int64(misuseClockSlack / unit.d)
+		if seed >= t-slack && seed <= t+slack {
+			return unit.name
+		}
+	}
+	return ""
+}
+
+// misuseCaller finds the first frame outside this package, or a test of it
+func misuseCaller() string {
+	pc := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/villenny/fastrand64-go.") ||
+			strings.HasSuffix(frame.File, "_test.go") || !more {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+	}
+}
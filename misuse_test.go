@@ -0,0 +1,69 @@
+package fastrand64
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetMisuseHandler(t *testing.T) {
+	var got []Misuse
+	SetMisuseHandler(func(m Misuse) { got = append(got, m) })
+	defer SetMisuseHandler(nil)
+
+	// the same tick handed to several constructors
+	seed := time.Now().UnixNano()
+	NewUnsafeXoshiro256ssRNG(seed)
+	assert.Empty(t, got)
+	NewUnsafePcg64DxsmRNG(seed)
+	NewUnsafeXoshiro256ssRNG(seed)
+	assert.Len(t, got, 2)
+	assert.Equal(t, seed, got[0].Seed)
+	assert.Equal(t, 2, got[0].Count)
+	assert.Equal(t, 3, got[1].Count)
+	assert.Equal(t, "ns", got[0].Clock)
+	assert.True(t, strings.Contains(got[0].Caller, "misuse_test.go:"), got[0].Caller)
+	assert.Contains(t, got[0].String(), "same clock reading")
+
+	// coarse clocks too, and through the registry
+	got = nil
+	secs := time.Now().Unix()
+	NewByName("pcg64dxsm", secs)
+	NewByName("pcg64dxsm", secs)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "s", got[0].Clock)
+
+	// fixed seeds repeat on purpose
+	got = nil
+	NewUnsafeXoshiro256ssRNG(1)
+	NewUnsafeXoshiro256ssRNG(1)
+	NewUnsafeIsaac64RNG(12345)
+	NewUnsafeIsaac64RNG(12345)
+	assert.Empty(t, got)
+
+	// off again, and the seeds are forgotten
+	SetMisuseHandler(nil)
+	NewUnsafeXoshiro256ssRNG(seed)
+	SetMisuseHandler(func(m Misuse) { got = append(got, m) })
+	NewUnsafeXoshiro256ssRNG(seed)
+	assert.Empty(t, got)
+}
+
+func Test_SetMisuseHandler_Window(t *testing.T) {
+	var got []Misuse
+	SetMisuseHandler(func(m Misuse) { got = append(got, m) })
+	defer SetMisuseHandler(nil)
+
+	seed := time.Now().UnixNano()
+	for i := 0; i <= misuseWindow; i++ {
+		NewUnsafeSplitmix64RNG(seed + int64(i))
+	}
+	assert.Len(t, misuseSeen, misuseWindow)
+	// the oldest was dropped, the newest is remembered
+	NewUnsafeSplitmix64RNG(seed)
+	assert.Empty(t, got)
+	NewUnsafeSplitmix64RNG(seed + misuseWindow)
+	assert.Len(t, got, 1)
+}
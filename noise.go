@@ -0,0 +1,58 @@
+package fastrand64
+
+import "math"
+
+// LaplaceNoise fills dst with Laplace(0, scale) noise from a thread unsafe RNG, for
+// differential privacy style experiments. This is NOT a cryptographically secure mechanism.
+func LaplaceNoise(r UnsafeRNG, dst []float64, scale float64) []float64 {
+	for i := range dst {
+		x := r.Uint64()
+		// exponential from the top 53 bits, random sign from the low bit
+		e := -scale * math.Log(1-float64(x>>11)*(1.0/(1<<53)))
+		if x&1 == 1 {
+			e = -e
+		}
+		dst[i] = e
+	}
+	return dst
+}
+
+// GaussianNoise fills dst with Normal(0, sigma) noise from a thread unsafe RNG, using the
+// Marsaglia polar method so that values are produced in pairs
+func GaussianNoise(r UnsafeRNG, dst []float64, sigma float64) []float64 {
+	for i := 0; i < len(dst); i += 2 {
+		var u, v, q float64
+		for {
+			u = 2*unitFloat64(r) - 1
+			v = 2*unitFloat64(r) - 1
+			q = u*u + v*v
+			if q > 0 && q < 1 {
+				break
+			}
+		}
+		f := sigma * math.Sqrt(-2*math.Log(q)/q)
+		dst[i] = u * f
+		if i+1 < len(dst) {
+			dst[i+1] = v * f
+		}
+	}
+	return dst
+}
+
+// LaplaceNoise fills dst with Laplace(0, scale) noise using a single pool checkout
+func (s *ThreadsafePoolRNG) LaplaceNoise(dst []float64, scale float64) []float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	LaplaceNoise(r, dst, scale)
+	rngPool.Put(r)
+	return dst
+}
+
+// GaussianNoise fills dst with Normal(0, sigma) noise using a single pool checkout
+func (s *ThreadsafePoolRNG) GaussianNoise(dst []float64, sigma float64) []float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	GaussianNoise(r, dst, sigma)
+	rngPool.Put(r)
+	return dst
+}
@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func meanAndVariance(xs []float64) (float64, float64) {
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	variance := 0.0
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean)
+	}
+	return mean, variance / float64(len(xs)-1)
+}
+
+func Test_SafeRNG_LaplaceNoise(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	xs := rng.LaplaceNoise(make([]float64, 100000), 2)
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 0, mean, 0.05)
+	assert.InDelta(t, 8, variance, 0.3)
+
+	// median absolute value of Laplace(0, b) is b*ln(2)
+	below := 0
+	for _, x := range xs {
+		if math.Abs(x) < 2*math.Ln2 {
+			below++
+		}
+	}
+	assert.InDelta(t, 0.5, float64(below)/float64(len(xs)), 0.01)
+}
+
+func Test_SafeRNG_GaussianNoise(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	xs := rng.GaussianNoise(make([]float64, 100001), 3)
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 0, mean, 0.05)
+	assert.InDelta(t, 9, variance, 0.2)
+	assert.NotEqual(t, 0.0, xs[len(xs)-1])
+}
+
+func Benchmark_SyncPoolGaussianNoise_1024(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	xs := make([]float64, 1024)
+	for i := 0; i < b.N; i++ {
+		rng.GaussianNoise(xs, 1)
+	}
+	BenchSink = &xs
+}
@@ -0,0 +1,38 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormFloat64(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	n := 1000000
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = normFloat64(r)
+	}
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 0, mean, 5/math.Sqrt(float64(n)))
+	// the variance of a sample variance of normals is 2/n
+	assert.InDelta(t, 1, variance, 5*math.Sqrt(2/float64(n)))
+
+	// the CDF, through the rectangles, the wedges and both tails
+	for _, x := range []float64{-4, -zigguratNormR, -2, -1, -0.3, 0, 0.5, 1.5, 3, zigguratNormR, 4} {
+		p := 0.5 * math.Erfc(-x/math.Sqrt2)
+		below := 0
+		for _, v := range xs {
+			if v < x {
+				below++
+			}
+		}
+		assert.InDelta(t, p, float64(below)/float64(n), 5*math.Sqrt(p*(1-p)/float64(n))+1e-6, x)
+	}
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for i := 0; i < 1000; i++ {
+		assert.False(t, math.IsNaN(rng.NormFloat64()) || math.IsInf(NormFloat64(), 0))
+	}
+}
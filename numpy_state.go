@@ -0,0 +1,134 @@
+package fastrand64
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// NumPy keeps a bit generator's state as a dict, bit_generator.state, which can be set back to
+// continue the stream. The methods here read and write that dict as JSON, so a Go and a Python
+// stage of a pipeline can hand a generator back and forth mid-stream. In Python json.dumps(bg.state)
+// works for PCG64 and PCG64DXSM, for Philox convert its numpy arrays with .tolist() first.
+//
+// NumPy buffers half of a Uint64 for its 32 bit draws (has_uint32 and uinteger). Go generators
+// have no such buffer so it is dropped on import and exported empty, 64 bit draws continue identically.
+
+type numpyPcg64State struct {
+	BitGenerator string `json:"bit_generator"`
+	State        struct {
+		State json.Number `json:"state"`
+		Inc   json.Number `json:"inc"`
+	} `json:"state"`
+	HasUint32 int    `json:"has_uint32"`
+	Uinteger  uint64 `json:"uinteger"`
+}
+
+type numpyPhiloxState struct {
+	BitGenerator string `json:"bit_generator"`
+	State        struct {
+		Counter [4]uint64 `json:"counter"`
+		Key     [2]uint64 `json:"key"`
+	} `json:"state"`
+	Buffer    [4]uint64 `json:"buffer"`
+	BufferPos int       `json:"buffer_pos"`
+	HasUint32 int       `json:"has_uint32"`
+	Uinteger  uint64    `json:"uinteger"`
+}
+
+// MarshalNumPyState returns the state as NumPy's PCG64 state dict in JSON
+func (r *UnsafePcg64RNG) MarshalNumPyState() ([]byte, error) {
+	return marshalNumPyPcg64State("PCG64", r.hi, r.lo, r.incHi, r.incLo)
+}
+
+// UnmarshalNumPyState sets the state from NumPy's PCG64 state dict in JSON
+func (r *UnsafePcg64RNG) UnmarshalNumPyState(data []byte) (err error) {
+	r.hi, r.lo, r.incHi, r.incLo, err = unmarshalNumPyPcg64State("PCG64", data, r.hi, r.lo, r.incHi, r.incLo)
+	return err
+}
+
+// MarshalNumPyState returns the state as NumPy's PCG64DXSM state dict in JSON
+func (r *UnsafePcg64DxsmRNG) MarshalNumPyState() ([]byte, error) {
+	return marshalNumPyPcg64State("PCG64DXSM", r.hi, r.lo, r.incHi, r.incLo)
+}
+
+// UnmarshalNumPyState sets the state from NumPy's PCG64DXSM state dict in JSON
+func (r *UnsafePcg64DxsmRNG) UnmarshalNumPyState(data []byte) (err error) {
+	r.hi, r.lo, r.incHi, r.incLo, err = unmarshalNumPyPcg64State("PCG64DXSM", data, r.hi, r.lo, r.incHi, r.incLo)
+	return err
+}
+
+// MarshalNumPyState returns the state as NumPy's Philox state dict in JSON
+func (r *UnsafePhilox4x64RNG) MarshalNumPyState() ([]byte, error) {
+	var s numpyPhiloxState
+	s.BitGenerator = "Philox"
+	s.State.Counter, s.State.Key = r.ctr, r.key
+	s.Buffer, s.BufferPos = r.buffer, r.bufferPos
+	return json.Marshal(&s)
+}
+
+// UnmarshalNumPyState sets the state from NumPy's Philox state dict in JSON
+func (r *UnsafePhilox4x64RNG) UnmarshalNumPyState(data []byte) error {
+	var s numpyPhiloxState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s.BitGenerator != "Philox" {
+		return fmt.Errorf("numpy state is for %q, not Philox", s.BitGenerator)
+	}
+	if s.BufferPos < 0 || s.BufferPos > len(s.Buffer) {
+		return fmt.Errorf("numpy Philox buffer_pos %d out of range", s.BufferPos)
+	}
+	r.ctr, r.key = s.State.Counter, s.State.Key
+	r.buffer, r.bufferPos = s.Buffer, s.BufferPos
+	return nil
+}
+
+func marshalNumPyPcg64State(name string, hi, lo, incHi, incLo uint64) ([]byte, error) {
+	var s numpyPcg64State
+	s.BitGenerator = name
+	s.State.State = formatUint128(hi, lo)
+	s.State.Inc = formatUint128(incHi, incLo)
+	return json.Marshal(&s)
+}
+
+// unmarshalNumPyPcg64State returns the parsed state, or the unchanged state and an error
+func unmarshalNumPyPcg64State(name string, data []byte, hi, lo, incHi, incLo uint64) (uint64, uint64, uint64, uint64, error) {
+	var s numpyPcg64State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return hi, lo, incHi, incLo, err
+	}
+	if s.BitGenerator != name {
+		return hi, lo, incHi, incLo, fmt.Errorf("numpy state is for %q, not %s", s.BitGenerator, name)
+	}
+	newHi, newLo, err := parseUint128(s.State.State)
+	if err != nil {
+		return hi, lo, incHi, incLo, err
+	}
+	newIncHi, newIncLo, err := parseUint128(s.State.Inc)
+	if err != nil {
+		return hi, lo, incHi, incLo, err
+	}
+	if newIncLo&1 == 0 {
+		return hi, lo, incHi, incLo, fmt.Errorf("numpy %s inc must be odd", name)
+	}
+	return newHi, newLo, newIncHi, newIncLo, nil
+}
+
+// formatUint128 writes hi:lo as a decimal JSON number, like Python's json.dumps of an int
+func formatUint128(hi, lo uint64) json.Number {
+	x := new(big.Int).SetUint64(hi)
+	x.Lsh(x, 64)
+	x.Or(x, new(big.Int).SetUint64(lo))
+	return json.Number(x.String())
+}
+
+func parseUint128(n json.Number) (hi, lo uint64, err error) {
+	x, ok := new(big.Int).SetString(string(n), 10)
+	if !ok || x.Sign() < 0 || x.BitLen() > 128 {
+		return 0, 0, fmt.Errorf("numpy state %q is not a 128 bit unsigned integer", string(n))
+	}
+	lo = new(big.Int).And(x, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	hi = new(big.Int).Rsh(x, 64).Uint64()
+	return hi, lo, nil
+}
@@ -0,0 +1,66 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafePcg64RNG_NumPyState(t *testing.T) {
+	// the state pcg64_srandom_r(&rng, 42u, 54u) leaves, as NumPy prints it
+	state := `{"bit_generator": "PCG64", "state": {"state": 295316062460491129802283182632101823264, "inc": 109}, "has_uint32": 0, "uinteger": 0}`
+	rng := &UnsafePcg64RNG{}
+	assert.NoError(t, rng.UnmarshalNumPyState([]byte(state)))
+	assert.Equal(t, uint64(0x86b1da1d72062b68), rng.Uint64())
+
+	// round trip mid stream continues the same sequence
+	data, err := rng.MarshalNumPyState()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"bit_generator":"PCG64"`)
+	other := &UnsafePcg64RNG{}
+	assert.NoError(t, other.UnmarshalNumPyState(data))
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, rng.Uint64(), other.Uint64())
+	}
+
+	before := *other
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64DXSM", "state": {"state": 1, "inc": 1}}`)))
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64", "state": {"state": 1, "inc": 2}}`)))
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64", "state": {"state": -1, "inc": 1}}`)))
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64", "state": {"state": 340282366920938463463374607431768211456, "inc": 1}}`)))
+	assert.Equal(t, before, *other)
+}
+
+func Test_UnsafePcg64DxsmRNG_NumPyState(t *testing.T) {
+	rng := NewUnsafePcg64DxsmRNG(1)
+	rng.Uint64()
+	data, err := rng.MarshalNumPyState()
+	assert.NoError(t, err)
+	other := &UnsafePcg64DxsmRNG{}
+	assert.NoError(t, other.UnmarshalNumPyState(data))
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, rng.Uint64(), other.Uint64())
+	}
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64", "state": {"state": 1, "inc": 1}}`)))
+}
+
+func Test_UnsafePhilox4x64RNG_NumPyState(t *testing.T) {
+	// the largest counter wraps to zero on the next draw, giving the Random123 zero vector
+	state := `{"bit_generator": "Philox", "state": {"counter": [18446744073709551615, 18446744073709551615, 18446744073709551615, 18446744073709551615], "key": [0, 0]}, "buffer": [0, 0, 0, 0], "buffer_pos": 4, "has_uint32": 0, "uinteger": 0}`
+	rng := &UnsafePhilox4x64RNG{}
+	assert.NoError(t, rng.UnmarshalNumPyState([]byte(state)))
+	assert.Equal(t, uint64(0x16554d9eca36314c), rng.Uint64())
+
+	// part way through a buffer
+	data, err := rng.MarshalNumPyState()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"buffer_pos":1`)
+	other := &UnsafePhilox4x64RNG{}
+	assert.NoError(t, other.UnmarshalNumPyState(data))
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, rng.Uint64(), other.Uint64())
+	}
+
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "Philox", "buffer_pos": 5}`)))
+	assert.Error(t, other.UnmarshalNumPyState([]byte(`{"bit_generator": "PCG64"}`)))
+}
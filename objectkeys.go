@@ -0,0 +1,85 @@
+package fastrand64
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ObjectKeyOptions configures an ObjectKeyGenerator, keys look like
+// "<prefix>/<date partition>/<random suffix><extension>"
+type ObjectKeyOptions struct {
+	Seed int64
+	// Prefixes is the number of distinct top level prefixes, PrefixSkew > 1 picks them with that
+	// Zipf exponent so a few prefixes are hot, 0 is uniform
+	Prefixes   int
+	PrefixSkew float64
+	// Days > 0 adds a date partition uniform over [Start, Start+Days) formatted with DateLayout,
+	// which defaults to "2006/01/02"
+	Start      time.Time
+	Days       int
+	DateLayout string
+	// SuffixLength is the number of random hex characters, defaults to 16
+	SuffixLength int
+	Extension    string
+	// Cardinality > 0 bounds the number of distinct keys, so keys repeat like re-reads and overwrites
+	Cardinality uint64
+}
+
+// ObjectKeyGenerator produces realistic object storage key names, the same options (including Seed)
+// always produce the same sequence. It is NOT safe for concurrent use.
+type ObjectKeyGenerator struct {
+	opts     ObjectKeyOptions
+	r        *UnsafeXoshiro256ssRNG
+	prefixes []string
+	skew     *zipf
+}
+
+// NewObjectKeyGenerator returns a key generator, it panics if Prefixes < 1 or PrefixSkew is in (0..1]
+func NewObjectKeyGenerator(opts ObjectKeyOptions) *ObjectKeyGenerator {
+	if opts.Prefixes < 1 {
+		panic("ObjectKeyGenerator needs at least one prefix")
+	}
+	if opts.DateLayout == "" {
+		opts.DateLayout = "2006/01/02"
+	}
+	if opts.SuffixLength <= 0 {
+		opts.SuffixLength = 16
+	}
+	g := &ObjectKeyGenerator{opts: opts, r: NewUnsafeXoshiro256ssRNG(opts.Seed)}
+	if opts.PrefixSkew != 0 {
+		if g.skew = newZipf(opts.PrefixSkew, 1, uint64(opts.Prefixes-1)); g.skew == nil {
+			panic("ObjectKeyGenerator PrefixSkew must be > 1")
+		}
+	}
+	for i := 0; i < opts.Prefixes; i++ {
+		g.prefixes = append(g.prefixes, randomFileName(g.r, i))
+	}
+	return g
+}
+
+// Next returns the next object key
+func (g *ObjectKeyGenerator) Next() string {
+	var r UnsafeRNG = g.r
+	if g.opts.Cardinality > 0 {
+		// every field of a key is derived from its id, so ids map to stable keys
+		id := uint64n(g.r, g.opts.Cardinality)
+		r = NewUnsafeXoshiro256ssRNG(int64(Splitmix64(uint64(g.opts.Seed)) ^ id))
+	}
+
+	var sb strings.Builder
+	if g.skew != nil {
+		sb.WriteString(g.prefixes[g.skew.sample(r)])
+	} else {
+		sb.WriteString(g.prefixes[intn(r, len(g.prefixes))])
+	}
+	sb.WriteByte('/')
+	if g.opts.Days > 0 {
+		sb.WriteString(g.opts.Start.AddDate(0, 0, intn(r, g.opts.Days)).Format(g.opts.DateLayout))
+		sb.WriteByte('/')
+	}
+	suffix := make([]byte, (g.opts.SuffixLength+1)/2)
+	sb.WriteString(hex.EncodeToString(Bytes(r, suffix))[:g.opts.SuffixLength])
+	sb.WriteString(g.opts.Extension)
+	return sb.String()
+}
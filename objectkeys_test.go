@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObjectKeyGenerator(t *testing.T) {
+	opts := ObjectKeyOptions{
+		Seed:         3,
+		Prefixes:     20,
+		PrefixSkew:   1.5,
+		Start:        time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC),
+		Days:         3,
+		SuffixLength: 11,
+		Extension:    ".json",
+	}
+	g1, g2 := NewObjectKeyGenerator(opts), NewObjectKeyGenerator(opts)
+	format := regexp.MustCompile(`^[a-z]{4,12}-\d+/2020/(01/30|01/31|02/01)/[0-9a-f]{11}\.json$`)
+	prefixes := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		key := g1.Next()
+		assert.Equal(t, key, g2.Next())
+		assert.Regexp(t, format, key)
+		prefixes[strings.SplitN(key, "/", 2)[0]]++
+	}
+	assert.LessOrEqual(t, len(prefixes), 20)
+	// with skew 1.5 the hottest prefix takes roughly a third of keys
+	hottest := 0
+	for _, n := range prefixes {
+		if n > hottest {
+			hottest = n
+		}
+	}
+	assert.Greater(t, hottest, 2500)
+}
+
+func Test_ObjectKeyGenerator_Cardinality(t *testing.T) {
+	g := NewObjectKeyGenerator(ObjectKeyOptions{Seed: 1, Prefixes: 3, Cardinality: 50, Extension: ".bin"})
+	keys := map[string]bool{}
+	for i := 0; i < 5000; i++ {
+		key := g.Next()
+		assert.Regexp(t, `^[a-z]{4,12}-\d/[0-9a-f]{16}\.bin$`, key)
+		keys[key] = true
+	}
+	assert.Len(t, keys, 50)
+}
+
+func Test_ObjectKeyGenerator_BadOptions(t *testing.T) {
+	assert.Panics(t, func() { NewObjectKeyGenerator(ObjectKeyOptions{}) })
+	assert.Panics(t, func() { NewObjectKeyGenerator(ObjectKeyOptions{Prefixes: 2, PrefixSkew: 0.5}) })
+}
@@ -0,0 +1,50 @@
+package fastrand64
+
+import "math/bits"
+
+// OwenScrambler applies an independent random nested uniform (Owen) scramble to each dimension of a
+// base 2 digital net or sequence, such as Sobol points given as 32 bit fixed point fractions.
+// Scrambling keeps the stratification of the points, but makes each one uniformly distributed, so
+// the spread of estimates across replicates, each with its own scrambler, is an unbiased error
+// estimate for quasi Monte Carlo. Safe for concurrent use once created.
+type OwenScrambler struct {
+	seeds []uint32
+}
+
+// NewOwenScrambler draws the per dimension scramble seeds from a thread unsafe RNG
+func NewOwenScrambler(r UnsafeRNG, dims int) *OwenScrambler {
+	o := &OwenScrambler{seeds: make([]uint32, dims)}
+	for i := range o.seeds {
+		o.seeds[i] = uint32(r.Uint64() >> 32)
+	}
+	return o
+}
+
+// NewOwenScrambler creates a scrambler for dims dimensions, one replicate of a randomized QMC run
+func (s *ThreadsafePoolRNG) NewOwenScrambler(dims int) *OwenScrambler {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	o := NewOwenScrambler(r, dims)
+	rngPool.Put(r)
+	return o
+}
+
+// Scramble returns the scrambled coordinate x of dimension dim, a bijection on uint32 where each
+// output bit only depends on the input bits at least as significant
+// See: Burley, "Practical Hash-based Owen Scrambling"
+func (o *OwenScrambler) Scramble(dim int, x uint32) uint32 {
+	seed := o.seeds[dim]
+	// with the bits reversed, a hash whose bits only depend on lower bits is a nested scramble
+	x = bits.Reverse32(x)
+	x ^= x * 0x3d20adea
+	x += seed
+	x *= (seed >> 16) | 1
+	x ^= x * 0x05526c56
+	x ^= x * 0x53a22864
+	return bits.Reverse32(x)
+}
+
+// Float64 returns the scrambled coordinate as a float64 in [0..1)
+func (o *OwenScrambler) Float64(dim int, x uint32) float64 {
+	return float64(o.Scramble(dim, x)) * (1.0 / (1 << 32))
+}
@@ -0,0 +1,46 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OwenScrambler_Stratification(t *testing.T) {
+	o := NewSyncPoolXoshiro256ssRNG().NewOwenScrambler(2)
+	// the first 2^k points of the radical inverse (Sobol's first dimension) land one per stratum
+	// of width 2^-k, and scrambling must keep that
+	for k := uint(1); k <= 10; k++ {
+		seen := map[uint32]bool{}
+		for i := uint32(0); i < 1<<k; i++ {
+			seen[o.Scramble(1, bits.Reverse32(i))>>(32-k)] = true
+		}
+		assert.Len(t, seen, 1<<k)
+	}
+
+	// it is a bijection
+	seen := map[uint32]bool{}
+	for i := uint32(0); i < 1<<16; i++ {
+		seen[o.Scramble(0, i*65537)] = true
+	}
+	assert.Len(t, seen, 1<<16)
+}
+
+func Test_OwenScrambler_Replicates(t *testing.T) {
+	// integrate x^2 over [0, 1) with 64 scrambled radical inverse points, across replicates the
+	// estimates are unbiased and much tighter than plain Monte Carlo with the same budget
+	rng := NewSyncPoolXoshiro256ssRNG()
+	estimates := make([]float64, 200)
+	for i := range estimates {
+		o := rng.NewOwenScrambler(1)
+		for j := uint32(0); j < 64; j++ {
+			x := o.Float64(0, bits.Reverse32(j))
+			estimates[i] += x * x / 64
+		}
+	}
+	mean, variance := meanAndVariance(estimates)
+	assert.InDelta(t, 1.0/3, mean, 0.001)
+	// plain Monte Carlo variance is Var(X^2)/64 = (1/5 - 1/9)/64
+	assert.Less(t, variance*100, (1.0/5-1.0/9)/64)
+}
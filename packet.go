@@ -0,0 +1,118 @@
+package fastrand64
+
+import "encoding/binary"
+
+const (
+	ipv4HeaderLen = 20
+	tcpHeaderLen  = 20
+	udpHeaderLen  = 8
+	protocolTCP   = 6
+	protocolUDP   = 17
+)
+
+// tcpFlagSets are the flag combinations seen on real traffic: SYN, SYN|ACK, ACK, PSH|ACK, FIN|ACK, RST
+var tcpFlagSets = []byte{0x02, 0x12, 0x10, 0x18, 0x11, 0x04}
+
+// InternetChecksum returns the RFC 1071 ones complement checksum of b, seeded with a partial sum
+// (eg a pseudo header sum). A correctly checksummed header sums to 0.
+func InternetChecksum(b []byte, initial uint32) uint16 {
+	sum := initial
+	for ; len(b) >= 2; b = b[2:] {
+		sum += uint32(b[0])<<8 | uint32(b[1])
+	}
+	if len(b) == 1 {
+		sum += uint32(b[0]) << 8
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// RandomTCPPacket returns an IPv4 packet carrying a TCP segment with random unicast addresses,
+// ports, sequence numbers, a common flag combination and payloadLen random payload bytes.
+// Both checksums are valid.
+func RandomTCPPacket(r UnsafeRNG, payloadLen int) []byte {
+	p := make([]byte, ipv4HeaderLen+tcpHeaderLen+payloadLen)
+	randomIPv4Header(r, p, protocolTCP)
+	tcp := p[ipv4HeaderLen:]
+	x := r.Uint64()
+	binary.BigEndian.PutUint16(tcp[0:], uint16(x))
+	binary.BigEndian.PutUint16(tcp[2:], uint16(x>>16))
+	binary.BigEndian.PutUint16(tcp[14:], uint16(x>>32)) // window
+	binary.BigEndian.PutUint64(tcp[4:], r.Uint64())     // seq and ack
+	tcp[12] = (tcpHeaderLen / 4) << 4
+	tcp[13] = tcpFlagSets[intn(r, len(tcpFlagSets))]
+	Bytes(r, tcp[tcpHeaderLen:])
+	binary.BigEndian.PutUint16(tcp[16:], InternetChecksum(tcp, pseudoHeaderSum(p)))
+	return p
+}
+
+// RandomUDPPacket returns an IPv4 packet carrying a UDP datagram with random unicast addresses,
+// ports and payloadLen random payload bytes. Both checksums are valid.
+func RandomUDPPacket(r UnsafeRNG, payloadLen int) []byte {
+	p := make([]byte, ipv4HeaderLen+udpHeaderLen+payloadLen)
+	randomIPv4Header(r, p, protocolUDP)
+	udp := p[ipv4HeaderLen:]
+	x := r.Uint64()
+	binary.BigEndian.PutUint16(udp[0:], uint16(x))
+	binary.BigEndian.PutUint16(udp[2:], uint16(x>>16))
+	binary.BigEndian.PutUint16(udp[4:], uint16(len(udp)))
+	Bytes(r, udp[udpHeaderLen:])
+	checksum := InternetChecksum(udp, pseudoHeaderSum(p))
+	if checksum == 0 {
+		// zero means no checksum in UDP, so it is sent as all ones
+		checksum = 0xffff
+	}
+	binary.BigEndian.PutUint16(udp[6:], checksum)
+	return p
+}
+
+func randomIPv4Header(r UnsafeRNG, p []byte, protocol byte) {
+	x := r.Uint64()
+	p[0] = 0x45 // version 4, 5 word header
+	binary.BigEndian.PutUint16(p[2:], uint16(len(p)))
+	binary.BigEndian.PutUint16(p[4:], uint16(x)) // identification
+	p[6] = 0x40                                  // don't fragment
+	p[8] = byte(32 + intn(r, 256-32))            // ttl
+	p[9] = protocol
+	randomUnicastIPv4(r, p[12:16])
+	randomUnicastIPv4(r, p[16:20])
+	binary.BigEndian.PutUint16(p[10:], InternetChecksum(p[:ipv4HeaderLen], 0))
+}
+
+// randomUnicastIPv4 avoids 0/8, loopback 127/8 and the multicast and reserved space >= 224/8
+func randomUnicastIPv4(r UnsafeRNG, addr []byte) {
+	x := r.Uint64()
+	first := byte(1 + intn(r, 222))
+	if first >= 127 {
+		first++
+	}
+	addr[0], addr[1], addr[2], addr[3] = first, byte(x), byte(x>>8), byte(x>>16)
+}
+
+func pseudoHeaderSum(p []byte) uint32 {
+	sum := uint32(0)
+	for i := 12; i < 20; i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(p[i:]))
+	}
+	return sum + uint32(p[9]) + uint32(len(p)-ipv4HeaderLen)
+}
+
+// RandomTCPPacket returns a random valid IPv4/TCP packet using a single pool checkout
+func (s *ThreadsafePoolRNG) RandomTCPPacket(payloadLen int) []byte {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	p := RandomTCPPacket(r, payloadLen)
+	rngPool.Put(r)
+	return p
+}
+
+// RandomUDPPacket returns a random valid IPv4/UDP packet using a single pool checkout
+func (s *ThreadsafePoolRNG) RandomUDPPacket(payloadLen int) []byte {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	p := RandomUDPPacket(r, payloadLen)
+	rngPool.Put(r)
+	return p
+}
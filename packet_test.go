@@ -0,0 +1,59 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertValidIPv4(t *testing.T, p []byte, protocol byte) {
+	assert.Equal(t, byte(0x45), p[0])
+	assert.Equal(t, len(p), int(binary.BigEndian.Uint16(p[2:])))
+	assert.Equal(t, protocol, p[9])
+	assert.Equal(t, uint16(0), InternetChecksum(p[:ipv4HeaderLen], 0))
+	for _, first := range []byte{p[12], p[16]} {
+		assert.NotEqual(t, byte(0), first)
+		assert.NotEqual(t, byte(127), first)
+		assert.Less(t, first, byte(224))
+	}
+	// the transport checksum over the pseudo header and segment verifies to 0
+	assert.Equal(t, uint16(0), InternetChecksum(p[ipv4HeaderLen:], pseudoHeaderSum(p)))
+}
+
+func Test_InternetChecksum(t *testing.T) {
+	// RFC 1071 example bytes
+	b := []byte{0x00, 0x01, 0xf2, 0x03, 0xf4, 0xf5, 0xf6, 0xf7}
+	assert.Equal(t, ^uint16(0xddf2), InternetChecksum(b, 0))
+	assert.Equal(t, ^uint16(0x0100), InternetChecksum([]byte{0x01}, 0))
+}
+
+func Test_SafeRNG_RandomTCPPacket(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, n := range []int{0, 1, 7, 1400} {
+		p := rng.RandomTCPPacket(n)
+		assert.Len(t, p, ipv4HeaderLen+tcpHeaderLen+n)
+		assertValidIPv4(t, p, protocolTCP)
+		assert.Equal(t, byte(0x50), p[ipv4HeaderLen+12])
+		assert.Contains(t, tcpFlagSets, p[ipv4HeaderLen+13])
+	}
+}
+
+func Test_SafeRNG_RandomUDPPacket(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, n := range []int{0, 3, 512} {
+		p := rng.RandomUDPPacket(n)
+		assert.Len(t, p, ipv4HeaderLen+udpHeaderLen+n)
+		assertValidIPv4(t, p, protocolUDP)
+		assert.Equal(t, udpHeaderLen+n, int(binary.BigEndian.Uint16(p[ipv4HeaderLen+4:])))
+	}
+}
+
+func Benchmark_UnsafeRandomUDPPacket_64(b *testing.B) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	var p []byte
+	for i := 0; i < b.N; i++ {
+		p = RandomUDPPacket(r, 64)
+	}
+	BenchSink = &p
+}
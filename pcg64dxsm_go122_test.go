@@ -0,0 +1,26 @@
+//go:build go1.22
+// +build go1.22
+
+package fastrand64
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// math/rand/v2's PCG steps with the full 128 bit multiplier, not NumPy's cheap one, but its DXSM
+// output function is the same, an implementation independent of ours to check it against
+func Test_UnsafePcg64DxsmRNG_MathRandV2Output(t *testing.T) {
+	p := rand.NewPCG(0x123456789abcdef0, 0xfedcba9876543210)
+	for i := 0; i < 1000; i++ {
+		// PCG.Uint64 steps then outputs the new state, ours outputs the state then steps
+		x := p.Uint64()
+		data, err := p.MarshalBinary()
+		assert.NoError(t, err)
+		rng := &UnsafePcg64DxsmRNG{hi: binary.BigEndian.Uint64(data[4:]), lo: binary.BigEndian.Uint64(data[12:])}
+		assert.Equal(t, x, rng.Uint64())
+	}
+}
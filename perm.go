@@ -0,0 +1,22 @@
+package fastrand64
+
+// Perm fills p with a pseudorandom permutation of the ints [0..len(p)) from a thread unsafe RNG and
+// returns it, so the caller controls the allocation
+func Perm(r UnsafeRNG, p []int) []int {
+	// inside out Fisher-Yates, like math/rand
+	for i := range p {
+		j := intn(r, i+1)
+		p[i] = p[j]
+		p[j] = i
+	}
+	return p
+}
+
+// Perm returns a pseudorandom permutation of the ints [0..n) using a single pool checkout
+func (s *ThreadsafePoolRNG) Perm(n int) []int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	p := Perm(r, make([]int, n))
+	rngPool.Put(r)
+	return p
+}
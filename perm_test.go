@@ -0,0 +1,33 @@
+package fastrand64
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Perm(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	p := rng.Perm(100)
+	sorted := append([]int(nil), p...)
+	sort.Ints(sorted)
+	for i, x := range sorted {
+		assert.Equal(t, i, x)
+	}
+	assert.Len(t, rng.Perm(0), 0)
+
+	// every permutation of 3 is equally likely
+	counts := map[[3]int]int{}
+	r := NewUnsafeXoshiro256ssRNG(1)
+	p3 := make([]int, 3)
+	for i := 0; i < 60000; i++ {
+		Perm(r, p3)
+		counts[[3]int{p3[0], p3[1], p3[2]}]++
+	}
+	assert.Len(t, counts, 6)
+	for _, c := range counts {
+		// about 5 standard errors
+		assert.InDelta(t, 10000, c, 450)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build go1.21
+// +build go1.21
+
+// the go1.21 constraint raises this file's language version past the module's, for generics
+
+package fastrand64
+
+// pickBudget is how many random picks PickExcluding tries before it counts the allowed elements
+const pickBudget = 8
+
+// PickExcluding returns a uniformly chosen element of s that isn't in exclude, from the Default
+// RNG, and false if there is none, eg a worker for a job avoiding the ones that already failed it.
+// Threadsafe
+func PickExcluding[T comparable](s []T, exclude map[T]bool) (T, bool) {
+	rngPool := defaultRNG().pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x, ok := PickExcludingRNG(r, s, exclude)
+	rngPool.Put(r)
+	return x, ok
+}
+
+// PickExcludingRNG is PickExcluding with a thread unsafe RNG. It picks at random up to 8 times,
+// which finds an allowed element quickly unless most are excluded, and then counts the allowed
+// elements and picks one by index, so it takes O(len(s)) at worst, and never allocates
+func PickExcludingRNG[T comparable](r UnsafeRNG, s []T, exclude map[T]bool) (T, bool) {
+	var zero T
+	if len(s) == 0 {
+		return zero, false
+	}
+	if len(exclude) == 0 {
+		return s[intn(r, len(s))], true
+	}
+	// rejection picks uniformly from the allowed elements, and so does the fallback, so the mix does
+	for i := 0; i < pickBudget; i++ {
+		if x := s[intn(r, len(s))]; !exclude[x] {
+			return x, true
+		}
+	}
+
+	allowed := 0
+	for _, x := range s {
+		if !exclude[x] {
+			allowed++
+		}
+	}
+	if allowed == 0 {
+		return zero, false
+	}
+	k := intn(r, allowed)
+	for _, x := range s {
+		if !exclude[x] {
+			if k == 0 {
+				return x, true
+			}
+			k--
+		}
+	}
+	panic("unreachable")
+}
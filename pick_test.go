@@ -0,0 +1,60 @@
+//go:build go1.21
+// +build go1.21
+
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PickExcluding(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	_, ok := PickExcludingRNG(r, []int{}, nil)
+	assert.False(t, ok)
+	_, ok = PickExcludingRNG(r, []int{1, 2}, map[int]bool{1: true, 2: true})
+	assert.False(t, ok)
+	x, ok := PickExcludingRNG(r, []string{"a", "b"}, map[string]bool{"a": true, "z": true})
+	assert.True(t, ok)
+	assert.Equal(t, "b", x)
+
+	// uniform over the allowed elements, with few excluded (rejection) and with most excluded
+	// (rejection, then the counting fallback)
+	for _, excluded := range []int{3, 95} {
+		s := make([]int, 100)
+		exclude := map[int]bool{}
+		for i := range s {
+			s[i] = i
+			if i < excluded {
+				exclude[i] = true
+			}
+		}
+		n := 50000
+		counts := make([]int, len(s))
+		for i := 0; i < n; i++ {
+			x, ok := PickExcludingRNG(r, s, exclude)
+			assert.True(t, ok)
+			counts[x]++
+		}
+		expected := float64(n) / float64(len(s)-excluded)
+		for i, c := range counts {
+			if i < excluded {
+				assert.Equal(t, 0, c)
+			} else {
+				assert.InDelta(t, expected, float64(c), 5*math.Sqrt(expected), i)
+			}
+		}
+	}
+
+	x, ok = PickExcluding([]string{"a", "b", "c"}, map[string]bool{"a": true, "c": true})
+	assert.True(t, ok)
+	assert.Equal(t, "b", x)
+
+	s, exclude := []int{1, 2, 3, 4}, map[int]bool{1: true, 2: true, 3: true}
+	allocs := testing.AllocsPerRun(100, func() {
+		PickExcludingRNG(r, s, exclude)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
@@ -0,0 +1,74 @@
+package fastrand64
+
+import "math"
+
+// poissonKnuthMean is the mean below which Knuth's multiplication method, which takes about
+// lambda+1 uniforms, beats PTRS
+const poissonKnuthMean = 10
+
+// PoissonMaxMean is the largest mean Poisson accepts, 2^62, far enough below 2^64 that every draw
+// fits a uint64
+const PoissonMaxMean = 1 << 62
+
+// Poisson draws from Poisson(lambda), eg the number of requests in a second at lambda per second,
+// from a thread unsafe RNG: Knuth's method for small means and Hormann's PTRS transformed
+// rejection for large ones, so any lambda costs O(1) expected time. lambda <= 0 returns 0, it
+// panics if lambda is NaN or above PoissonMaxMean
+func Poisson(r UnsafeRNG, lambda float64) uint64 {
+	if math.IsNaN(lambda) || lambda > PoissonMaxMean {
+		panic("Poisson lambda must be a number <= PoissonMaxMean")
+	}
+	if lambda <= 0 {
+		return 0
+	}
+	if lambda < poissonKnuthMean {
+		return poissonKnuth(r, lambda)
+	}
+	return poissonPtrs(r, lambda)
+}
+
+// Poisson draws from Poisson(lambda) using a single pool checkout, see Poisson. Threadsafe
+func (s *ThreadsafePoolRNG) Poisson(lambda float64) uint64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := Poisson(r, lambda)
+	rngPool.Put(r)
+	return x
+}
+
+// poissonKnuth multiplies uniforms until the product drops below exp(-lambda)
+func poissonKnuth(r UnsafeRNG, lambda float64) uint64 {
+	limit := math.Exp(-lambda)
+	k := uint64(0)
+	for p := unitFloat64(r); p > limit; p *= unitFloat64(r) {
+		k++
+	}
+	return k
+}
+
+// poissonPtrs is PTRS for lambda >= 10, see Hormann: "The transformed rejection method for
+// generating Poisson random variables", Insurance: Mathematics and Economics 1993
+func poissonPtrs(r UnsafeRNG, lambda float64) uint64 {
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := unitFloat64(r) - 0.5
+		v := unitFloat64(r)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return uint64(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lgK, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lgK {
+			return uint64(k)
+		}
+	}
+}
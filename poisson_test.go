@@ -0,0 +1,53 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Poisson_Distribution(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(3)
+	draws := 200000
+	for _, lambda := range []float64{0.3, 4, 9.99, 10, 37.5, 2000} {
+		observed := map[uint64]float64{}
+		for i := 0; i < draws; i++ {
+			observed[Poisson(r, lambda)]++
+		}
+		// against the exact pmf, merging sparse bins
+		stat, df := 0.0, -1
+		expectedBin, observedBin := 0.0, 0.0
+		hi := uint64(lambda + 20*math.Sqrt(lambda) + 20)
+		for k := uint64(0); k <= hi; k++ {
+			lgK, _ := math.Lgamma(float64(k) + 1)
+			expectedBin += math.Exp(float64(k)*math.Log(lambda)-lambda-lgK) * float64(draws)
+			observedBin += observed[k]
+			if expectedBin >= 20 || k == hi {
+				stat += (observedBin - expectedBin) * (observedBin - expectedBin) / expectedBin
+				df++
+				expectedBin, observedBin = 0, 0
+			}
+		}
+		assert.Less(t, stat, float64(df)+4*math.Sqrt(2*float64(df)), lambda)
+	}
+
+	xs := make([]float64, 20000)
+	for i := range xs {
+		xs[i] = float64(Poisson(r, 1e9))
+	}
+	mean, variance := meanAndVariance(xs)
+	assert.InEpsilon(t, 1e9, mean, 1e-5)
+	assert.InEpsilon(t, 1e9, variance, 0.05)
+
+	assert.Equal(t, uint64(0), Poisson(r, 0))
+	assert.Equal(t, uint64(0), Poisson(r, -1))
+	assert.Less(t, NewSyncPoolXoshiro256ssRNG().Poisson(1), uint64(100))
+
+	// the largest mean still draws in range, anything past it or NaN panics rather than overflowing
+	assert.InEpsilon(t, float64(PoissonMaxMean), float64(Poisson(r, PoissonMaxMean)), 1e-6)
+	for _, lambda := range []float64{math.NaN(), math.Inf(1), 1e300, 2 * PoissonMaxMean} {
+		assert.Panics(t, func() { Poisson(r, lambda) }, lambda)
+		assert.Panics(t, func() { NewSyncPoolXoshiro256ssRNG().Poisson(lambda) }, lambda)
+	}
+}
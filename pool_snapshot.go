@@ -0,0 +1,67 @@
+package fastrand64
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// poolSnapshotVersion is bumped whenever a restored pool would draw differently from the original
+const poolSnapshotVersion = 1
+
+// PoolSnapshot is the configuration of a seeded pool RNG plus its stream bookkeeping, how many
+// generators it has allocated. All its fields encode with encoding/gob, so a worker can be
+// checkpointed and resumed elsewhere. The resumed pool allocates the streams the original would
+// have allocated next, so it never repeats randomness drawn before the checkpoint, as long as the
+// original stops drawing new streams once the snapshot is taken.
+type PoolSnapshot struct {
+	Version   int
+	Algorithm string  // a generator name, see Algorithms
+	Seed      SeedSeq // stream i is seeded from Seed.Child(i)
+	Streams   uint64
+}
+
+type seededPool struct {
+	streams   uint64 // first, so atomic access is aligned on 32 bit platforms
+	algorithm string
+	seed      SeedSeq
+}
+
+// NewSyncPoolSeededRNG makes a pool RNG whose generators are the named algorithm seeded from
+// children of seed in allocation order, so it can be checkpointed with Snapshot
+func NewSyncPoolSeededRNG(algorithm string, seed SeedSeq) (*ThreadsafePoolRNG, error) {
+	return RestorePoolRNG(PoolSnapshot{Version: poolSnapshotVersion, Algorithm: algorithm, Seed: seed})
+}
+
+// RestorePoolRNG makes a pool RNG that continues from a snapshot
+func RestorePoolRNG(snap PoolSnapshot) (*ThreadsafePoolRNG, error) {
+	if snap.Version != poolSnapshotVersion {
+		return nil, fmt.Errorf("pool snapshot version %d is not supported, want %d", snap.Version, poolSnapshotVersion)
+	}
+	a, err := LookupAlgorithm(snap.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	p := &seededPool{streams: snap.Streams, algorithm: a.Name, seed: snap.Seed}
+	s := &ThreadsafePoolRNG{}
+	s.swapSource(func() UnsafeRNG {
+		i := atomic.AddUint64(&p.streams, 1) - 1
+		return a.New(p.seed.Child(i).Seed())
+	}, p)
+	return s, nil
+}
+
+// Snapshot returns the pool's configuration and stream bookkeeping. Pools not made by
+// NewSyncPoolSeededRNG or RestorePoolRNG, or swapped to another source since, can't be reproduced
+// so they return an error
+func (s *ThreadsafePoolRNG) Snapshot() (PoolSnapshot, error) {
+	seeded := s.source.Load().(*poolSource).seeded
+	if seeded == nil {
+		return PoolSnapshot{}, fmt.Errorf("pool RNG is not seeded, it can't be snapshotted")
+	}
+	return PoolSnapshot{
+		Version:   poolSnapshotVersion,
+		Algorithm: seeded.algorithm,
+		Seed:      seeded.seed,
+		Streams:   atomic.LoadUint64(&seeded.streams),
+	}, nil
+}
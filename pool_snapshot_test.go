@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ThreadsafePoolRNG_Snapshot(t *testing.T) {
+	seed := NewSeedSeq([]byte("checkpoint"))
+	s, err := NewSyncPoolSeededRNG("xoshiro256pp", seed)
+	assert.NoError(t, err)
+	drawn := map[uint64]bool{}
+	for i := 0; i < 100; i++ {
+		drawn[s.Uint64()] = true
+	}
+
+	snap, err := s.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, "xoshiro256++", snap.Algorithm)
+	assert.Equal(t, seed, snap.Seed)
+	assert.GreaterOrEqual(t, snap.Streams, uint64(1))
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(snap))
+	var decoded PoolSnapshot
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, snap, decoded)
+
+	// the resumed pool allocates the next stream, which is fresh randomness
+	resumed, err := RestorePoolRNG(decoded)
+	assert.NoError(t, err)
+	first := resumed.Uint64()
+	assert.False(t, drawn[first])
+	after, _ := resumed.Snapshot()
+	assert.Equal(t, snap.Streams+1, after.Streams)
+
+	// and it is the stream the original would have allocated next
+	assert.Equal(t, NewUnsafeXoshiro256ppRNG(seed.Child(snap.Streams).Seed()).Uint64(), first)
+}
+
+func Test_ThreadsafePoolRNG_Snapshot_Errors(t *testing.T) {
+	_, err := NewSyncPoolXoshiro256ssRNG().Snapshot()
+	assert.Error(t, err)
+
+	_, err = NewSyncPoolSeededRNG("no-such-generator", NewSeedSeq(nil))
+	assert.Error(t, err)
+
+	_, err = RestorePoolRNG(PoolSnapshot{Version: poolSnapshotVersion + 1, Algorithm: "xoshiro256ss"})
+	assert.Error(t, err)
+
+	var seed SeedSeq
+	assert.Error(t, seed.UnmarshalBinary([]byte{1, 2, 3}))
+}
@@ -0,0 +1,62 @@
+package fastrand64
+
+// RandomizedResponse reports truth with probability p, otherwise a fair coin flip, for local
+// privacy telemetry. Use EstimateRandomizedResponse to recover the population rate.
+func (s *ThreadsafePoolRNG) RandomizedResponse(truth bool, p float64) bool {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := r.Uint64()
+	rngPool.Put(r)
+	if float64(x>>11)*(1.0/(1<<53)) < p {
+		return truth
+	}
+	return x&1 == 1
+}
+
+// EstimateRandomizedResponse inverts RandomizedResponse: given the observed fraction of true
+// reports, it returns the estimated fraction of true answers in the population
+func EstimateRandomizedResponse(trueFraction float64, p float64) float64 {
+	return (trueFraction - (1-p)/2) / p
+}
+
+// RandomizedResponseK is the k-ary generalization of RandomizedResponse. It reports the category
+// truth in [0..k) with probability p, otherwise a uniformly random category in [0..k). It panics
+// if k < 1, truth is outside [0..k) or p is outside [0..1], rather than leak an out of range truth
+func (s *ThreadsafePoolRNG) RandomizedResponseK(truth int, k int, p float64) int {
+	if k < 1 {
+		panic("RandomizedResponseK k must be >= 1")
+	}
+	if truth < 0 || truth >= k {
+		panic("RandomizedResponseK truth must be in [0..k)")
+	}
+	if !(p >= 0 && p <= 1) {
+		panic("RandomizedResponseK p must be in [0..1]")
+	}
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	result := truth
+	if unitFloat64(r) >= p {
+		result = intn(r, k)
+	}
+	rngPool.Put(r)
+	return result
+}
+
+// EstimateRandomizedResponseK inverts RandomizedResponseK: given the count of reports per category,
+// it returns the estimated population fraction of each category. Estimates can fall slightly
+// outside [0..1] for small samples.
+func EstimateRandomizedResponseK(counts []uint64, p float64) []float64 {
+	total := uint64(0)
+	for _, c := range counts {
+		total += c
+	}
+	k := float64(len(counts))
+	result := make([]float64, len(counts))
+	if total == 0 {
+		return result
+	}
+	for i, c := range counts {
+		result[i] = (float64(c)/float64(total) - (1-p)/k) / p
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_RandomizedResponse(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	const n = 100000
+	yes := 0
+	for i := 0; i < n; i++ {
+		// 30% of the population has the sensitive attribute
+		if rng.RandomizedResponse(i%10 < 3, 0.5) {
+			yes++
+		}
+	}
+	assert.InDelta(t, 0.3, EstimateRandomizedResponse(float64(yes)/n, 0.5), 0.02)
+
+	for i := 0; i < 64; i++ {
+		assert.True(t, rng.RandomizedResponse(true, 1))
+	}
+}
+
+func Test_SafeRNG_RandomizedResponseK(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	const n = 100000
+	truth := []float64{0.5, 0.3, 0.2, 0}
+	counts := make([]uint64, len(truth))
+	for i := 0; i < n; i++ {
+		category := 0
+		switch {
+		case i%10 >= 8:
+			category = 2
+		case i%10 >= 5:
+			category = 1
+		}
+		c := rng.RandomizedResponseK(category, len(truth), 0.6)
+		assert.Less(t, c, len(truth))
+		counts[c]++
+	}
+	assert.InDeltaSlice(t, truth, EstimateRandomizedResponseK(counts, 0.6), 0.02)
+	assert.Equal(t, []float64{0, 0}, EstimateRandomizedResponseK([]uint64{0, 0}, 0.5))
+
+	assert.Panics(t, func() { rng.RandomizedResponseK(0, 0, 0.5) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(0, -1, 0.5) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(-1, 4, 0.5) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(4, 4, 0.5) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(0, 4, -0.1) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(0, 4, 1.1) })
+	assert.Panics(t, func() { rng.RandomizedResponseK(0, 4, math.NaN()) })
+	assert.Equal(t, 0, rng.RandomizedResponseK(0, 1, 0))
+	assert.Equal(t, 3, rng.RandomizedResponseK(3, 4, 1))
+}
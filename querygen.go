@@ -0,0 +1,151 @@
+package fastrand64
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// QueryParamType is the type of value drawn for a query parameter
+type QueryParamType int
+
+const (
+	// QueryInt draws int64 values in [Min..Max]
+	QueryInt QueryParamType = iota
+	// QueryFloat draws float64 values in [Min..Max)
+	QueryFloat
+	// QueryString draws strings matching Pattern, or lowercase alphanumerics of length [Min..Max]
+	QueryString
+	// QueryBool draws true or false with equal odds
+	QueryBool
+	// QueryTime draws UTC time.Time values with unix seconds in [Min..Max]
+	QueryTime
+)
+
+// QueryParam describes the distribution of one query parameter
+type QueryParam struct {
+	Type QueryParamType
+	Min  int64
+	Max  int64
+	// Skew > 1 draws QueryInt and QueryTime values from a Zipf distribution with that exponent,
+	// so values near Min are hot. 0 means uniform.
+	Skew float64
+	// Pattern is an optional regexp for QueryString values, see FromRegexp
+	Pattern string
+}
+
+// QueryTemplate is one parameterized query, Weight is its share of the workload
+type QueryTemplate struct {
+	SQL    string
+	Params []QueryParam
+	Weight float64
+}
+
+// QueryWorkload generates a stream of randomized parameterized queries. Threadsafe
+type QueryWorkload struct {
+	rng        *ThreadsafePoolRNG
+	templates  []QueryTemplate
+	cumWeights []float64
+	zipfs      [][]*zipf
+	patterns   [][]*syntax.Regexp
+}
+
+// NewQueryWorkload validates the templates and returns a workload drawing from this pool
+func (s *ThreadsafePoolRNG) NewQueryWorkload(templates []QueryTemplate) (*QueryWorkload, error) {
+	w := &QueryWorkload{rng: s, templates: templates}
+	total := 0.0
+	for i, t := range templates {
+		if t.Weight < 0 {
+			return nil, fmt.Errorf("query template %d has negative weight", i)
+		}
+		total += t.Weight
+		w.cumWeights = append(w.cumWeights, total)
+
+		zipfs := make([]*zipf, len(t.Params))
+		patterns := make([]*syntax.Regexp, len(t.Params))
+		for j, p := range t.Params {
+			if p.Max < p.Min {
+				return nil, fmt.Errorf("query template %d param %d has Max < Min", i, j)
+			}
+			if p.Skew != 0 {
+				if zipfs[j] = newZipf(p.Skew, 1, uint64(p.Max-p.Min)); zipfs[j] == nil {
+					return nil, fmt.Errorf("query template %d param %d needs Skew > 1", i, j)
+				}
+			}
+			if p.Pattern != "" {
+				re, err := syntax.Parse(p.Pattern, syntax.Perl)
+				if err != nil {
+					return nil, err
+				}
+				patterns[j] = re.Simplify()
+			}
+		}
+		w.zipfs = append(w.zipfs, zipfs)
+		w.patterns = append(w.patterns, patterns)
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("query workload needs at least one template with positive weight")
+	}
+	return w, nil
+}
+
+// Next picks a template by weight and draws its parameters
+func (w *QueryWorkload) Next() (string, []interface{}) {
+	rngPool := w.rng.pool()
+	r := rngPool.Get().(UnsafeRNG)
+
+	x := unitFloat64(r) * w.cumWeights[len(w.cumWeights)-1]
+	i := 0
+	for i < len(w.cumWeights)-1 && x >= w.cumWeights[i] {
+		i++
+	}
+
+	t := &w.templates[i]
+	args := make([]interface{}, len(t.Params))
+	for j := range t.Params {
+		args[j] = w.param(r, i, j)
+	}
+
+	rngPool.Put(r)
+	return t.SQL, args
+}
+
+func (w *QueryWorkload) param(r UnsafeRNG, i int, j int) interface{} {
+	p := &w.templates[i].Params[j]
+	switch p.Type {
+	case QueryInt:
+		return w.intParam(r, i, j)
+	case QueryFloat:
+		return float64(p.Min) + unitFloat64(r)*float64(p.Max-p.Min)
+	case QueryString:
+		var sb strings.Builder
+		if re := w.patterns[i][j]; re != nil {
+			genRegexp(r, re, &sb)
+			return sb.String()
+		}
+		const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+		n := w.intParam(r, i, j)
+		for k := int64(0); k < n; k++ {
+			sb.WriteByte(alphabet[intn(r, len(alphabet))])
+		}
+		return sb.String()
+	case QueryBool:
+		return r.Uint64()&1 == 1
+	case QueryTime:
+		return time.Unix(w.intParam(r, i, j), 0).UTC()
+	}
+	return nil
+}
+
+func (w *QueryWorkload) intParam(r UnsafeRNG, i int, j int) int64 {
+	p := &w.templates[i].Params[j]
+	if z := w.zipfs[i][j]; z != nil {
+		return p.Min + int64(z.sample(r))
+	}
+	span := uint64(p.Max-p.Min) + 1
+	if span == 0 {
+		return int64(r.Uint64())
+	}
+	return p.Min + int64(uint64n(r, span))
+}
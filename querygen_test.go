@@ -0,0 +1,81 @@
+package fastrand64
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_QueryWorkload(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	w, err := rng.NewQueryWorkload([]QueryTemplate{
+		{
+			SQL:    "SELECT * FROM users WHERE id = ?",
+			Params: []QueryParam{{Type: QueryInt, Min: 1, Max: 1000, Skew: 1.2}},
+			Weight: 3,
+		},
+		{
+			SQL: "INSERT INTO users (email, score, active, created) VALUES (?, ?, ?, ?)",
+			Params: []QueryParam{
+				{Type: QueryString, Pattern: `[a-z]{4,8}@example\.com`},
+				{Type: QueryFloat, Min: 0, Max: 100},
+				{Type: QueryBool},
+				{Type: QueryTime, Min: 1600000000, Max: 1700000000},
+			},
+			Weight: 1,
+		},
+		{
+			SQL:    "SELECT * FROM tags WHERE name = ?",
+			Params: []QueryParam{{Type: QueryString, Min: 2, Max: 5}},
+			Weight: 0,
+		},
+	})
+	assert.NoError(t, err)
+
+	email := regexp.MustCompile(`^[a-z]{4,8}@example\.com$`)
+	counts := map[string]int{}
+	hot := 0
+	for i := 0; i < 4096; i++ {
+		sql, args := w.Next()
+		counts[sql]++
+		switch len(args) {
+		case 1:
+			id := args[0].(int64)
+			assert.GreaterOrEqual(t, id, int64(1))
+			assert.LessOrEqual(t, id, int64(1000))
+			if id <= 10 {
+				hot++
+			}
+		case 4:
+			assert.Regexp(t, email, args[0])
+			assert.GreaterOrEqual(t, args[1].(float64), 0.0)
+			assert.Less(t, args[1].(float64), 100.0)
+			assert.IsType(t, true, args[2])
+			created := args[3].(time.Time).Unix()
+			assert.GreaterOrEqual(t, created, int64(1600000000))
+			assert.LessOrEqual(t, created, int64(1700000000))
+		}
+	}
+	assert.Equal(t, 0, counts["SELECT * FROM tags WHERE name = ?"])
+	assert.InDelta(t, 0.75, float64(counts["SELECT * FROM users WHERE id = ?"])/4096, 0.05)
+	// with uniform ids only ~1% would be <= 10, the skew makes it the majority
+	assert.Greater(t, hot, counts["SELECT * FROM users WHERE id = ?"]/2)
+}
+
+func Test_SafeRNG_QueryWorkload_Errors(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, templates := range [][]QueryTemplate{
+		nil,
+		{{SQL: "SELECT 1", Weight: 0}},
+		{{SQL: "SELECT 1", Weight: -1}},
+		{{SQL: "SELECT ?", Weight: 1, Params: []QueryParam{{Type: QueryInt, Min: 5, Max: 1}}}},
+		{{SQL: "SELECT ?", Weight: 1, Params: []QueryParam{{Type: QueryInt, Max: 10, Skew: 0.5}}}},
+		{{SQL: "SELECT ?", Weight: 1, Params: []QueryParam{{Type: QueryString, Pattern: "("}}}},
+	} {
+		w, err := rng.NewQueryWorkload(templates)
+		assert.Error(t, err)
+		assert.Nil(t, w)
+	}
+}
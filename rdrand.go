@@ -0,0 +1,75 @@
+package fastrand64
+
+// UnsafeRdrandRNG draws from the CPU's hardware random number generator with the RDRAND (or
+// RDSEED) instruction, so hardware entropy can be mixed into a pool, eg
+// NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRdrandRNG(seed) }). When the instruction is
+// missing, or keeps failing, values come from a software xoshiro256** generator instead, see
+// Hardware. Its output can't be reproduced. It is unsafe to call UnsafeRNG methods from
+// concurrent goroutines.
+type UnsafeRdrandRNG struct {
+	fallback UnsafeXoshiro256ssRNG
+	draw     func() (uint64, bool) // nil without the instruction
+	retries  int
+}
+
+// rdrandRetries is Intel's recommended retry count for RDRAND, which only fails transiently
+const rdrandRetries = 10
+
+// rdseedRetries is higher since RDSEED runs dry whenever the entropy source is drawn faster than
+// it produces
+const rdseedRetries = 100
+
+// HasRDRAND reports whether this machine has the RDRAND instruction, it is only detected on amd64
+func HasRDRAND() bool {
+	return hasRDRAND
+}
+
+// HasRDSEED reports whether this machine has the RDSEED instruction, it is only detected on amd64
+func HasRDSEED() bool {
+	return hasRDSEED
+}
+
+// Seed seeds the software fallback
+func (r *UnsafeRdrandRNG) Seed(seed int64) {
+	r.fallback.Seed(seed)
+}
+
+// Hardware reports whether Uint64 draws from the hardware instruction
+func (r *UnsafeRdrandRNG) Hardware() bool {
+	return r.draw != nil
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeRdrandRNG) Uint64() uint64 {
+	if r.draw != nil {
+		for i := 0; i < r.retries; i++ {
+			if x, ok := r.draw(); ok {
+				return x
+			}
+		}
+	}
+	return r.fallback.Uint64()
+}
+
+// NewUnsafeRdrandRNG creates a generator using RDRAND, the output of a hardware DRBG reseeded
+// from the CPU's entropy source, seed is for the software fallback
+func NewUnsafeRdrandRNG(seed int64) *UnsafeRdrandRNG {
+	r := &UnsafeRdrandRNG{retries: rdrandRetries}
+	if hasRDRAND {
+		r.draw = rdrand64
+	}
+	r.Seed(seed)
+	return r
+}
+
+// NewUnsafeRdseedRNG creates a generator using RDSEED, conditioned output of the CPU's entropy
+// source, much slower than RDRAND but suited to seeding other generators, seed is for the
+// software fallback
+func NewUnsafeRdseedRNG(seed int64) *UnsafeRdrandRNG {
+	r := &UnsafeRdrandRNG{retries: rdseedRetries}
+	if hasRDSEED {
+		r.draw = rdseed64
+	}
+	r.Seed(seed)
+	return r
+}
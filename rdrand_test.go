@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"io/ioutil"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeRdrandRNG_Uint64(t *testing.T) {
+	for _, rng := range []*UnsafeRdrandRNG{NewUnsafeRdrandRNG(1), NewUnsafeRdseedRNG(1)} {
+		seen := map[uint64]bool{}
+		for i := 0; i < 100; i++ {
+			seen[rng.Uint64()] = true
+		}
+		assert.Len(t, seen, 100)
+	}
+
+	// without the instruction it is the software generator
+	rng := NewUnsafeRdrandRNG(1)
+	rng.draw = nil
+	assert.False(t, rng.Hardware())
+	expected := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, expected.Uint64(), rng.Uint64())
+	}
+
+	// and when the hardware keeps failing
+	rng = NewUnsafeRdrandRNG(1)
+	rng.draw = func() (uint64, bool) { return 0, false }
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), rng.Uint64())
+}
+
+func Test_HasRDRAND(t *testing.T) {
+	assert.Equal(t, HasRDRAND(), NewUnsafeRdrandRNG(1).Hardware())
+	assert.Equal(t, HasRDSEED(), NewUnsafeRdseedRNG(1).Hardware())
+	if runtime.GOARCH != "amd64" {
+		assert.False(t, HasRDRAND())
+		assert.False(t, HasRDSEED())
+	}
+	if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+		cpuinfo, err := ioutil.ReadFile("/proc/cpuinfo")
+		if err == nil {
+			assert.Equal(t, regexp.MustCompile(`(?m)^flags\s*:.*\brdrand\b`).Match(cpuinfo), HasRDRAND())
+			assert.Equal(t, regexp.MustCompile(`(?m)^flags\s*:.*\brdseed\b`).Match(cpuinfo), HasRDSEED())
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package fastrand64
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ReferenceVector is a machine readable known answer test, so ports of this package to other
+// languages can check they produce exactly the same numbers. Generators, named as in Algorithms,
+// record their first Uint64s after NewUnsafe...RNG(Seed). Distributions record samples drawn with
+// NewUnsafeXoshiro256ssRNG(Seed) and quantiles at fixed probabilities, Params are the constructor
+// arguments in order.
+// The same vectors are checked in as testdata/reference_vectors.json.
+type ReferenceVector struct {
+	Name      string       `json:"name"`
+	Seed      int64        `json:"seed"`
+	Params    []float64    `json:"params,omitempty"`
+	Uint64s   []uint64     `json:"uint64s,omitempty"`
+	Samples   []float64    `json:"samples,omitempty"`
+	Quantiles [][2]float64 `json:"quantiles,omitempty"` // pairs of p and Quantile(p)
+}
+
+// referenceSeed is the seed every reference vector uses
+const referenceSeed = 20200607
+
+// referenceDistributions are the params each distribution's vectors use, see NewDistribution
+var referenceDistributions = map[string][]float64{
+	"stable":        {1.5, 0.5, 2, 1},
+	"levy":          {1, 2},
+	"vonmises":      {1, 2},
+	"wrappednormal": {-2, 0.5},
+	"gumbel":        {1, 2},
+	"frechet":       {3, 2, 1},
+	"gev":           {0, 1, -0.2},
+	"lognormal":     {1, 0.5},
+	"gamma":         {0.7, 2},
+	"beta":          {2.5, 0.8},
+	"pareto":        {1.5, 2},
+	"weibull":       {1.5, 2},
+	"empirical":     {3, 1, 4, 1.5, 5, 9, 2, 6},
+	"histogram":     {0, 10, 50, 100, 50, 30, 20},
+}
+
+// referenceDiscrete are the discrete samplers, their samples are whole numbers
+var referenceDiscrete = map[string][]float64{
+	"binomial": {20, 0.3},
+	// large enough n*p for BTPE
+	"binomial-btpe":    {1000, 0.4},
+	"geometric":        {0.2},
+	"hypergeometric":   {30, 70, 20},
+	"negativebinomial": {2.5, 0.3},
+	// large enough variance for the gamma Poisson mixture
+	"negativebinomial-mixture": {50, 0.01},
+	"poisson":                  {3},
+	// large enough lambda for PTRS
+	"poisson-ptrs": {50},
+	// s, v and imax, like NewZipf
+	"zipf": {1.5, 2, 100},
+}
+
+func sampleReferenceDiscrete(name string, r UnsafeRNG, p []float64) float64 {
+	switch name {
+	case "binomial", "binomial-btpe":
+		return float64(Binomial(r, uint64(p[0]), p[1]))
+	case "geometric":
+		return float64(Geometric(r, p[0]))
+	case "hypergeometric":
+		return float64(Hypergeometric(r, uint64(p[0]), uint64(p[1]), uint64(p[2])))
+	case "poisson", "poisson-ptrs":
+		return float64(Poisson(r, p[0]))
+	case "zipf":
+		return float64(newZipf(p[0], p[1], uint64(p[2])).sample(r))
+	}
+	return float64(NegativeBinomial(r, p[0], p[1]))
+}
+
+// referenceProbabilities are where distribution quantiles are recorded
+var referenceProbabilities = []float64{0.01, 0.1, 0.5, 0.9, 0.99}
+
+// ReferenceVectorNames lists every name ReferenceVectors accepts, sorted
+func ReferenceVectorNames() []string {
+	var names []string
+	for _, a := range algorithms {
+		names = append(names, a.Name)
+	}
+	for name := range referenceDistributions {
+		names = append(names, name)
+	}
+	for name := range referenceDiscrete {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReferenceVectors computes the reference vector for a generator or distribution name, generators
+// may be named by an alias
+func ReferenceVectors(name string) (*ReferenceVector, error) {
+	v := &ReferenceVector{Name: name, Seed: referenceSeed}
+	if a, err := LookupAlgorithm(name); err == nil {
+		v.Name = a.Name
+		r := a.New(referenceSeed)
+		v.Uint64s = make([]uint64, 16)
+		for i := range v.Uint64s {
+			v.Uint64s[i] = r.Uint64()
+		}
+		return v, nil
+	}
+	if params, ok := referenceDistributions[name]; ok {
+		d, err := NewDistribution(name, params...)
+		if err != nil {
+			return nil, err
+		}
+		v.Params = params
+		v.Samples = make([]float64, 8)
+		r := NewUnsafeXoshiro256ssRNG(referenceSeed)
+		for i := range v.Samples {
+			v.Samples[i] = d.Sample(r)
+		}
+		for _, p := range referenceProbabilities {
+			v.Quantiles = append(v.Quantiles, [2]float64{p, d.Quantile(p)})
+		}
+		return v, nil
+	}
+	if params, ok := referenceDiscrete[name]; ok {
+		v.Params = params
+		v.Samples = make([]float64, 8)
+		r := NewUnsafeXoshiro256ssRNG(referenceSeed)
+		for i := range v.Samples {
+			v.Samples[i] = sampleReferenceDiscrete(name, r, params)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("no reference vectors named %q", name)
+}
+
+// WriteReferenceVectors writes every reference vector as an indented JSON array
+func WriteReferenceVectors(w io.Writer) error {
+	var all []*ReferenceVector
+	for _, name := range ReferenceVectorNames() {
+		v, err := ReferenceVectors(name)
+		if err != nil {
+			return err
+		}
+		all = append(all, v)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(all)
+}
+
+// LoadReferenceVectors reads reference vectors written by WriteReferenceVectors
+func LoadReferenceVectors(r io.Reader) ([]ReferenceVector, error) {
+	var all []ReferenceVector
+	if err := json.NewDecoder(r).Decode(&all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
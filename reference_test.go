@@ -0,0 +1,76 @@
+package fastrand64
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateReferenceVectors = flag.Bool("update", false, "rewrite testdata/reference_vectors.json")
+
+const referenceVectorsPath = "testdata/reference_vectors.json"
+
+func Test_ReferenceVectors_Golden(t *testing.T) {
+	if *updateReferenceVectors {
+		var buf bytes.Buffer
+		assert.NoError(t, WriteReferenceVectors(&buf))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(referenceVectorsPath), 0755))
+		assert.NoError(t, ioutil.WriteFile(referenceVectorsPath, buf.Bytes(), 0644))
+	}
+
+	f, err := os.Open(referenceVectorsPath)
+	assert.NoError(t, err)
+	defer f.Close()
+	golden, err := LoadReferenceVectors(f)
+	assert.NoError(t, err)
+	assert.Equal(t, ReferenceVectorNames(), referenceNames(golden))
+
+	for _, g := range golden {
+		v, err := ReferenceVectors(g.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, g.Seed, v.Seed, g.Name)
+		assert.Equal(t, g.Params, v.Params, g.Name)
+		assert.Equal(t, g.Uint64s, v.Uint64s, g.Name)
+		// floats may differ in the last bits between platforms' math routines
+		assert.Len(t, v.Samples, len(g.Samples), g.Name)
+		for i := range g.Samples {
+			assert.InDelta(t, g.Samples[i], v.Samples[i], 1e-12*math.Max(1, math.Abs(g.Samples[i])), g.Name)
+		}
+		assert.Len(t, v.Quantiles, len(g.Quantiles), g.Name)
+		for i := range g.Quantiles {
+			assert.Equal(t, g.Quantiles[i][0], v.Quantiles[i][0], g.Name)
+			assert.InDelta(t, g.Quantiles[i][1], v.Quantiles[i][1], 1e-9*math.Max(1, math.Abs(g.Quantiles[i][1])), g.Name)
+		}
+	}
+}
+
+func Test_ReferenceVectors(t *testing.T) {
+	v, err := ReferenceVectors("xoshiro256ss")
+	assert.NoError(t, err)
+	r := NewUnsafeXoshiro256ssRNG(v.Seed)
+	for _, x := range v.Uint64s {
+		assert.Equal(t, r.Uint64(), x)
+	}
+
+	v, err = ReferenceVectors("gumbel")
+	assert.NoError(t, err)
+	assert.Len(t, v.Quantiles, len(referenceProbabilities))
+	assert.InDelta(t, 1-2*math.Log(math.Ln2), v.Quantiles[2][1], 1e-12)
+
+	_, err = ReferenceVectors("no-such-generator")
+	assert.Error(t, err)
+}
+
+func referenceNames(vs []ReferenceVector) []string {
+	var names []string
+	for _, v := range vs {
+		names = append(names, v.Name)
+	}
+	return names
+}
@@ -0,0 +1,125 @@
+package fastrand64
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// regexpMaxRepeat caps how many extra repetitions an unbounded *, + or {n,} produces
+const regexpMaxRepeat = 8
+
+// FromRegexp compiles a regular expression into a generator of random strings that match it.
+// Each call of the returned func checks one generator out of the pool, so it is threadsafe.
+//
+// Anchors and word boundaries are ignored, any-char and negated classes prefer printable ascii,
+// and unbounded repetition is capped at regexpMaxRepeat extra repeats.
+func (s *ThreadsafePoolRNG) FromRegexp(pattern string) (func() string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	re = re.Simplify()
+	return func() string {
+		rngPool := s.pool()
+		r := rngPool.Get().(UnsafeRNG)
+		var sb strings.Builder
+		genRegexp(r, re, &sb)
+		rngPool.Put(r)
+		return sb.String()
+	}, nil
+}
+
+func genRegexp(r UnsafeRNG, re *syntax.Regexp, sb *strings.Builder) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && r.Uint64()&1 == 1 {
+				c = unicode.SimpleFold(c)
+			}
+			sb.WriteRune(c)
+		}
+	case syntax.OpCharClass:
+		sb.WriteRune(genCharClass(r, re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		sb.WriteRune(rune(' ' + intn(r, '~'-' '+1)))
+	case syntax.OpCapture:
+		genRegexp(r, re.Sub[0], sb)
+	case syntax.OpStar:
+		genRepeat(r, re.Sub[0], 0, -1, sb)
+	case syntax.OpPlus:
+		genRepeat(r, re.Sub[0], 1, -1, sb)
+	case syntax.OpQuest:
+		genRepeat(r, re.Sub[0], 0, 1, sb)
+	case syntax.OpRepeat:
+		genRepeat(r, re.Sub[0], re.Min, re.Max, sb)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			genRegexp(r, sub, sb)
+		}
+	case syntax.OpAlternate:
+		genRegexp(r, re.Sub[intn(r, len(re.Sub))], sb)
+	}
+	// OpEmptyMatch, OpNoMatch, anchors and word boundaries produce nothing
+}
+
+func genRepeat(r UnsafeRNG, re *syntax.Regexp, min int, max int, sb *strings.Builder) {
+	if max < 0 {
+		max = min + regexpMaxRepeat
+	}
+	n := min + intn(r, max-min+1)
+	for i := 0; i < n; i++ {
+		genRegexp(r, re, sb)
+	}
+}
+
+// genCharClass picks a rune from a class given as [lo, hi] pairs, preferring the printable ascii
+// subset of the class when there is one, and never picking a surrogate
+func genCharClass(r UnsafeRNG, ranges []rune) rune {
+	if c, ok := pickRuneInRanges(r, ranges, ' ', '~'); ok {
+		return c
+	}
+	if c, ok := pickRuneInRanges(r, ranges, 0, 0xD7FF); ok {
+		return c
+	}
+	if c, ok := pickRuneInRanges(r, ranges, 0xE000, unicode.MaxRune); ok {
+		return c
+	}
+	return unicode.ReplacementChar
+}
+
+func pickRuneInRanges(r UnsafeRNG, ranges []rune, clipLo rune, clipHi rune) (rune, bool) {
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := clipRange(ranges[i], ranges[i+1], clipLo, clipHi)
+		if lo <= hi {
+			total += int(hi - lo + 1)
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	n := intn(r, total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := clipRange(ranges[i], ranges[i+1], clipLo, clipHi)
+		if lo > hi {
+			continue
+		}
+		if size := int(hi - lo + 1); n >= size {
+			n -= size
+			continue
+		}
+		return lo + rune(n), true
+	}
+	return 0, false
+}
+
+func clipRange(lo rune, hi rune, clipLo rune, clipHi rune) (rune, rune) {
+	if lo < clipLo {
+		lo = clipLo
+	}
+	if hi > clipHi {
+		hi = clipHi
+	}
+	return lo, hi
+}
@@ -0,0 +1,47 @@
+package fastrand64
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_FromRegexp(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	patterns := []string{
+		`[a-z]{3,8}@[a-z]+\.(com|org|net)`,
+		`\d{3}-\d{4}`,
+		`(?i)hello world`,
+		`[^a-z0-9]+`,
+		`a.b*c?`,
+		`[\p{Greek}]{2}`,
+		`(foo|bar|)x{0,3}`,
+	}
+	for _, pattern := range patterns {
+		gen, err := rng.FromRegexp(pattern)
+		assert.NoError(t, err)
+		re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for i := 0; i < 256; i++ {
+			s := gen()
+			assert.Regexp(t, re, s)
+		}
+	}
+}
+
+func Test_SafeRNG_FromRegexp_BadPattern(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	gen, err := rng.FromRegexp(`(unclosed`)
+	assert.Error(t, err)
+	assert.Nil(t, gen)
+}
+
+func Benchmark_SyncPoolFromRegexp(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	gen, _ := rng.FromRegexp(`[a-z]{3,8}@[a-z]+\.(com|org|net)`)
+	var s string
+	for i := 0; i < b.N; i++ {
+		s = gen()
+	}
+	BenchSink = &s
+}
@@ -0,0 +1,78 @@
+package fastrand64
+
+import "math"
+
+// ResampleSystematic draws n particle indices proportionally to weights with systematic resampling,
+// a single uniform offset shared by n evenly spaced positions. Lowest variance, the usual default.
+// Indices are returned in ascending order, weights need not be normalized.
+func ResampleSystematic(r UnsafeRNG, weights []float64, n int) []int {
+	u := unitFloat64(r)
+	return resampleWalk(weights, n, func(i int) float64 { return (float64(i) + u) / float64(n) })
+}
+
+// ResampleStratified draws n particle indices proportionally to weights with one independent
+// uniform per stratum [i/n, (i+1)/n)
+func ResampleStratified(r UnsafeRNG, weights []float64, n int) []int {
+	return resampleWalk(weights, n, func(i int) float64 { return (float64(i) + unitFloat64(r)) / float64(n) })
+}
+
+// ResampleMultinomial draws n independent particle indices proportionally to weights, in O(n)
+// by generating the uniforms already sorted from normalized exponential spacings
+func ResampleMultinomial(r UnsafeRNG, weights []float64, n int) []int {
+	spacings := make([]float64, n+1)
+	total := 0.0
+	for i := range spacings {
+		total += -math.Log(1 - unitFloat64(r))
+		spacings[i] = total
+	}
+	return resampleWalk(weights, n, func(i int) float64 { return spacings[i] / total })
+}
+
+// resampleWalk maps n ascending positions in [0..1) onto the cumulative weights
+func resampleWalk(weights []float64, n int, position func(i int) float64) []int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if !(total > 0) {
+		panic("Resample needs a positive total weight")
+	}
+	result := make([]int, n)
+	j, cum := 0, weights[0]
+	for i := 0; i < n; i++ {
+		x := position(i) * total
+		for x >= cum && j < len(weights)-1 {
+			j++
+			cum += weights[j]
+		}
+		result[i] = j
+	}
+	return result
+}
+
+// ResampleSystematic draws n particle indices with systematic resampling using a single pool checkout
+func (s *ThreadsafePoolRNG) ResampleSystematic(weights []float64, n int) []int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	result := ResampleSystematic(r, weights, n)
+	rngPool.Put(r)
+	return result
+}
+
+// ResampleStratified draws n particle indices with stratified resampling using a single pool checkout
+func (s *ThreadsafePoolRNG) ResampleStratified(weights []float64, n int) []int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	result := ResampleStratified(r, weights, n)
+	rngPool.Put(r)
+	return result
+}
+
+// ResampleMultinomial draws n particle indices with multinomial resampling using a single pool checkout
+func (s *ThreadsafePoolRNG) ResampleMultinomial(weights []float64, n int) []int {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	result := ResampleMultinomial(r, weights, n)
+	rngPool.Put(r)
+	return result
+}
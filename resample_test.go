@@ -0,0 +1,61 @@
+package fastrand64
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Resample(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	weights := []float64{0.1, 0, 0.6, 0.3}
+	for name, resample := range map[string]func([]float64, int) []int{
+		"systematic":  rng.ResampleSystematic,
+		"stratified":  rng.ResampleStratified,
+		"multinomial": rng.ResampleMultinomial,
+	} {
+		const n = 20000
+		indices := resample(weights, n)
+		assert.Len(t, indices, n, name)
+		assert.True(t, sort.IntsAreSorted(indices), name)
+		counts := make([]float64, len(weights))
+		for _, i := range indices {
+			counts[i]++
+		}
+		for i, w := range weights {
+			assert.InDelta(t, w, counts[i]/n, 0.015, name)
+		}
+	}
+}
+
+func Test_ResampleSystematic_LowVariance(t *testing.T) {
+	// systematic resampling gives every particle floor(n*w) or ceil(n*w) copies
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for trial := 0; trial < 100; trial++ {
+		counts := make([]int, 3)
+		for _, i := range ResampleSystematic(r, []float64{1, 2, 7}, 10) {
+			counts[i]++
+		}
+		assert.Equal(t, []int{1, 2, 7}, counts)
+	}
+}
+
+func Test_Resample_ZeroWeights(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	assert.Panics(t, func() { ResampleStratified(r, []float64{0, 0}, 4) })
+	assert.Empty(t, ResampleMultinomial(r, []float64{1}, 0))
+}
+
+func Benchmark_SyncPoolResampleSystematic_1024(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	weights := rng.GaussianNoise(make([]float64, 1024), 1)
+	for i := range weights {
+		weights[i] *= weights[i]
+	}
+	var indices []int
+	for i := 0; i < b.N; i++ {
+		indices = rng.ResampleSystematic(weights, 1024)
+	}
+	BenchSink = &indices
+}
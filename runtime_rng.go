@@ -0,0 +1,25 @@
+package fastrand64
+
+// RuntimeRNG draws from the Go runtime's own per P generator, the one behind map iteration order
+// and select, so unlike every other generator here it is threadsafe without a pool or a lock. It
+// holds no state, can't be seeded, and is NOT reproducible, the runtime seeds itself at startup.
+//
+// How it reaches the runtime depends on the Go version: math/rand/v2 from go1.22, a linkname to
+// runtime.fastrand64 from go1.19, and to runtime.fastrand before that.
+type RuntimeRNG struct{}
+
+// NewRuntimeRNG returns the runtime backed generator, it is safe to share between goroutines
+func NewRuntimeRNG() RuntimeRNG {
+	return RuntimeRNG{}
+}
+
+// Uint64 returns pseudorandom uint64. Threadsafe
+func (RuntimeRNG) Uint64() uint64 {
+	return runtimeFastrand64()
+}
+
+// NewRuntimePoolRNG wraps RuntimeRNG in the pool API, every checkout gets the same stateless value
+// so the pool never allocates a generator
+func NewRuntimePoolRNG() *ThreadsafePoolRNG {
+	return NewSyncPoolRNG(func() UnsafeRNG { return RuntimeRNG{} })
+}
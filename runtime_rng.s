@@ -0,0 +1,5 @@
+//go:build !go1.22
+// +build !go1.22
+
+// This file is intentionally empty, it lets the linknamed runtime functions be declared without
+// a body.
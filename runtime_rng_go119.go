@@ -0,0 +1,9 @@
+//go:build go1.19 && !go1.22
+// +build go1.19,!go1.22
+
+package fastrand64
+
+import _ "unsafe" // for go:linkname
+
+//go:linkname runtimeFastrand64 runtime.fastrand64
+func runtimeFastrand64() uint64
@@ -0,0 +1,11 @@
+//go:build go1.22
+// +build go1.22
+
+package fastrand64
+
+import "math/rand/v2"
+
+// math/rand/v2's top level functions are the runtime generator, no linkname needed
+func runtimeFastrand64() uint64 {
+	return rand.Uint64()
+}
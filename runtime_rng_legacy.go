@@ -0,0 +1,15 @@
+//go:build !go1.19
+// +build !go1.19
+
+package fastrand64
+
+import _ "unsafe" // for go:linkname
+
+// before go1.19 the runtime only has a 32 bit fastrand
+
+//go:linkname runtimeFastrand runtime.fastrand
+func runtimeFastrand() uint32
+
+func runtimeFastrand64() uint64 {
+	return uint64(runtimeFastrand())<<32 | uint64(runtimeFastrand())
+}
@@ -0,0 +1,43 @@
+package fastrand64
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RuntimeRNG_Uint64(t *testing.T) {
+	// shared between goroutines without a pool or lock, run with -race
+	rng := NewRuntimeRNG()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[uint64]bool{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				x := rng.Uint64()
+				mu.Lock()
+				seen[x] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 8000)
+
+	results, err := RunStatTests(rng, MinStatTestWords)
+	assert.NoError(t, err)
+	for _, res := range results {
+		assert.True(t, res.Passed(1e-6), res.Name)
+	}
+}
+
+func Test_RuntimePoolRNG(t *testing.T) {
+	rng := NewRuntimePoolRNG()
+	assert.NotEqual(t, rng.Uint64(), rng.Uint64())
+	x := rng.Uint32n(10)
+	assert.True(t, x < 10)
+}
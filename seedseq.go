@@ -0,0 +1,93 @@
+package fastrand64
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// SeedSeq turns entropy of any length into reproducible, well separated seeds for any number of
+// generators, and into child sequences for parallel workers (like NumPy's SeedSequence). It is an
+// immutable value, so it is safe to copy and share between goroutines.
+type SeedSeq struct {
+	key [sha256.Size]byte
+}
+
+// NewSeedSeq derives a SeedSeq from entropy, eg a recorded experiment seed or a passphrase
+func NewSeedSeq(entropy []byte) SeedSeq {
+	return SeedSeq{key: seedSeqHash("fastrand64 seedseq v1\x00", entropy)}
+}
+
+// Child returns the i-th child sequence. Children of different indices, and children and their
+// parent, produce unrelated streams.
+func (s SeedSeq) Child(i uint64) SeedSeq {
+	var index [8]byte
+	binary.LittleEndian.PutUint64(index[:], i)
+	return SeedSeq{key: seedSeqHash("fastrand64 seedseq child\x00", s.key[:], index[:])}
+}
+
+// Named returns the child sequence for a name, eg a simulation component. Different names, and
+// names and numbered children, produce unrelated streams.
+func (s SeedSeq) Named(name string) SeedSeq {
+	return SeedSeq{key: seedSeqHash("fastrand64 seedseq named\x00", s.key[:], []byte(name))}
+}
+
+// Spawn returns children 0..n-1, eg one per worker
+func (s SeedSeq) Spawn(n int) []SeedSeq {
+	children := make([]SeedSeq, n)
+	for i := range children {
+		children[i] = s.Child(uint64(i))
+	}
+	return children
+}
+
+// GenerateState fills dst with seed words, the same sequence always gives the same words
+func (s SeedSeq) GenerateState(dst []uint64) []uint64 {
+	var block [8]byte
+	for i := 0; i < len(dst); i += 4 {
+		binary.LittleEndian.PutUint64(block[:], uint64(i/4))
+		digest := seedSeqHash("fastrand64 seedseq state\x00", s.key[:], block[:])
+		for j := 0; j < 4 && i+j < len(dst); j++ {
+			dst[i+j] = binary.LittleEndian.Uint64(digest[8*j:])
+		}
+	}
+	return dst
+}
+
+// Seed returns a single int64 seed, for the New...RNG(seed int64) constructors
+func (s SeedSeq) Seed() int64 {
+	var state [1]uint64
+	return int64(s.GenerateState(state[:])[0])
+}
+
+// NewXoshiro256ssRNG returns a thread unsafe xoshiro256** generator with all 256 bits of its state
+// drawn from the sequence
+func (s SeedSeq) NewXoshiro256ssRNG() *UnsafeXoshiro256ssRNG {
+	var state [4]uint64
+	return newXoshiro256ssFromState(s.GenerateState(state[:]))
+}
+
+// MarshalBinary encodes the sequence, so it can be checkpointed with encoding/gob
+func (s SeedSeq) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), s.key[:]...), nil
+}
+
+// UnmarshalBinary decodes a sequence encoded by MarshalBinary
+func (s *SeedSeq) UnmarshalBinary(data []byte) error {
+	if len(data) != len(s.key) {
+		return fmt.Errorf("seedseq needs %d bytes, got %d", len(s.key), len(data))
+	}
+	copy(s.key[:], data)
+	return nil
+}
+
+func seedSeqHash(domain string, parts ...[]byte) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	for _, p := range parts {
+		h.Write(p)
+	}
+	var digest [sha256.Size]byte
+	h.Sum(digest[:0])
+	return digest
+}
@@ -0,0 +1,47 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SeedSeq(t *testing.T) {
+	s := NewSeedSeq([]byte("experiment-42"))
+	assert.Equal(t, s, NewSeedSeq([]byte("experiment-42")))
+	assert.NotEqual(t, s, NewSeedSeq([]byte("experiment-43")))
+
+	state := s.GenerateState(make([]uint64, 9))
+	assert.Equal(t, state, s.GenerateState(make([]uint64, 9)))
+	assert.Equal(t, state[:3], s.GenerateState(make([]uint64, 3)))
+	assert.Equal(t, int64(state[0]), s.Seed())
+
+	r := s.NewXoshiro256ssRNG()
+	assert.Equal(t, UnsafeXoshiro256ssRNG{s0: state[0], s1: state[1], s2: state[2], s3: state[3]}, *r)
+}
+
+func Test_SeedSeq_Spawn(t *testing.T) {
+	s := NewSeedSeq([]byte("experiment-42"))
+	children := s.Spawn(4)
+	assert.Len(t, children, 4)
+	seeds := map[int64]bool{s.Seed(): true}
+	for i, c := range children {
+		assert.Equal(t, s.Child(uint64(i)), c)
+		seeds[c.Seed()] = true
+		seeds[c.Child(0).Seed()] = true
+	}
+	assert.Len(t, seeds, 9)
+}
+
+func Test_BeaconSeedSeq(t *testing.T) {
+	const randomness = "101297f1ca7dc44ef6088d94ad5fb7ba03455dc33d53ddb412bbc4564ed986ec"
+	s, err := BeaconSeedSeqHex(1000, randomness)
+	assert.NoError(t, err)
+	s2, _ := BeaconSeedSeqHex(1000, randomness)
+	assert.Equal(t, s, s2)
+	s3, _ := BeaconSeedSeqHex(1001, randomness)
+	assert.NotEqual(t, s, s3)
+
+	_, err = BeaconSeedSeqHex(1000, "not hex")
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package fastrand64
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// SeriesOptions configures SyntheticSeries. Each component is disabled by its zero value.
+type SeriesOptions struct {
+	Seed int64
+	// Intercept + Slope*t is the trend, ChangePointRate is the per step probability that the slope
+	// changes by Normal(0, ChangePointScale), giving a piecewise linear trend
+	Intercept        float64
+	Slope            float64
+	ChangePointRate  float64
+	ChangePointScale float64
+	// SeasonAmplitude*sin(2*pi*t/SeasonPeriod) is the seasonality
+	SeasonPeriod    int
+	SeasonAmplitude float64
+	// ARMA(p, q) noise x[t] = sum(AR[i]*x[t-1-i]) + e[t] + sum(MA[j]*e[t-1-j]), e ~ Normal(0, NoiseSigma)
+	AR         []float64
+	MA         []float64
+	NoiseSigma float64
+}
+
+// SyntheticSeries generates n points of trend + seasonality + ARMA noise,
+// the same options (including Seed) always produce the same series
+func SyntheticSeries(n int, opts SeriesOptions) []float64 {
+	r := NewUnsafeXoshiro256ssRNG(opts.Seed)
+
+	// burn in the ARMA recursion so the series starts near its stationary distribution
+	burnIn := 10 * (len(opts.AR) + len(opts.MA))
+	e := GaussianNoise(r, make([]float64, burnIn+n), opts.NoiseSigma)
+	x := make([]float64, burnIn+n)
+	for t := range x {
+		x[t] = e[t]
+		for i, a := range opts.AR {
+			if t-1-i >= 0 {
+				x[t] += a * x[t-1-i]
+			}
+		}
+		for j, m := range opts.MA {
+			if t-1-j >= 0 {
+				x[t] += m * e[t-1-j]
+			}
+		}
+	}
+
+	series := x[burnIn:]
+	level, slope := opts.Intercept, opts.Slope
+	var changes [1]float64
+	for t := range series {
+		if opts.ChangePointRate > 0 && unitFloat64(r) < opts.ChangePointRate {
+			slope += GaussianNoise(r, changes[:], opts.ChangePointScale)[0]
+		}
+		series[t] += level
+		level += slope
+		if opts.SeasonPeriod > 0 {
+			series[t] += opts.SeasonAmplitude * math.Sin(2*math.Pi*float64(t)/float64(opts.SeasonPeriod))
+		}
+	}
+	return series
+}
+
+// WriteSeriesCSV writes a series as "t,value" csv rows with a header
+func WriteSeriesCSV(w io.Writer, series []float64) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"t", "value"}); err != nil {
+		return err
+	}
+	for t, v := range series {
+		if err := cw.Write([]string{strconv.Itoa(t), strconv.FormatFloat(v, 'g', -1, 64)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,52 @@
+package fastrand64
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SyntheticSeries_Trend(t *testing.T) {
+	s := SyntheticSeries(100, SeriesOptions{Intercept: 10, Slope: 0.5, SeasonPeriod: 4, SeasonAmplitude: 2})
+	assert.InDelta(t, 10, s[0], 1e-12)
+	assert.InDelta(t, 10+0.5+2, s[1], 1e-12)
+	assert.InDelta(t, 10+1.0, s[2], 1e-12)
+	assert.InDelta(t, 10+1.5-2, s[3], 1e-12)
+}
+
+func Test_SyntheticSeries_ARMA(t *testing.T) {
+	opts := SeriesOptions{Seed: 3, AR: []float64{0.8}, MA: []float64{0.3}, NoiseSigma: 1}
+	s := SyntheticSeries(200000, opts)
+	assert.Equal(t, s[:100], SyntheticSeries(100, opts))
+
+	// lag 1 autocorrelation of ARMA(1,1) is (1+a*m)(a+m)/(1+2*a*m+m*m)
+	mean, variance := meanAndVariance(s)
+	cov := 0.0
+	for i := 1; i < len(s); i++ {
+		cov += (s[i] - mean) * (s[i-1] - mean)
+	}
+	rho := cov / float64(len(s)-1) / variance
+	assert.InDelta(t, (1+0.8*0.3)*(0.8+0.3)/(1+2*0.8*0.3+0.3*0.3), rho, 0.01)
+	// variance is (1+2*a*m+m*m)/(1-a*a) * sigma^2
+	assert.InDelta(t, (1+2*0.8*0.3+0.3*0.3)/(1-0.8*0.8), variance, 0.15)
+}
+
+func Test_SyntheticSeries_ChangePoints(t *testing.T) {
+	s := SyntheticSeries(1000, SeriesOptions{Seed: 1, ChangePointRate: 0.01, ChangePointScale: 1})
+	// with no noise the series is piecewise linear, so second differences are zero except at changes
+	changes := 0
+	for i := 2; i < len(s); i++ {
+		if d := s[i] - 2*s[i-1] + s[i-2]; d > 1e-9 || d < -1e-9 {
+			changes++
+		}
+	}
+	assert.Greater(t, changes, 0)
+	assert.Less(t, changes, 40)
+}
+
+func Test_WriteSeriesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSeriesCSV(&buf, []float64{1.5, -2}))
+	assert.Equal(t, "t,value\n0,1.5\n1,-2\n", buf.String())
+}
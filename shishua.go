@@ -0,0 +1,144 @@
+package fastrand64
+
+import "encoding/binary"
+
+// UnsafeShishuaRNG is Thomas Kim's SHISHUA, a generator built around SIMD that makes 128 bytes a
+// step. On amd64 with AVX2 it runs in assembly and Read fills buffers several times faster than any
+// scalar generator, elsewhere it runs a portable version of the same steps. Uint64 and Read take
+// consecutive bytes of the same output, which matches the reference prng_gen.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+// See https://github.com/espadrine/shishua
+type UnsafeShishuaRNG struct {
+	s   shishuaState
+	buf [shishuaBlock]byte // output not yet returned by Uint64
+	pos int
+}
+
+// shishuaState is the reference prng_state, the asm depends on the layout
+type shishuaState struct {
+	state   [16]uint64 // s0..s3, 4 lanes each
+	output  [16]uint64 // o0..o3
+	counter [4]uint64
+}
+
+// shishuaBlock is the size of the buffer Uint64 draws from, two steps
+const shishuaBlock = 256
+
+// shishuaPhi is the fractional part of the golden ratio, the state the seed is mixed into
+var shishuaPhi = [16]uint64{
+	0x9E3779B97F4A7C15, 0xF39CC0605CEDC834, 0x1082276BF3A27251, 0xF86C6A11D0C18E95,
+	0x2767F0B153D27B7F, 0x0347045B5BF1827F, 0x01886F0928403002, 0xC1D64BA40F335E36,
+	0xF06AD7AE9717877E, 0x85839D6EFFBD7DC6, 0x64D325D1C5371682, 0xCADD0CCCFDFFBBE1,
+	0x626E33B8D04B4331, 0xBBF73C790D94F79D, 0x471C4AB3ED3D82A5, 0xFEC507705E4AE6E5,
+}
+
+// shishuaGenGeneric is the reference prng_gen without SIMD, buf must be a multiple of 128 bytes
+func shishuaGenGeneric(s *shishuaState, buf []byte) {
+	var t, u [16]uint64
+	for i := 0; i+128 <= len(buf); i += 128 {
+		for j, o := range s.output {
+			binary.LittleEndian.PutUint64(buf[i+8*j:], o)
+		}
+		for k := 0; k < 4; k++ {
+			s.state[4+k] += s.counter[k]
+			s.state[12+k] += s.counter[k]
+			s.counter[k] += uint64(7 - 2*k)
+		}
+		for j := 0; j < 16; j += 4 {
+			// s0 and s2 shift by 1 and shuffle their 32 bit halves with shu0, s1 and s3 shift by 3
+			// and shuffle with shu1
+			shift, rot := uint(1), 2
+			if j%8 == 4 {
+				shift, rot = 3, 1
+			}
+			for k := 0; k < 4; k++ {
+				u[j+k] = s.state[j+k] >> shift
+				t[j+k] = s.state[j+(k+rot)&3]>>32 | s.state[j+(k+rot+1)&3]<<32
+			}
+		}
+		for k := 0; k < 16; k++ {
+			s.state[k] = t[k] + u[k]
+		}
+		for k := 0; k < 4; k++ {
+			s.output[k] = u[k] ^ t[4+k]
+			s.output[4+k] = u[8+k] ^ t[12+k]
+			s.output[8+k] = s.state[k] ^ s.state[12+k]
+			s.output[12+k] = s.state[8+k] ^ s.state[4+k]
+		}
+	}
+}
+
+// SetState seeds the generator exactly like the reference prng_init
+func (r *UnsafeShishuaRNG) SetState(seed [4]uint64) {
+	r.s = shishuaState{}
+	r.s.state = shishuaPhi
+	// the seed only touches even lanes, so half the state is fixed and no seed is a bad state
+	r.s.state[0] ^= seed[0]
+	r.s.state[2] ^= seed[1]
+	r.s.state[4] ^= seed[2]
+	r.s.state[6] ^= seed[3]
+	r.s.state[8] ^= seed[2]
+	r.s.state[10] ^= seed[3]
+	r.s.state[12] ^= seed[0]
+	r.s.state[14] ^= seed[1]
+	var buf [128]byte
+	for i := 0; i < 13; i++ {
+		shishuaGen(&r.s, buf[:])
+		for j := 0; j < 4; j++ {
+			copy(r.s.state[4*j:4*j+4], r.s.output[4*(3-j):4*(3-j)+4])
+		}
+	}
+	r.pos = len(r.buf)
+}
+
+// Seed takes a single int64 and runs it through splitmix64 to pick the four seed words
+func (r *UnsafeShishuaRNG) Seed(seed int64) {
+	var words [4]uint64
+	for i := range words {
+		words[i] = Splitmix64(uint64(seed) + uint64(i))
+	}
+	r.SetState(words)
+}
+
+// Uint64 generates a random uint64 from the next 8 output bytes, (not thread safe)
+func (r *UnsafeShishuaRNG) Uint64() uint64 {
+	if len(r.buf)-r.pos < 8 {
+		// a Read can leave a few bytes, they start the word so the output stays in order
+		var word [8]byte
+		n := copy(word[:], r.buf[r.pos:])
+		shishuaGen(&r.s, r.buf[:])
+		r.pos = copy(word[n:], r.buf[:])
+		return binary.LittleEndian.Uint64(word[:])
+	}
+	x := binary.LittleEndian.Uint64(r.buf[r.pos:])
+	r.pos += 8
+	return x
+}
+
+// Read fills p with the next output bytes, after any left over from Uint64, generating whole
+// blocks straight into p. It always returns len(p), nil
+func (r *UnsafeShishuaRNG) Read(p []byte) (int, error) {
+	n := copy(p, r.buf[r.pos:])
+	r.pos += n
+	rest := p[n:]
+	whole := len(rest) / shishuaBlock * shishuaBlock
+	shishuaGen(&r.s, rest[:whole])
+	if rest = rest[whole:]; len(rest) > 0 {
+		shishuaGen(&r.s, r.buf[:])
+		r.pos = copy(rest, r.buf[:])
+	}
+	return len(p), nil
+}
+
+// fillBytes makes Bytes the same as Read
+func (r *UnsafeShishuaRNG) fillBytes(bytes []byte) {
+	r.Read(bytes)
+}
+
+// NewUnsafeShishuaRNG creates a new Thread unsafe SHISHUA generator
+func NewUnsafeShishuaRNG(seed int64) *UnsafeShishuaRNG {
+	noteSeed(seed)
+	r := &UnsafeShishuaRNG{}
+	r.Seed(seed)
+	return r
+}
@@ -0,0 +1,14 @@
+package fastrand64
+
+// shishuaGenAVX2 is shishuaGenGeneric in AVX2, implemented in shishua_amd64.s
+//
+//go:noescape
+func shishuaGenAVX2(s *shishuaState, buf []byte)
+
+func shishuaGen(s *shishuaState, buf []byte) {
+	if hasAVX2 {
+		shishuaGenAVX2(s, buf)
+		return
+	}
+	shishuaGenGeneric(s, buf)
+}
@@ -0,0 +1,95 @@
+#include "textflag.h"
+
+// the shuffles as VPERMD indices, and the per lane counter increments
+DATA shishuaShu0<>+0(SB)/4, $5
+DATA shishuaShu0<>+4(SB)/4, $6
+DATA shishuaShu0<>+8(SB)/4, $7
+DATA shishuaShu0<>+12(SB)/4, $0
+DATA shishuaShu0<>+16(SB)/4, $1
+DATA shishuaShu0<>+20(SB)/4, $2
+DATA shishuaShu0<>+24(SB)/4, $3
+DATA shishuaShu0<>+28(SB)/4, $4
+GLOBL shishuaShu0<>(SB), RODATA|NOPTR, $32
+
+DATA shishuaShu1<>+0(SB)/4, $3
+DATA shishuaShu1<>+4(SB)/4, $4
+DATA shishuaShu1<>+8(SB)/4, $5
+DATA shishuaShu1<>+12(SB)/4, $6
+DATA shishuaShu1<>+16(SB)/4, $7
+DATA shishuaShu1<>+20(SB)/4, $0
+DATA shishuaShu1<>+24(SB)/4, $1
+DATA shishuaShu1<>+28(SB)/4, $2
+GLOBL shishuaShu1<>(SB), RODATA|NOPTR, $32
+
+DATA shishuaIncrement<>+0(SB)/8, $7
+DATA shishuaIncrement<>+8(SB)/8, $5
+DATA shishuaIncrement<>+16(SB)/8, $3
+DATA shishuaIncrement<>+24(SB)/8, $1
+GLOBL shishuaIncrement<>(SB), RODATA|NOPTR, $32
+
+// func shishuaGenAVX2(s *shishuaState, buf []byte)
+TEXT ·shishuaGenAVX2(SB), NOSPLIT, $0-32
+	MOVQ s+0(FP), AX
+	MOVQ buf_base+8(FP), DI
+	MOVQ buf_len+16(FP), CX
+
+	VMOVDQU 0(AX), Y0   // s0
+	VMOVDQU 32(AX), Y1  // s1
+	VMOVDQU 64(AX), Y2  // s2
+	VMOVDQU 96(AX), Y3  // s3
+	VMOVDQU 128(AX), Y4 // o0
+	VMOVDQU 160(AX), Y5 // o1
+	VMOVDQU 192(AX), Y6 // o2
+	VMOVDQU 224(AX), Y7 // o3
+	VMOVDQU 256(AX), Y8 // counter
+	VMOVDQU shishuaShu0<>(SB), Y9
+	VMOVDQU shishuaShu1<>(SB), Y10
+	VMOVDQU shishuaIncrement<>(SB), Y11
+
+loop:
+	CMPQ CX, $128
+	JB   done
+
+	VMOVDQU Y4, 0(DI)
+	VMOVDQU Y5, 32(DI)
+	VMOVDQU Y6, 64(DI)
+	VMOVDQU Y7, 96(DI)
+
+	VPADDQ Y8, Y1, Y1
+	VPADDQ Y8, Y3, Y3
+	VPADDQ Y11, Y8, Y8
+
+	VPSRLQ $1, Y0, Y12 // u0
+	VPSRLQ $3, Y1, Y13 // u1
+	VPSRLQ $1, Y2, Y14 // u2
+	VPSRLQ $3, Y3, Y15 // u3
+
+	VPERMD Y1, Y10, Y1  // t1
+	VPXOR  Y1, Y12, Y4  // o0 = u0 ^ t1
+	VPADDQ Y13, Y1, Y1  // s1 = t1 + u1
+	VPERMD Y3, Y10, Y3  // t3
+	VPXOR  Y3, Y14, Y5  // o1 = u2 ^ t3
+	VPADDQ Y15, Y3, Y3  // s3 = t3 + u3
+	VPERMD Y0, Y9, Y0   // t0
+	VPADDQ Y12, Y0, Y0  // s0 = t0 + u0
+	VPERMD Y2, Y9, Y2   // t2
+	VPADDQ Y14, Y2, Y2  // s2 = t2 + u2
+	VPXOR  Y0, Y3, Y6   // o2 = s0 ^ s3
+	VPXOR  Y2, Y1, Y7   // o3 = s2 ^ s1
+
+	ADDQ $128, DI
+	SUBQ $128, CX
+	JMP  loop
+
+done:
+	VMOVDQU Y0, 0(AX)
+	VMOVDQU Y1, 32(AX)
+	VMOVDQU Y2, 64(AX)
+	VMOVDQU Y3, 96(AX)
+	VMOVDQU Y4, 128(AX)
+	VMOVDQU Y5, 160(AX)
+	VMOVDQU Y6, 192(AX)
+	VMOVDQU Y7, 224(AX)
+	VMOVDQU Y8, 256(AX)
+	VZEROUPPER
+	RET
@@ -0,0 +1,8 @@
+//go:build !amd64
+// +build !amd64
+
+package fastrand64
+
+func shishuaGen(s *shishuaState, buf []byte) {
+	shishuaGenGeneric(s, buf)
+}
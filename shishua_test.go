@@ -0,0 +1,76 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeShishuaRNG_Uint64(t *testing.T) {
+	// prng_init(seed) then prng_gen of 512 bytes, from a C build of the reference AVX2 code
+	vectors := map[[4]uint64][]uint64{
+		{0, 0, 0, 0}: {0x53aab40ff9965d95, 0xe2097c3ae6822d09, 0x395a5aa7a5a4a52c, 0x2bcee75d12b468dc},
+		{1, 2, 3, 4}: {0x970efd6b4b3cfa60, 0xb80f58ecee77239c, 0x41a9fcaca6a22dc2, 0x6672e8d26c305f7f},
+	}
+	last := map[[4]uint64]uint64{{0, 0, 0, 0}: 0x8f93ec50a70354b0, {1, 2, 3, 4}: 0x595d7d96f898f1bd}
+	for seed, expected := range vectors {
+		rng := &UnsafeShishuaRNG{}
+		rng.SetState(seed)
+		for _, x := range expected {
+			assert.Equal(t, x, rng.Uint64())
+		}
+		for i := len(expected); i < 63; i++ {
+			rng.Uint64()
+		}
+		assert.Equal(t, last[seed], rng.Uint64())
+	}
+
+	rng1 := NewUnsafeShishuaRNG(1)
+	rng2 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeShishuaRNG(1) })
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+}
+
+func Test_UnsafeShishuaRNG_Read(t *testing.T) {
+	expected := make([]byte, 4096)
+	rng := NewUnsafeShishuaRNG(7)
+	for i := 0; i < len(expected); i += 8 {
+		binary.LittleEndian.PutUint64(expected[i:], rng.Uint64())
+	}
+
+	// any mix of Reads and Uint64s takes consecutive output bytes
+	rng = NewUnsafeShishuaRNG(7)
+	var got []byte
+	for _, n := range []int{8, 3, 600, 1, 0, 256, 1000} {
+		p := make([]byte, n)
+		m, err := rng.Read(p)
+		assert.NoError(t, err)
+		assert.Equal(t, n, m)
+		got = append(got, p...)
+		got = append(got, make([]byte, 8)...)
+		binary.LittleEndian.PutUint64(got[len(got)-8:], rng.Uint64())
+	}
+	assert.Equal(t, expected[:len(got)], got)
+
+	// Bytes and the pool use Read
+	rng = NewUnsafeShishuaRNG(7)
+	assert.Equal(t, expected[:1001], Bytes(rng, make([]byte, 1001)))
+	pool := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeShishuaRNG(7) })
+	assert.Equal(t, expected[:1001], pool.Bytes(1001))
+}
+
+func Test_shishuaGen_AVX2(t *testing.T) {
+	if !hasAVX2 {
+		t.Skip("no AVX2")
+	}
+	var generic, avx2 shishuaState
+	rng := NewUnsafeShishuaRNG(3)
+	generic, avx2 = rng.s, rng.s
+	p, q := make([]byte, 128*9), make([]byte, 128*9)
+	shishuaGenGeneric(&generic, p)
+	shishuaGen(&avx2, q)
+	assert.Equal(t, p, q)
+	assert.Equal(t, generic, avx2)
+}
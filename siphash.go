@@ -0,0 +1,110 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// UnsafeSipHashRNG is a stateless keyed generator, output i is SipHash-2-4 of the 64 bit counter i
+// under a 128 bit key. Any position can be generated directly with At, workers splitting a stream
+// just take disjoint counter ranges, and streams under different keys are as unrelated as the
+// outputs of a keyed PRF, so they can't correlate by accident the way nearby seeds of a weak
+// generator can. It is slower than the non cryptographic generators, and the key is the secret, a
+// stream gives no more protection than its key. It is unsafe to call UnsafeRNG methods from
+// concurrent goroutines.
+// See https://www.aumasson.jp/siphash/siphash.pdf
+type UnsafeSipHashRNG struct {
+	k0, k1 uint64
+	ctr    uint64
+}
+
+// sipHash24 is SipHash-2-4 of the 8 byte little endian message m
+func sipHash24(k0 uint64, k1 uint64, m uint64) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	// the message block, then the final block which is only the length, 8, in the top byte
+	v3 ^= m
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0 ^= m
+	v3 ^= 8 << 56
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0 ^= 8 << 56
+	v2 ^= 0xff
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// sipRound is one SipRound, small enough to inline
+func sipRound(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
+
+// SetKey sets the 16 byte SipHash key, in the reference byte order, and starts the counter at 0
+func (r *UnsafeSipHashRNG) SetKey(key [16]byte) {
+	r.SetState(binary.LittleEndian.Uint64(key[:8]), binary.LittleEndian.Uint64(key[8:]), 0)
+}
+
+// SetState sets the key halves and the counter of the next Uint64
+func (r *UnsafeSipHashRNG) SetState(k0 uint64, k1 uint64, ctr uint64) {
+	r.k0, r.k1, r.ctr = k0, k1, ctr
+}
+
+// Seed derives a key from the seed with splitmix64 and starts the counter at 0
+func (r *UnsafeSipHashRNG) Seed(seed int64) {
+	r.SetState(Splitmix64(uint64(seed)), Splitmix64(uint64(seed)+splitmix64Gamma), 0)
+}
+
+// Uint64 generates a random uint64, (not thread safe)
+func (r *UnsafeSipHashRNG) Uint64() uint64 {
+	x := sipHash24(r.k0, r.k1, r.ctr)
+	r.ctr++
+	return x
+}
+
+// At returns the value for counter, which is the value Uint64 returns after SetState(k0, k1, counter).
+// It doesn't change the generator's state, so it is safe to call concurrently
+func (r *UnsafeSipHashRNG) At(counter uint64) uint64 {
+	return sipHash24(r.k0, r.k1, counter)
+}
+
+// Jump skips the next n values in constant time
+func (r *UnsafeSipHashRNG) Jump(n uint64) {
+	r.ctr += n
+}
+
+// NewUnsafeSipHashRNG creates a new Thread unsafe SipHash keyed generator
+func NewUnsafeSipHashRNG(seed int64) *UnsafeSipHashRNG {
+	noteSeed(seed)
+	r := &UnsafeSipHashRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// NewUnsafeSipHashKeyRNG creates a new Thread unsafe SipHash generator with a 16 byte key
+func NewUnsafeSipHashKeyRNG(key [16]byte) *UnsafeSipHashRNG {
+	r := &UnsafeSipHashRNG{}
+	r.SetKey(key)
+	return r
+}
@@ -0,0 +1,35 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeSipHashRNG_Uint64(t *testing.T) {
+	// the reference 64 bit vector for the key 00..0f and the 8 byte message 00..07
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	rng := NewUnsafeSipHashKeyRNG(key)
+	assert.Equal(t, uint64(0x93f5f5799a932462), rng.At(0x0706050403020100))
+
+	rng.SetState(1, 2, 0)
+	assert.Equal(t, []uint64{0xda6a5e17da3b77b0, 0xe4c64f414a98bee4, 0x2bae290b9c1e8c64},
+		[]uint64{rng.Uint64(), rng.Uint64(), rng.Uint64()})
+
+	// At and Jump agree with the sequence, and At leaves it alone
+	rng = NewUnsafeSipHashRNG(7)
+	seq := make([]uint64, 10)
+	for i := range seq {
+		seq[i] = rng.Uint64()
+	}
+	rng = NewUnsafeSipHashRNG(7)
+	assert.Equal(t, seq[9], rng.At(9))
+	assert.Equal(t, seq[0], rng.Uint64())
+	rng.Jump(4)
+	assert.Equal(t, seq[5], rng.Uint64())
+
+	assert.NotEqual(t, NewUnsafeSipHashRNG(1).Uint64(), NewUnsafeSipHashRNG(2).Uint64())
+}
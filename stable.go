@@ -0,0 +1,198 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+)
+
+// Stable is the alpha-stable distribution S(alpha, beta, scale, loc) in the Samorodnitsky-Taqqu
+// (S1) parameterization, for heavy tailed simulations (network traffic, finance).
+// alpha = 2 is Normal(loc, 2*scale^2), alpha = 1 with beta = 0 is Cauchy.
+// The mean is loc for alpha > 1 and undefined otherwise, the variance is infinite for alpha < 2.
+type Stable struct {
+	alpha, beta, scale, loc float64
+	b, s                    float64 // precomputed B and S terms of Chambers-Mallows-Stuck
+}
+
+// NewStable validates 0 < alpha <= 2, -1 <= beta <= 1, scale > 0
+func NewStable(alpha float64, beta float64, scale float64, loc float64) (*Stable, error) {
+	if !(alpha > 0 && alpha <= 2) {
+		return nil, errors.New("Stable alpha must be in (0, 2]")
+	}
+	if !(beta >= -1 && beta <= 1) {
+		return nil, errors.New("Stable beta must be in [-1, 1]")
+	}
+	if !(scale > 0) {
+		return nil, errors.New("Stable scale must be > 0")
+	}
+	d := &Stable{alpha: alpha, beta: beta, scale: scale, loc: loc}
+	if alpha != 1 {
+		t := beta * math.Tan(math.Pi*alpha/2)
+		d.b = math.Atan(t) / alpha
+		d.s = math.Pow(1+t*t, 1/(2*alpha))
+	}
+	return d, nil
+}
+
+// Sample draws with the Chambers-Mallows-Stuck method
+// See: Weron, "On the Chambers-Mallows-Stuck method for simulating skewed stable random variables"
+func (d *Stable) Sample(r UnsafeRNG) float64 {
+	v := math.Pi * (unitFloat64(r) - 0.5)
+	w := exponential(r)
+	if d.alpha == 1 {
+		x := (2 / math.Pi) * ((math.Pi/2+d.beta*v)*math.Tan(v) -
+			d.beta*math.Log((math.Pi/2*w*math.Cos(v))/(math.Pi/2+d.beta*v)))
+		return d.scale*x + d.shiftedLoc()
+	}
+	a := d.alpha * (v + d.b)
+	x := d.s * math.Sin(a) / math.Pow(math.Cos(v), 1/d.alpha) *
+		math.Pow(math.Cos(v-a)/w, (1-d.alpha)/d.alpha)
+	return d.scale*x + d.loc
+}
+
+// shiftedLoc is where scale * the standard S1 variable is centered, in the S1 parameterization it
+// differs from loc when alpha = 1 and beta != 0
+func (d *Stable) shiftedLoc() float64 {
+	if d.alpha == 1 {
+		return d.loc + (2/math.Pi)*d.beta*d.scale*math.Log(d.scale)
+	}
+	return d.loc
+}
+
+// Quantile returns the inverse CDF. The normal, Cauchy and Lévy special cases are closed form, the
+// general case numerically inverts Nolan's integral form of the CDF, which is slow but accurate
+// See: Nolan, "Numerical calculation of stable densities and distribution functions"
+func (d *Stable) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	shift := d.shiftedLoc()
+	switch {
+	case d.alpha == 2:
+		return shift + d.scale*math.Sqrt2*normalQuantile(p)
+	case d.alpha == 1 && d.beta == 0:
+		return shift + d.scale*math.Tan(math.Pi*(p-0.5))
+	case d.alpha == 0.5 && d.beta == 1:
+		return shift + d.scale*levyQuantile(p)
+	case d.alpha == 0.5 && d.beta == -1:
+		return shift - d.scale*levyQuantile(1-p)
+	}
+	// totally skewed with alpha < 1 is bounded on one side
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if d.alpha < 1 && d.beta == 1 {
+		lower = 0
+	}
+	if d.alpha < 1 && d.beta == -1 {
+		upper = 0
+	}
+	switch p {
+	case 0:
+		return shift + d.scale*lower
+	case 1:
+		return shift + d.scale*upper
+	}
+	cdf := func(x float64) float64 { return stableCDF(x, d.alpha, d.beta) }
+	lo, hi := math.Max(lower, -1), math.Min(upper, 1)
+	return shift + d.scale*invertCDF(cdf, p, lo, hi)
+}
+
+// stableCDF is the CDF of the standard S1(alpha, beta, 1, 0) distribution
+func stableCDF(x float64, alpha float64, beta float64) float64 {
+	if alpha == 1 {
+		switch {
+		case beta == 0:
+			return 0.5 + math.Atan(x)/math.Pi
+		case beta < 0:
+			return 1 - stableCDF(-x, alpha, -beta)
+		}
+		logScale := -math.Pi*x/(2*beta) + math.Log(2/math.Pi)
+		integral := integrateStable(func(theta float64) float64 {
+			a := math.Pi/2 + beta*theta
+			return logScale + math.Log(a/math.Cos(theta)) + a*math.Tan(theta)/beta
+		}, -math.Pi/2, math.Pi/2)
+		return clamp01(integral / math.Pi)
+	}
+
+	theta0 := math.Atan(beta*math.Tan(math.Pi*alpha/2)) / alpha
+	switch {
+	case x == 0:
+		return (math.Pi/2 - theta0) / math.Pi
+	case x < 0:
+		return 1 - stableCDF(-x, alpha, -beta)
+	}
+	c1 := 1.0
+	if alpha < 1 {
+		c1 = (math.Pi/2 - theta0) / math.Pi
+	}
+	// near alpha = 1 the exponent e is huge, so g is only representable by its log
+	e := alpha / (alpha - 1)
+	logScale := e*math.Log(x) + math.Log(math.Cos(alpha*theta0))/(alpha-1)
+	integral := integrateStable(func(theta float64) float64 {
+		return logScale + e*math.Log(math.Cos(theta)/math.Sin(alpha*(theta0+theta))) +
+			math.Log(math.Cos(alpha*theta0+(alpha-1)*theta)/math.Cos(theta))
+	}, -theta0, math.Pi/2)
+	return clamp01(c1 + math.Copysign(1, 1-alpha)*integral/math.Pi)
+}
+
+// integrateStable integrates exp(-g) over [a, b] given log g for Nolan's g, which is monotone in
+// theta and goes from 0 to infinity (or back) across the interval. Near alpha = 1 the integrand is
+// almost a step, so the interval is split where g = 1 to let the quadrature see it
+func integrateStable(logG func(float64) float64, a float64, b float64) float64 {
+	f := func(theta float64) float64 {
+		x := logG(theta)
+		if math.IsNaN(x) {
+			// only happens at the ends, where g is unbounded
+			return 0
+		}
+		return math.Exp(-math.Exp(x))
+	}
+	lo, hi := a, b
+	rising := f(a+(b-a)*1e-9) > f(b-(b-a)*1e-9)
+	for i := 0; i < 100; i++ {
+		mid := lo + (hi-lo)/2
+		if (f(mid) > math.Exp(-1)) == rising {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return integrate(f, a, lo) + integrate(f, lo, b)
+}
+
+func clamp01(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
+
+// Levy is the Lévy distribution, the stable distribution with alpha = 1/2 and beta = 1, supported
+// on (loc, inf). Its mean and variance are infinite.
+type Levy struct {
+	loc, scale float64
+}
+
+// NewLevy validates scale > 0
+func NewLevy(loc float64, scale float64) (*Levy, error) {
+	if !(scale > 0) {
+		return nil, errors.New("Levy scale must be > 0")
+	}
+	return &Levy{loc: loc, scale: scale}, nil
+}
+
+// Quantile returns the inverse CDF, loc + scale/(2*erfcinv(p)^2)
+func (d *Levy) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return d.loc + d.scale*levyQuantile(p)
+}
+
+// levyQuantile is the inverse CDF of the standard Lévy distribution
+func levyQuantile(p float64) float64 {
+	e := math.Erfcinv(p)
+	return 1 / (2 * e * e)
+}
+
+// Sample draws loc + scale/Z^2 for a standard normal Z
+func (d *Levy) Sample(r UnsafeRNG) float64 {
+	z := normal(r)
+	return d.loc + d.scale/(z*z)
+}
@@ -0,0 +1,125 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sampleQuantiles draws n samples of d and returns the requested empirical quantiles
+func sampleQuantiles(d Distribution, n int, ps ...float64) []float64 {
+	// seeded, so the quantile checks are deterministic
+	r := NewUnsafeXoshiro256ssRNG(1)
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = d.Sample(r)
+	}
+	sort.Float64s(xs)
+	qs := make([]float64, len(ps))
+	for i, p := range ps {
+		qs[i] = xs[int(p*float64(n))]
+	}
+	return qs
+}
+
+func Test_Stable_Normal(t *testing.T) {
+	d, err := NewStable(2, 0, 1.5, 3)
+	assert.NoError(t, err)
+	xs := NewSyncPoolXoshiro256ssRNG().FillDist(d, make([]float64, 100000))
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 3, mean, 0.05)
+	assert.InDelta(t, 2*1.5*1.5, variance, 0.15)
+}
+
+func Test_Stable_Cauchy(t *testing.T) {
+	d, err := NewStable(1, 0, 2, -1)
+	assert.NoError(t, err)
+	// Cauchy quartiles are loc -/+ scale
+	assert.InDeltaSlice(t, []float64{-3, -1, 1}, sampleQuantiles(d, 100000, 0.25, 0.5, 0.75), 0.05)
+}
+
+func Test_Stable_HeavyTailedMean(t *testing.T) {
+	// for alpha > 1 the mean is loc, even with skew. The variance is infinite, so compare the
+	// median of batch means, which is far less noisy than one big sample mean
+	d, err := NewStable(1.8, 0.5, 1, 2)
+	assert.NoError(t, err)
+	rng := NewSyncPoolXoshiro256ssRNG()
+	means := make([]float64, 41)
+	for i := range means {
+		means[i], _ = meanAndVariance(rng.FillDist(d, make([]float64, 10000)))
+	}
+	sort.Float64s(means)
+	assert.InDelta(t, 2, means[len(means)/2], 0.1)
+
+	// totally skewed to the right with alpha < 1 means support on (loc, inf)
+	d, err = NewStable(0.5, 1, 1, 0)
+	assert.NoError(t, err)
+	assert.Greater(t, sampleQuantiles(d, 10000, 0.001)[0], 0.0)
+}
+
+func Test_Stable_Validation(t *testing.T) {
+	for _, params := range [][4]float64{{0, 0, 1, 0}, {2.1, 0, 1, 0}, {1.5, 1.1, 1, 0}, {1.5, 0, 0, 0}, {math.NaN(), 0, 1, 0}} {
+		d, err := NewStable(params[0], params[1], params[2], params[3])
+		assert.Error(t, err)
+		assert.Nil(t, d)
+	}
+}
+
+func Test_Levy(t *testing.T) {
+	d, err := NewLevy(1, 2)
+	assert.NoError(t, err)
+	// the median is loc + scale/(2*erfcinv(0.5)^2)
+	median := 1 + 2/(2*math.Pow(math.Erfcinv(0.5), 2))
+	assert.InEpsilon(t, median, sampleQuantiles(d, 100000, 0.5)[0], 0.03)
+
+	// the Lévy distribution is Stable(1/2, 1)
+	s, _ := NewStable(0.5, 1, 2, 1)
+	assert.InEpsilon(t, median, sampleQuantiles(s, 100000, 0.5)[0], 0.03)
+
+	_, err = NewLevy(0, -1)
+	assert.Error(t, err)
+}
+
+func Test_Stable_CDF(t *testing.T) {
+	for _, x := range []float64{0.1, 0.5, 1, 3, 20} {
+		// the general integral matches the closed form normal, Lévy and Cauchy cases
+		assert.InDelta(t, 0.5*math.Erfc(-x/2), stableCDF(x, 2, 0), 1e-9)
+		assert.InDelta(t, 0.5*math.Erfc(x/2), stableCDF(-x, 2, 0), 1e-9)
+		assert.InDelta(t, math.Erfc(math.Sqrt(1/(2*x))), stableCDF(x, 0.5, 1), 1e-7)
+		assert.Equal(t, 0.0, stableCDF(-x, 0.5, 1))
+		for _, alpha := range []float64{0.999, 1.001} {
+			assert.InDelta(t, 0.5+math.Atan(x)/math.Pi, stableCDF(x, alpha, 0), 1e-3)
+		}
+	}
+}
+
+func Test_Stable_Quantile(t *testing.T) {
+	normal, _ := NewStable(2, 0, 1.5, 3)
+	assert.InDelta(t, 3, normal.Quantile(0.5), 1e-12)
+	assert.InDelta(t, 3+1.5*math.Sqrt2*1.959963984540054, normal.Quantile(0.975), 1e-9)
+
+	levy, _ := NewLevy(1, 2)
+	stableLevy, _ := NewStable(0.5, 1, 2, 1)
+	for _, p := range []float64{0.1, 0.5, 0.9} {
+		assert.InDelta(t, levy.Quantile(p), stableLevy.Quantile(p), 1e-9)
+	}
+	assert.Equal(t, 1.0, stableLevy.Quantile(0))
+
+	// the general case round trips through the CDF, and matches sampled quantiles
+	for _, params := range [][4]float64{{1.5, 0.5, 1, 0}, {1, 0.8, 3, 0}, {0.3, -1, 1, 0}} {
+		d, err := NewStable(params[0], params[1], params[2], params[3])
+		assert.NoError(t, err)
+		for _, x := range []float64{-2, -0.5, 0.5, 2} {
+			if p := stableCDF(x, params[0], params[1]); p > 0 && p < 1 {
+				assert.InDelta(t, x, (d.Quantile(p)-d.shiftedLoc())/d.scale, 1e-6, params)
+			}
+		}
+		sampled := sampleQuantiles(d, 100000, 0.25, 0.5, 0.75)
+		for i, p := range []float64{0.25, 0.5, 0.75} {
+			expected := d.Quantile(p)
+			assert.InDelta(t, expected, sampled[i], 0.05*math.Max(params[2], math.Abs(expected)), params)
+		}
+	}
+}
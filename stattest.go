@@ -0,0 +1,192 @@
+package fastrand64
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// StatTestResult is the outcome of one test of the statistical battery
+type StatTestResult struct {
+	Name      string
+	Statistic float64
+	PValue    float64 // the chance of a result at least this extreme from an ideal generator
+}
+
+// Passed reports whether the p-value is at least alpha. Even an ideal generator fails a test with
+// probability alpha, so pick a small alpha, eg 1e-4, and a fixed seed for CI gates
+func (t StatTestResult) Passed(alpha float64) bool {
+	return t.PValue >= alpha
+}
+
+// MinStatTestWords is the fewest words RunStatTests accepts, smaller samples make the chi-square
+// tests unreliable
+const MinStatTestWords = 1 << 12
+
+// RunStatTests runs a quick battery of statistical tests over the next words Uint64s of r, to
+// catch broken generators, eg a custom UnsafeRNG with weak low bits or correlated outputs. Passing
+// doesn't prove a generator good, use TestU01 or PractRand for that.
+func RunStatTests(r UnsafeRNG, words int) ([]StatTestResult, error) {
+	if words < MinStatTestWords {
+		return nil, fmt.Errorf("statistical tests need at least %d words, got %d", MinStatTestWords, words)
+	}
+	xs := make([]uint64, words)
+	for i := range xs {
+		xs[i] = r.Uint64()
+	}
+	return []StatTestResult{
+		monobitTest(xs),
+		bitPositionTest(xs),
+		byteFrequencyTest(xs),
+		runsTest(xs),
+		serialPairsTest(xs),
+		uniformKSTest(xs),
+		lag1CorrelationTest(xs),
+	}, nil
+}
+
+// chiSquarePValue is the upper tail of the chi-square distribution with df degrees of freedom
+func chiSquarePValue(stat float64, df int) float64 {
+	return 1 - gammaP(float64(df)/2, stat/2)
+}
+
+// normalPValue is the two sided p-value of a standard normal z
+func normalPValue(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// monobitTest checks the overall proportion of one bits
+func monobitTest(xs []uint64) StatTestResult {
+	ones := 0
+	for _, x := range xs {
+		ones += bits.OnesCount64(x)
+	}
+	n := float64(64 * len(xs))
+	z := (float64(ones) - n/2) / math.Sqrt(n/4)
+	return StatTestResult{Name: "monobit", Statistic: z, PValue: normalPValue(z)}
+}
+
+// bitPositionTest checks every bit position is balanced on its own, catching weak low bits
+func bitPositionTest(xs []uint64) StatTestResult {
+	var ones [64]int
+	for _, x := range xs {
+		for b := range ones {
+			ones[b] += int(x >> uint(b) & 1)
+		}
+	}
+	n := float64(len(xs))
+	stat := 0.0
+	for _, c := range ones {
+		z := (float64(c) - n/2) / math.Sqrt(n/4)
+		stat += z * z
+	}
+	return StatTestResult{Name: "bit-positions", Statistic: stat, PValue: chiSquarePValue(stat, 64)}
+}
+
+// byteFrequencyTest checks all 256 byte values are equally common
+func byteFrequencyTest(xs []uint64) StatTestResult {
+	var counts [256]int
+	for _, x := range xs {
+		for i := 0; i < 8; i++ {
+			counts[byte(x>>(8*uint(i)))]++
+		}
+	}
+	stat := chiSquare(counts[:], float64(8*len(xs))/256)
+	return StatTestResult{Name: "bytes", Statistic: stat, PValue: chiSquarePValue(stat, 255)}
+}
+
+// runsTest is the NIST runs test over the bit stream, low bits of each word first
+func runsTest(xs []uint64) StatTestResult {
+	ones, transitions := 0, 0
+	prev := xs[0] & 1
+	for _, x := range xs {
+		ones += bits.OnesCount64(x)
+		// bit i differs from bit i-1, and bit 0 from the previous word's bit 63
+		transitions += bits.OnesCount64(x ^ (x<<1 | prev))
+		prev = x >> 63
+	}
+	n := float64(64 * len(xs))
+	pi := float64(ones) / n
+	v := float64(transitions) + 1
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(n) {
+		// too unbalanced for the runs test to mean anything, monobit will fail too
+		return StatTestResult{Name: "runs", Statistic: v, PValue: 0}
+	}
+	z := (v - 2*n*pi*(1-pi)) / (2 * math.Sqrt(2*n) * pi * (1 - pi))
+	return StatTestResult{Name: "runs", Statistic: v, PValue: math.Erfc(math.Abs(z))}
+}
+
+// serialPairsTest checks the top 4 bits, and the bottom 4 bits, of consecutive words are
+// independent, the bottom bits of plain LCGs repeat with short periods
+func serialPairsTest(xs []uint64) StatTestResult {
+	var high, low [256]int
+	for i := 0; i+1 < len(xs); i += 2 {
+		high[xs[i]>>60<<4|xs[i+1]>>60]++
+		low[xs[i]&15<<4|xs[i+1]&15]++
+	}
+	expected := float64(len(xs)/2) / 256
+	stat := chiSquare(high[:], expected) + chiSquare(low[:], expected)
+	return StatTestResult{Name: "serial-pairs", Statistic: stat, PValue: chiSquarePValue(stat, 510)}
+}
+
+// uniformKSTest is a Kolmogorov-Smirnov test of the words as floats in [0,1), on up to 100000 words
+func uniformKSTest(xs []uint64) StatTestResult {
+	n := len(xs)
+	if n > 100000 {
+		n = 100000
+	}
+	us := make([]float64, n)
+	for i := range us {
+		us[i] = float64(xs[i]>>11) / (1 << 53)
+	}
+	sort.Float64s(us)
+	d := 0.0
+	for i, u := range us {
+		d = math.Max(d, math.Max(float64(i+1)/float64(n)-u, u-float64(i)/float64(n)))
+	}
+	sqrtN := math.Sqrt(float64(n))
+	return StatTestResult{Name: "ks-uniform", Statistic: d, PValue: kolmogorovQ((sqrtN + 0.12 + 0.11/sqrtN) * d)}
+}
+
+// lag1CorrelationTest checks consecutive words as floats are uncorrelated
+func lag1CorrelationTest(xs []uint64) StatTestResult {
+	num, den := 0.0, 0.0
+	prev := float64(xs[0]>>11)/(1<<53) - 0.5
+	den += prev * prev
+	for _, x := range xs[1:] {
+		u := float64(x>>11)/(1<<53) - 0.5
+		num += prev * u
+		den += u * u
+		prev = u
+	}
+	rho := num / den
+	z := rho * math.Sqrt(float64(len(xs)))
+	return StatTestResult{Name: "lag1-correlation", Statistic: rho, PValue: normalPValue(z)}
+}
+
+func chiSquare(counts []int, expected float64) float64 {
+	stat := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		stat += d * d / expected
+	}
+	return stat
+}
+
+// kolmogorovQ is the upper tail of the Kolmogorov distribution
+func kolmogorovQ(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1
+	}
+	q, sign := 0.0, 1.0
+	for k := 1; k <= 100; k++ {
+		term := sign * math.Exp(-2*float64(k*k)*lambda*lambda)
+		q += term
+		if math.Abs(term) < 1e-12 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0, math.Min(1, 2*q))
+}
@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counterRNG is a terrible generator for the battery to catch
+type counterRNG struct{ x uint64 }
+
+func (c *counterRNG) Uint64() uint64 {
+	c.x++
+	return c.x
+}
+
+// lcgRNG returns its whole state, the low bits have tiny periods
+type lcgRNG struct{ x uint64 }
+
+func (l *lcgRNG) Uint64() uint64 {
+	l.x = l.x*6364136223846793005 + 1442695040888963407
+	return l.x
+}
+
+func Test_RunStatTests(t *testing.T) {
+	for _, a := range Algorithms() {
+		results, err := RunStatTests(a.New(1), 1<<16)
+		assert.NoError(t, err)
+		assert.Len(t, results, 7)
+		for _, res := range results {
+			assert.True(t, res.Passed(1e-6), a.Name, res)
+		}
+	}
+
+	failed := func(r UnsafeRNG) []string {
+		results, err := RunStatTests(r, 1<<16)
+		assert.NoError(t, err)
+		var names []string
+		for _, res := range results {
+			if !res.Passed(1e-6) {
+				names = append(names, res.Name)
+			}
+		}
+		return names
+	}
+	assert.Subset(t, failed(&counterRNG{}), []string{"monobit", "bit-positions", "bytes", "ks-uniform"})
+	assert.Contains(t, failed(&lcgRNG{x: 1}), "serial-pairs")
+
+	_, err := RunStatTests(&counterRNG{}, MinStatTestWords-1)
+	assert.Error(t, err)
+}
+
+func Test_kolmogorovQ(t *testing.T) {
+	// the 5% and 1% critical values of the Kolmogorov distribution
+	assert.InDelta(t, 0.05, kolmogorovQ(1.3581), 1e-4)
+	assert.InDelta(t, 0.01, kolmogorovQ(1.6276), 1e-4)
+	assert.Equal(t, 1.0, kolmogorovQ(0))
+}
@@ -0,0 +1,59 @@
+package fastrand64
+
+// StratifiedUniforms fills dst with one uniform from each of len(dst) equal strata of [0..1),
+// dst[i] is in [i/n, (i+1)/n). Averages over stratified uniforms have lower variance than over
+// independent ones, map them through Distribution.Quantile to stratify any distribution.
+func StratifiedUniforms(r UnsafeRNG, dst []float64) []float64 {
+	n := float64(len(dst))
+	for i := range dst {
+		dst[i] = (float64(i) + unitFloat64(r)) / n
+	}
+	return dst
+}
+
+// StratifiedUniforms returns one uniform from each of strata equal strata of [0..1), in stratum order
+func (s *ThreadsafePoolRNG) StratifiedUniforms(strata int) []float64 {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	u := StratifiedUniforms(r, make([]float64, strata))
+	rngPool.Put(r)
+	return u
+}
+
+// StratifiedSample returns n samples of d, one from each of n equal probability strata, in
+// ascending order
+func (s *ThreadsafePoolRNG) StratifiedSample(d Distribution, n int) []float64 {
+	xs := s.StratifiedUniforms(n)
+	for i, u := range xs {
+		xs[i] = d.Quantile(u)
+	}
+	return xs
+}
+
+// ImportanceSample draws n samples from proposal and returns them with self normalized importance
+// weights targetPDF(x)/proposalPDF(x), scaled to sum to 1, so sum(weights[i]*f(xs[i])) estimates
+// the expectation of f under the target. The densities only need to be known up to a constant.
+func (s *ThreadsafePoolRNG) ImportanceSample(proposal Distribution, targetPDF func(float64) float64,
+	proposalPDF func(float64) float64, n int) ([]float64, []float64) {
+	xs := s.FillDist(proposal, make([]float64, n))
+	weights := make([]float64, n)
+	total := 0.0
+	for i, x := range xs {
+		weights[i] = targetPDF(x) / proposalPDF(x)
+		total += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return xs, weights
+}
+
+// EffectiveSampleSize is Kish's 1/sum(w^2) for weights summing to 1, when it is much smaller than
+// the number of samples a few samples dominate and the proposal is a poor fit
+func EffectiveSampleSize(weights []float64) float64 {
+	sum := 0.0
+	for _, w := range weights {
+		sum += w * w
+	}
+	return 1 / sum
+}
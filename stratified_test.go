@@ -0,0 +1,77 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_StratifiedUniforms(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	u := rng.StratifiedUniforms(100)
+	assert.Len(t, u, 100)
+	for i, x := range u {
+		assert.True(t, x >= float64(i)/100 && x < float64(i+1)/100)
+	}
+
+	// the stratified estimate of E[X^2] for uniform X has far lower variance than the plain one
+	stratified := make([]float64, 200)
+	plain := make([]float64, 200)
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := range stratified {
+		for _, x := range rng.StratifiedUniforms(50) {
+			stratified[i] += x * x / 50
+		}
+		for j := 0; j < 50; j++ {
+			x := unitFloat64(r)
+			plain[i] += x * x / 50
+		}
+	}
+	stratifiedMean, stratifiedVariance := meanAndVariance(stratified)
+	_, plainVariance := meanAndVariance(plain)
+	assert.InDelta(t, 1.0/3, stratifiedMean, 0.001)
+	assert.Less(t, stratifiedVariance*100, plainVariance)
+}
+
+func Test_SafeRNG_StratifiedSample(t *testing.T) {
+	d, _ := NewGumbel(0, 1)
+	xs := NewSyncPoolXoshiro256ssRNG().StratifiedSample(d, 1000)
+	for i := 1; i < len(xs); i++ {
+		assert.Less(t, xs[i-1], xs[i])
+	}
+	mean, _ := meanAndVariance(xs)
+	assert.InDelta(t, eulerGamma, mean, 0.01)
+}
+
+func Test_SafeRNG_ImportanceSample(t *testing.T) {
+	// P(X > 10) for X ~ Exp(1) is e^-10, far too rare to estimate with 10000 plain samples
+	proposal, _ := NewGamma(1, 10)
+	// a fresh pool's first checkout is this seeded generator, so the estimate is deterministic
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	xs, weights := rng.ImportanceSample(proposal,
+		func(x float64) float64 { return math.Exp(-x) },
+		func(x float64) float64 { return 0.1 * math.Exp(-0.1*x) },
+		10000)
+	assert.InDelta(t, 1, sumFloat64s(weights), 1e-9)
+	estimate := 0.0
+	for i, x := range xs {
+		if x > 10 {
+			estimate += weights[i]
+		}
+	}
+	assert.InEpsilon(t, math.Exp(-10), estimate, 0.15)
+	ess := EffectiveSampleSize(weights)
+	assert.True(t, ess > 100 && ess < 10000)
+
+	assert.InDelta(t, 4, EffectiveSampleSize([]float64{0.25, 0.25, 0.25, 0.25}), 1e-12)
+	assert.InDelta(t, 1, EffectiveSampleSize([]float64{1, 0, 0, 0}), 1e-12)
+}
+
+func sumFloat64s(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
@@ -0,0 +1,212 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// writeRandomChunk is the buffer size WriteRandom streams through
+const writeRandomChunk = 32 * 1024
+
+// WriteRandom writes n random bytes from a thread unsafe RNG to w, in chunks through a single
+// buffer, returning the number of bytes written. The bytes are those of a RandomStream, the same
+// as Bytes filling one n byte slice, so 8*n bytes are the next n words of r. A negative n returns
+// an error
+func WriteRandom(w io.Writer, r UnsafeRNG, n int64) (int64, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("WriteRandom n %d must be >= 0", n)
+	}
+	return NewStreamRNG(r, n).WriteTo(w)
+}
+
+// WriteRandom writes n random bytes to w using a single pool checkout
+func (s *ThreadsafePoolRNG) WriteRandom(w io.Writer, n int64) (int64, error) {
+	rngPool := s.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	written, err := WriteRandom(w, r, n)
+	rngPool.Put(r)
+	return written, err
+}
+
+// RandomStream is n random bytes as an io.Reader that also implements io.WriterTo, so
+// io.Copy(dst, stream) writes straight from its internal chunk buffer, with no allocation per
+// chunk, which makes multi GB test files cheap. io.Copy prefers the source's WriterTo to the
+// destination's ReaderFrom. The bytes are the same however they are read, the same as Bytes
+// filling one n byte slice. NOT threadsafe.
+type RandomStream struct {
+	r         UnsafeRNG
+	rngPool   *sync.Pool
+	remaining int64
+	buf       []byte
+	pos       int
+}
+
+// NewStreamRNG returns a stream of n random bytes from a thread unsafe RNG, which the stream owns.
+// It panics if n < 0
+func NewStreamRNG(r UnsafeRNG, n int64) *RandomStream {
+	if n < 0 {
+		panic(fmt.Sprintf("RandomStream n %d must be >= 0", n))
+	}
+	size := int64(writeRandomChunk)
+	if n < size {
+		// whole words, so chunks line up with Bytes
+		size = (n + 7) &^ 7
+	}
+	buf := make([]byte, size)
+	return &RandomStream{r: r, remaining: n, buf: buf, pos: len(buf)}
+}
+
+// NewStream returns a stream of n random bytes from a generator checked out of the pool, which is
+// checked back in once the stream is exhausted or closed. It panics if n < 0
+func (s *ThreadsafePoolRNG) NewStream(n int64) *RandomStream {
+	if n < 0 {
+		panic(fmt.Sprintf("RandomStream n %d must be >= 0", n))
+	}
+	rngPool := s.pool()
+	stream := NewStreamRNG(rngPool.Get().(UnsafeRNG), n)
+	stream.rngPool = rngPool
+	stream.checkIn()
+	return stream
+}
+
+// Len returns the number of bytes left in the stream
+func (s *RandomStream) Len() int64 {
+	return s.remaining
+}
+
+// Read reads up to len(p) bytes, it returns io.EOF once the stream is exhausted
+func (s *RandomStream) Read(p []byte) (int, error) {
+	if s.remaining == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > s.remaining {
+		p = p[:s.remaining]
+	}
+	n := 0
+	for n < len(p) {
+		m := copy(p[n:], s.chunk())
+		s.pos += m
+		n += m
+	}
+	s.remaining -= int64(n)
+	s.checkIn()
+	return n, nil
+}
+
+// WriteTo writes the rest of the stream to w
+func (s *RandomStream) WriteTo(w io.Writer) (int64, error) {
+	written := int64(0)
+	for s.remaining > 0 {
+		chunk := s.chunk()
+		if int64(len(chunk)) > s.remaining {
+			chunk = chunk[:s.remaining]
+		}
+		m, err := w.Write(chunk)
+		s.pos += m
+		s.remaining -= int64(m)
+		written += int64(m)
+		if err == nil && m < len(chunk) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+	s.checkIn()
+	return written, nil
+}
+
+// chunk returns the unread part of the buffer, refilling it when it is all read
+func (s *RandomStream) chunk() []byte {
+	if s.pos == len(s.buf) {
+		// not Bytes, which draws a word past the end of every slice
+		for i := 0; i < len(s.buf); i += 8 {
+			binary.LittleEndian.PutUint64(s.buf[i:], s.r.Uint64())
+		}
+		s.pos = 0
+	}
+	return s.buf[s.pos:]
+}
+
+// Close ends the stream early, returning its generator to the pool it came from, later reads
+// return io.EOF. It always returns nil
+func (s *RandomStream) Close() error {
+	s.remaining = 0
+	s.checkIn()
+	return nil
+}
+
+// checkIn returns an exhausted stream's generator to the pool it came from
+func (s *RandomStream) checkIn() {
+	if s.remaining == 0 && s.rngPool != nil {
+		s.rngPool.Put(s.r)
+		s.rngPool, s.r = nil, nil
+	}
+}
+
+// ThrottledStream is an endless stream of random bytes whose Read limits throughput to a fixed
+// rate, for bandwidth controlled load generation. It is a token bucket holding at most a chunk,
+// or a second's worth at low rates, so a consumer that falls behind, eg a congested connection
+// pushing back, doesn't get a burst to catch up with afterwards. NOT threadsafe.
+type ThrottledStream struct {
+	stream      *RandomStream
+	bytesPerSec float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	now         func() time.Time
+	sleep       func(time.Duration)
+}
+
+// NewThrottledStreamRNG returns a stream of random bytes from a thread unsafe RNG, which the stream
+// owns, limited to bytesPerSec. It panics unless bytesPerSec > 0
+func NewThrottledStreamRNG(r UnsafeRNG, bytesPerSec float64) *ThrottledStream {
+	if !(bytesPerSec > 0) {
+		panic("ThrottledStream bytesPerSec must be > 0")
+	}
+	return &ThrottledStream{
+		stream:      NewStreamRNG(r, math.MaxInt64),
+		bytesPerSec: bytesPerSec,
+		burst:       math.Max(1, math.Min(writeRandomChunk, bytesPerSec)),
+		last:        time.Now(),
+		now:         time.Now,
+		sleep:       time.Sleep,
+	}
+}
+
+// NewThrottledStream returns a randomly seeded stream of random bytes limited to bytesPerSec, it
+// panics unless bytesPerSec > 0
+func NewThrottledStream(bytesPerSec float64) *ThrottledStream {
+	return NewThrottledStreamRNG(NewUnsafeXoshiro256ssRNG(time.Now().UnixNano()), bytesPerSec)
+}
+
+// Read blocks until it can read at least one byte within the rate, and reads up to len(p), it
+// never returns an error
+func (s *ThrottledStream) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	s.refill()
+	if want := math.Min(float64(len(p)), s.burst); s.tokens < want {
+		s.sleep(time.Duration((want - s.tokens) / s.bytesPerSec * float64(time.Second)))
+		s.refill()
+	}
+	n := int(math.Min(s.tokens, float64(len(p))))
+	if n < 1 {
+		// a coarse clock woke us early, borrow from the next refill
+		n = 1
+	}
+	n, _ = s.stream.Read(p[:n])
+	s.tokens -= float64(n)
+	return n, nil
+}
+
+func (s *ThrottledStream) refill() {
+	now := s.now()
+	s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.bytesPerSec)
+	s.last = now
+}
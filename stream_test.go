@@ -0,0 +1,161 @@
+package fastrand64
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteRandom(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteRandom(&buf, NewUnsafeXoshiro256ssRNG(1), 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100000), n)
+	assert.Equal(t, 100000, buf.Len())
+
+	// a short write is streamed from the same generator as Bytes
+	var small bytes.Buffer
+	_, err = WriteRandom(&small, NewUnsafeXoshiro256ssRNG(1), 24)
+	assert.NoError(t, err)
+	assert.Equal(t, Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 24)), small.Bytes())
+
+	n, err = WriteRandom(&small, NewUnsafeXoshiro256ssRNG(1), -1)
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), n)
+	_, err = NewSyncPoolXoshiro256ssRNG().WriteRandom(&small, -1)
+	assert.Error(t, err)
+}
+
+type failingWriter struct{ limit int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		return w.limit, errors.New("disk full")
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func Test_SafeRNG_WriteRandom_Error(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	n, err := rng.WriteRandom(&failingWriter{limit: 40000}, 100000)
+	assert.Error(t, err)
+	assert.Equal(t, int64(40000), n)
+}
+
+func Test_RandomStream(t *testing.T) {
+	expected := Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 100003))
+
+	// io.Copy uses WriteTo
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), 100003))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100003), n)
+	assert.Equal(t, expected, buf.Bytes())
+
+	// odd sized reads give the same bytes
+	stream := NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), 100003)
+	var read []byte
+	p := make([]byte, 777)
+	for {
+		m, err := stream.Read(p)
+		read = append(read, p[:m]...)
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, expected, read)
+	assert.Equal(t, int64(0), stream.Len())
+
+	// and so does mixing the two
+	stream = NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), 100003)
+	head := make([]byte, 5)
+	_, err = stream.Read(head)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(99998), stream.Len())
+	var tail bytes.Buffer
+	_, err = stream.WriteTo(&tail)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, append(head, tail.Bytes()...))
+
+	empty, err := ioutil.ReadAll(NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), 0))
+	assert.NoError(t, err)
+	assert.Len(t, empty, 0)
+}
+
+func Test_SafeRNG_NewStream(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	n, err := io.Copy(ioutil.Discard, rng.NewStream(1<<20))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1<<20), n)
+
+	stream := rng.NewStream(100000)
+	n, err = stream.WriteTo(&failingWriter{limit: 40000})
+	assert.Error(t, err)
+	assert.Equal(t, int64(40000), n)
+	assert.Equal(t, int64(60000), stream.Len())
+
+	// closing early checks the generator back in
+	assert.NotNil(t, stream.r)
+	assert.NoError(t, stream.Close())
+	assert.Nil(t, stream.r)
+	assert.Nil(t, stream.rngPool)
+	m, err := stream.Read(make([]byte, 10))
+	assert.Equal(t, 0, m)
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, stream.Close())
+
+	assert.Panics(t, func() { rng.NewStream(-1) })
+	assert.Panics(t, func() { NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), -1) })
+	var _ io.ReadCloser = stream
+}
+
+func Benchmark_RandomStream_Copy_16MB(b *testing.B) {
+	b.SetBytes(16 << 20)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		io.Copy(ioutil.Discard, NewStreamRNG(NewUnsafeXoshiro256ssRNG(1), 16<<20))
+	}
+}
+
+func Test_ThrottledStream(t *testing.T) {
+	stream := NewThrottledStreamRNG(NewUnsafeXoshiro256ssRNG(1), 1000)
+	clock := stream.last
+	slept := time.Duration(0)
+	stream.now = func() time.Time { return clock }
+	stream.sleep = func(d time.Duration) {
+		slept += d
+		clock = clock.Add(d)
+	}
+
+	// 10000 bytes at 1000 per second take 10 seconds, and are the same bytes as an unthrottled stream
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, stream, 10000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), n)
+	assert.InDelta(t, 10, slept.Seconds(), 0.01)
+	assert.Equal(t, Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 10000)), buf.Bytes())
+
+	// an idle consumer banks at most a second's worth
+	clock = clock.Add(time.Minute)
+	slept = 0
+	_, err = io.CopyN(ioutil.Discard, stream, 3000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2, slept.Seconds(), 0.01)
+
+	assert.Panics(t, func() { NewThrottledStream(0) })
+}
+
+func Test_ThrottledStream_RealClock(t *testing.T) {
+	start := time.Now()
+	n, err := io.CopyN(ioutil.Discard, NewThrottledStream(1<<20), 100<<10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100<<10), n)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(90*time.Millisecond))
+}
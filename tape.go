@@ -0,0 +1,88 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// TapeRNG serves Uint64s from a tape of pregenerated randomness, wrapping around at the end, so
+// Go, Python and C++ implementations of one experiment can share the exact same random numbers.
+// The tape is raw little endian uint64 words, eg numpy's ndarray.astype('<u8').tofile, or
+// WriteRandom(w, r, 8*n) for n words of r. It is unsafe to call UnsafeRNG methods from concurrent
+// goroutines, use Clone to give each goroutine its own position on a shared tape.
+type TapeRNG struct {
+	data   []byte
+	pos    int
+	unmap  func() error
+	shared bool
+}
+
+// NewTapeRNG serves a tape held in memory, data is not copied
+func NewTapeRNG(data []byte) (*TapeRNG, error) {
+	if len(data) == 0 || len(data)%8 != 0 {
+		return nil, errors.New("random tape must be a non empty whole number of uint64 words")
+	}
+	return &TapeRNG{data: data}, nil
+}
+
+// OpenTapeRNG memory maps a tape file where the platform supports it, and reads it otherwise.
+// Close releases the mapping.
+func OpenTapeRNG(path string) (*TapeRNG, error) {
+	data, unmap, err := mapTapeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTapeRNG(data)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	t.unmap = unmap
+	return t, nil
+}
+
+// Uint64 returns the next word of the tape, (not thread safe)
+func (t *TapeRNG) Uint64() uint64 {
+	x := binary.LittleEndian.Uint64(t.data[t.pos:])
+	t.pos += 8
+	if t.pos == len(t.data) {
+		t.pos = 0
+	}
+	return x
+}
+
+// Len returns the number of words on the tape
+func (t *TapeRNG) Len() int {
+	return len(t.data) / 8
+}
+
+// Pos returns the index of the word the next Uint64 returns
+func (t *TapeRNG) Pos() int {
+	return t.pos / 8
+}
+
+// Seek moves to word i of the tape, modulo its length
+func (t *TapeRNG) Seek(i int) {
+	i %= t.Len()
+	if i < 0 {
+		i += t.Len()
+	}
+	t.pos = 8 * i
+}
+
+// Clone returns a reader of the same tape at the same position. Clones must not be used after
+// the original is closed.
+func (t *TapeRNG) Clone() *TapeRNG {
+	return &TapeRNG{data: t.data, pos: t.pos, shared: true}
+}
+
+// Close unmaps a tape opened by OpenTapeRNG, it is a no op for clones and in memory tapes
+func (t *TapeRNG) Close() error {
+	if t.unmap == nil || t.shared {
+		return nil
+	}
+	err := t.unmap()
+	t.unmap = nil
+	t.data = nil
+	return err
+}
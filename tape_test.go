@@ -0,0 +1,61 @@
+package fastrand64
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TapeRNG(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tape")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a tape written by WriteRandom replays the generator, then wraps around
+	path := filepath.Join(dir, "tape.bin")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	// past WriteRandom's first 32KB chunk, 4096 words, so the chunks must join up
+	_, err = WriteRandom(f, NewUnsafeXoshiro256ssRNG(1), 8*5000)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	tape, err := OpenTapeRNG(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, tape.Len())
+	for round := 0; round < 2; round++ {
+		ref := NewUnsafeXoshiro256ssRNG(1)
+		for i := 0; i < 5000; i++ {
+			assert.Equal(t, ref.Uint64(), tape.Uint64())
+		}
+	}
+
+	tape.Seek(-1)
+	assert.Equal(t, 4999, tape.Pos())
+	clone := tape.Clone()
+	assert.Equal(t, tape.Uint64(), clone.Uint64())
+	assert.Equal(t, 0, tape.Pos())
+	assert.NoError(t, clone.Close())
+	assert.NoError(t, tape.Close())
+	assert.NoError(t, tape.Close())
+
+	// a pool of clones gives every goroutine its own reader of the same tape
+	mem, err := NewTapeRNG([]byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0})
+	assert.NoError(t, err)
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return mem.Clone() })
+	for i := 0; i < 8; i++ {
+		assert.Contains(t, []uint64{1, 2}, rng.Uint64())
+	}
+}
+
+func Test_TapeRNG_Errors(t *testing.T) {
+	_, err := NewTapeRNG(nil)
+	assert.Error(t, err)
+	_, err = NewTapeRNG(make([]byte, 12))
+	assert.Error(t, err)
+	_, err = OpenTapeRNG(filepath.Join(os.TempDir(), "fastrand64-no-such-tape"))
+	assert.Error(t, err)
+}
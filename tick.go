@@ -0,0 +1,111 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// TickRNG is a generator for lockstep and rollback netcode, where every client must draw the same
+// random outcomes for the same simulation tick. Tick t's stream is xoshiro256** seeded from
+// master.Child(t), so a tick's draws depend only on the shared master sequence and the tick
+// number, never on how many values earlier ticks drew. A fixed budget of draws per tick turns a
+// client drawing more than the others into a panic at the tick it diverged, instead of a desync
+// found much later, and bounds the cost of RestoreTick. It is unsafe to call UnsafeRNG methods
+// from concurrent goroutines.
+type TickRNG struct {
+	master       SeedSeq
+	drawsPerTick uint64
+	tick         uint64
+	draws        uint64
+	rng          *UnsafeXoshiro256ssRNG
+}
+
+// TickReplayLimit bounds the draws RestoreTick replays for a TickRNG with no budget, about a tenth
+// of a second, so a snapshot from an untrusted peer can't stall the process
+const TickReplayLimit = 1 << 24
+
+// TickSnapshot is the position of a TickRNG, to roll it back to with RestoreTick. It holds no
+// generator state, only the tick and the draws made in it, so it is small enough to send over the
+// network with MarshalBinary and compare between clients to detect a desync
+type TickSnapshot struct {
+	Tick  uint64
+	Draws uint64
+}
+
+// NewTickRNG makes a generator at the start of tick 0. drawsPerTick is the budget of Uint64 draws
+// in a tick, 0 means no limit, though RestoreTick then replays at most TickReplayLimit
+func NewTickRNG(master SeedSeq, drawsPerTick uint64) *TickRNG {
+	t := &TickRNG{master: master, drawsPerTick: drawsPerTick}
+	t.SetTick(0)
+	return t
+}
+
+// Uint64 draws from the current tick's stream, it panics when the tick's budget is spent, (not thread safe)
+func (t *TickRNG) Uint64() uint64 {
+	if t.drawsPerTick > 0 && t.draws >= t.drawsPerTick {
+		panic(fmt.Sprintf("TickRNG drew more than %d values in tick %d", t.drawsPerTick, t.tick))
+	}
+	t.draws++
+	return t.rng.Uint64()
+}
+
+// NextTick moves to the start of the next tick
+func (t *TickRNG) NextTick() {
+	t.SetTick(t.tick + 1)
+}
+
+// SetTick moves to the start of tick, eg to join a match in progress
+func (t *TickRNG) SetTick(tick uint64) {
+	t.tick, t.draws = tick, 0
+	t.rng = t.master.Child(tick).NewXoshiro256ssRNG()
+}
+
+// Tick returns the current tick
+func (t *TickRNG) Tick() uint64 {
+	return t.tick
+}
+
+// Draws returns how many values have been drawn in the current tick
+func (t *TickRNG) Draws() uint64 {
+	return t.draws
+}
+
+// SnapshotTick returns the current position, see RestoreTick
+func (t *TickRNG) SnapshotTick() TickSnapshot {
+	return TickSnapshot{Tick: t.tick, Draws: t.draws}
+}
+
+// RestoreTick rolls the generator back, or forward, to a snapshot, after which it draws exactly
+// what it drew after the snapshot was taken. It replays the snapshot's draws within its tick, at
+// most the budget, or TickReplayLimit without one. A snapshot with more draws returns an error
+func (t *TickRNG) RestoreTick(snap TickSnapshot) error {
+	if t.drawsPerTick > 0 && snap.Draws > t.drawsPerTick {
+		return fmt.Errorf("tick snapshot has %d draws, the budget is %d per tick", snap.Draws, t.drawsPerTick)
+	}
+	if t.drawsPerTick == 0 && snap.Draws > TickReplayLimit {
+		return fmt.Errorf("tick snapshot has %d draws, more than the %d a TickRNG without a budget replays", snap.Draws, TickReplayLimit)
+	}
+	t.SetTick(snap.Tick)
+	for ; t.draws < snap.Draws; t.draws++ {
+		t.rng.Uint64()
+	}
+	return nil
+}
+
+// MarshalBinary encodes the snapshot in 16 bytes
+func (s TickSnapshot) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, s.Tick)
+	binary.LittleEndian.PutUint64(data[8:], s.Draws)
+	return data, nil
+}
+
+// UnmarshalBinary decodes a snapshot encoded by MarshalBinary
+func (s *TickSnapshot) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("tick snapshot needs 16 bytes, got %d", len(data))
+	}
+	s.Tick = binary.LittleEndian.Uint64(data)
+	s.Draws = binary.LittleEndian.Uint64(data[8:])
+	return nil
+}
@@ -0,0 +1,67 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TickRNG(t *testing.T) {
+	master := NewSeedSeq([]byte("match 17"))
+	a, b := NewTickRNG(master, 8), NewTickRNG(master, 8)
+
+	// tick 0 draws differently on each client, tick 1 still agrees
+	assert.Equal(t, a.Uint64(), b.Uint64())
+	a.Uint64()
+	assert.Equal(t, uint64(2), a.Draws())
+	a.NextTick()
+	b.NextTick()
+	assert.Equal(t, uint64(1), a.Tick())
+	assert.Equal(t, uint64(0), a.Draws())
+	assert.Equal(t, master.Child(1).NewXoshiro256ssRNG().Uint64(), a.Uint64())
+	assert.Equal(t, TickSnapshot{Tick: 1, Draws: 1}, a.SnapshotTick())
+
+	// rollback replays exactly
+	snap := a.SnapshotTick()
+	want := []uint64{a.Uint64(), a.Uint64()}
+	a.NextTick()
+	a.Uint64()
+	assert.NoError(t, a.RestoreTick(snap))
+	assert.Equal(t, snap, a.SnapshotTick())
+	assert.Equal(t, want, []uint64{a.Uint64(), a.Uint64()})
+
+	// and a snapshot restores on another client too
+	assert.NoError(t, b.RestoreTick(snap))
+	assert.Equal(t, want[0], b.Uint64())
+
+	// the budget
+	for a.Draws() < 8 {
+		a.Uint64()
+	}
+	assert.Panics(t, func() { a.Uint64() })
+	assert.Error(t, a.RestoreTick(TickSnapshot{Tick: 3, Draws: 9}))
+	a.SetTick(3)
+	assert.Equal(t, master.Child(3).NewXoshiro256ssRNG().Uint64(), a.Uint64())
+
+	unlimited := NewTickRNG(master, 0)
+	for i := 0; i < 100; i++ {
+		unlimited.Uint64()
+	}
+	assert.NoError(t, unlimited.RestoreTick(TickSnapshot{Tick: 2, Draws: 1000}))
+	// a hostile snapshot is refused rather than replayed for ever
+	assert.Error(t, unlimited.RestoreTick(TickSnapshot{Tick: 2, Draws: 1 << 63}))
+	assert.Error(t, unlimited.RestoreTick(TickSnapshot{Tick: 2, Draws: TickReplayLimit + 1}))
+	assert.Equal(t, uint64(2), unlimited.Tick())
+	assert.Equal(t, uint64(1000), unlimited.Draws())
+}
+
+func Test_TickSnapshot_MarshalBinary(t *testing.T) {
+	snap := TickSnapshot{Tick: 123456789, Draws: 42}
+	data, err := snap.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 16)
+	var back TickSnapshot
+	assert.NoError(t, back.UnmarshalBinary(data))
+	assert.Equal(t, snap, back)
+	assert.Error(t, back.UnmarshalBinary(data[:15]))
+}
@@ -0,0 +1,75 @@
+package fastrand64
+
+// AccessTraceOptions configures an AccessTrace
+type AccessTraceOptions struct {
+	// NewKeyProbability is the chance each access is a never before seen key (a cold miss)
+	NewKeyProbability float64
+	// LocalitySkew > 1 is the Zipf exponent of the LRU stack distance of reuses,
+	// larger values mean stronger temporal locality
+	LocalitySkew float64
+	// MaxStackDepth bounds how far back a reuse can reach, ie the working set remembered
+	MaxStackDepth int
+}
+
+// AccessTrace generates cache key access sequences with tunable temporal locality using the
+// LRU stack model: each access either introduces a new key or reuses the key at a Zipf distributed
+// depth in the recency stack. Randomness comes from the pool, but the trace itself is stateful,
+// so it is NOT safe for concurrent use.
+type AccessTrace struct {
+	rng      *ThreadsafePoolRNG
+	opts     AccessTraceOptions
+	distance *zipf
+	stack    []uint64 // most recent first
+	nextKey  uint64
+}
+
+// NewAccessTrace returns a trace generator, it panics if LocalitySkew <= 1 or MaxStackDepth < 1
+func (s *ThreadsafePoolRNG) NewAccessTrace(opts AccessTraceOptions) *AccessTrace {
+	if opts.MaxStackDepth < 1 {
+		panic("AccessTrace MaxStackDepth must be >= 1")
+	}
+	z := newZipf(opts.LocalitySkew, 1, uint64(opts.MaxStackDepth-1))
+	if z == nil {
+		panic("AccessTrace LocalitySkew must be > 1")
+	}
+	return &AccessTrace{rng: s, opts: opts, distance: z}
+}
+
+// Next returns the next key accessed
+func (a *AccessTrace) Next() uint64 {
+	var key [1]uint64
+	a.Fill(key[:])
+	return key[0]
+}
+
+// Fill fills dst with the next len(dst) accesses using a single pool checkout
+func (a *AccessTrace) Fill(dst []uint64) []uint64 {
+	rngPool := a.rng.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	for i := range dst {
+		dst[i] = a.access(r)
+	}
+	rngPool.Put(r)
+	return dst
+}
+
+func (a *AccessTrace) access(r UnsafeRNG) uint64 {
+	d := -1
+	if len(a.stack) > 0 && unitFloat64(r) >= a.opts.NewKeyProbability {
+		d = int(a.distance.sample(r))
+	}
+	if d < 0 || d >= len(a.stack) {
+		key := a.nextKey
+		a.nextKey++
+		if len(a.stack) < a.opts.MaxStackDepth {
+			a.stack = append(a.stack, 0)
+		}
+		copy(a.stack[1:], a.stack)
+		a.stack[0] = key
+		return key
+	}
+	key := a.stack[d]
+	copy(a.stack[1:d+1], a.stack[:d])
+	a.stack[0] = key
+	return key
+}
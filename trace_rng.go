@@ -0,0 +1,54 @@
+package fastrand64
+
+import "sync/atomic"
+
+// Draw is the provenance of one Uint64 drawn through a TracingRNG
+type Draw struct {
+	Stream uint64 // which generator it came from
+	Seq    uint64 // 1 for the first draw of the stream, increasing by 1 per draw
+	Value  uint64
+}
+
+// TracingRNG is an opt in UnsafeRNG wrapper that numbers every draw, so a simulation can log the
+// provenance of each stochastic decision for audits. It is unsafe to call UnsafeRNG methods from
+// concurrent goroutines.
+type TracingRNG struct {
+	rng    UnsafeRNG
+	stream uint64
+	seq    uint64
+	hook   func(Draw)
+}
+
+// NewTracingRNG wraps r as stream, hook is called with every draw and may be nil
+func NewTracingRNG(stream uint64, r UnsafeRNG, hook func(Draw)) *TracingRNG {
+	return &TracingRNG{rng: r, stream: stream, hook: hook}
+}
+
+// Uint64 draws from the wrapped generator and records it, (not thread safe)
+func (t *TracingRNG) Uint64() uint64 {
+	x := t.rng.Uint64()
+	t.seq++
+	if t.hook != nil {
+		t.hook(Draw{Stream: t.stream, Seq: t.seq, Value: x})
+	}
+	return x
+}
+
+// Stream returns the stream ID
+func (t *TracingRNG) Stream() uint64 {
+	return t.stream
+}
+
+// Seq returns the sequence number of the latest draw, 0 before the first
+func (t *TracingRNG) Seq() uint64 {
+	return t.seq
+}
+
+// NewSyncPoolTracingRNG wraps every generator the pool allocates in a TracingRNG with the next
+// stream ID, starting at 0. hook is called concurrently from all of them, so it must be threadsafe
+func NewSyncPoolTracingRNG(fn func() UnsafeRNG, hook func(Draw)) *ThreadsafePoolRNG {
+	var next uint64
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		return NewTracingRNG(atomic.AddUint64(&next, 1)-1, fn(), hook)
+	})
+}
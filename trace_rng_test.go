@@ -0,0 +1,66 @@
+package fastrand64
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TracingRNG(t *testing.T) {
+	var draws []Draw
+	rng := NewTracingRNG(7, NewUnsafeXoshiro256ssRNG(1), func(d Draw) { draws = append(draws, d) })
+	ref := NewUnsafeXoshiro256ssRNG(1)
+	assert.Equal(t, uint64(0), rng.Seq())
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, ref.Uint64(), rng.Uint64())
+		assert.Equal(t, uint64(i), rng.Seq())
+	}
+	assert.Equal(t, uint64(7), rng.Stream())
+	assert.Len(t, draws, 4)
+	for i, d := range draws {
+		assert.Equal(t, uint64(7), d.Stream)
+		assert.Equal(t, uint64(i+1), d.Seq)
+	}
+
+	// a decision built from several draws logs all of them
+	before := rng.Seq()
+	GaussianNoise(rng, make([]float64, 2), 1)
+	assert.Greater(t, rng.Seq(), before)
+	assert.Len(t, draws, int(rng.Seq()))
+
+	assert.NotPanics(t, func() { NewTracingRNG(0, ref, nil).Uint64() })
+}
+
+func Test_SafeRNG_Tracing(t *testing.T) {
+	var mu sync.Mutex
+	lastSeq := map[uint64]uint64{}
+	monotonic := true
+	rng := NewSyncPoolTracingRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, func(d Draw) {
+		mu.Lock()
+		if d.Seq != lastSeq[d.Stream]+1 {
+			monotonic = false
+		}
+		lastSeq[d.Stream] = d.Seq
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			for i := 0; i < 1000; i++ {
+				rng.Uint64()
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	total := uint64(0)
+	for _, seq := range lastSeq {
+		total += seq
+	}
+	assert.True(t, monotonic)
+	assert.Equal(t, uint64(8000), total)
+}
@@ -0,0 +1,54 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lruHitRatio replays a trace through an LRU cache of the given size
+func lruHitRatio(trace []uint64, size int) float64 {
+	var lru []uint64
+	hits := 0
+	for _, key := range trace {
+		found := -1
+		for i, k := range lru {
+			if k == key {
+				found = i
+				break
+			}
+		}
+		if found >= 0 {
+			hits++
+			lru = append(lru[:found], lru[found+1:]...)
+		} else if len(lru) == size {
+			lru = lru[:size-1]
+		}
+		lru = append([]uint64{key}, lru...)
+	}
+	return float64(hits) / float64(len(trace))
+}
+
+func Test_AccessTrace_Locality(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	weak := rng.NewAccessTrace(AccessTraceOptions{NewKeyProbability: 0.05, LocalitySkew: 1.1, MaxStackDepth: 1000})
+	strong := rng.NewAccessTrace(AccessTraceOptions{NewKeyProbability: 0.05, LocalitySkew: 2.5, MaxStackDepth: 1000})
+
+	weakTrace := weak.Fill(make([]uint64, 20000))
+	strongTrace := strong.Fill(make([]uint64, 20000))
+
+	// stronger locality means a small cache catches more
+	assert.Greater(t, lruHitRatio(strongTrace, 16), lruHitRatio(weakTrace, 16)+0.1)
+	// every access is a reuse or a new key, so hit ratio with an unbounded cache is ~1-p
+	assert.InDelta(t, 0.95, lruHitRatio(strongTrace, 1000), 0.02)
+}
+
+func Test_AccessTrace_NewKeys(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	trace := rng.NewAccessTrace(AccessTraceOptions{NewKeyProbability: 1, LocalitySkew: 2, MaxStackDepth: 4})
+	for i := uint64(0); i < 10; i++ {
+		assert.Equal(t, i, trace.Next())
+	}
+	assert.Panics(t, func() { rng.NewAccessTrace(AccessTraceOptions{LocalitySkew: 1, MaxStackDepth: 4}) })
+	assert.Panics(t, func() { rng.NewAccessTrace(AccessTraceOptions{LocalitySkew: 2}) })
+}
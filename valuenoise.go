@@ -0,0 +1,86 @@
+package fastrand64
+
+import "math"
+
+// FBMOptions shapes fractal Brownian motion, a sum of octaves of noise at rising frequencies and
+// falling amplitudes. Zero fields take the defaults
+type FBMOptions struct {
+	Octaves    int     // layers of noise, default 1
+	Frequency  float64 // lattice cells per unit of the first octave, default 1
+	Lacunarity float64 // frequency multiplier per octave, default 2
+	Gain       float64 // amplitude multiplier per octave, the persistence, default 0.5
+}
+
+func (o FBMOptions) withDefaults() FBMOptions {
+	if o.Octaves <= 0 {
+		o.Octaves = 1
+	}
+	if o.Frequency == 0 {
+		o.Frequency = 1
+	}
+	if o.Lacunarity == 0 {
+		o.Lacunarity = 2
+	}
+	if o.Gain == 0 {
+		o.Gain = 0.5
+	}
+	return o
+}
+
+// ValueNoise2D returns fBm value noise in [-1, 1] at (x, y), with octaves doubling in frequency
+// and halving in amplitude, eg for terrain heights. The lattice values come from key, so the same
+// key always gives the same terrain, and World(seed).Feature("height") style keys give each
+// feature of a world its own. See ValueNoise2DFBM for the other options
+func ValueNoise2D(key WorldSeed, x float64, y float64, octaves int) float64 {
+	return ValueNoise2DFBM(key, x, y, FBMOptions{Octaves: octaves})
+}
+
+// ValueNoise2DFBM returns fBm value noise in [-1, 1] at (x, y) shaped by opts
+func ValueNoise2DFBM(key WorldSeed, x float64, y float64, opts FBMOptions) float64 {
+	opts = opts.withDefaults()
+	sum, norm := 0.0, 0.0
+	freq, amp := opts.Frequency, 1.0
+	for octave := 0; octave < opts.Octaves; octave++ {
+		// each octave has its own lattice, so their cells don't line up at the origin
+		sum += amp * valueNoise(key.Child(uint64(octave)), x*freq, y*freq)
+		norm += amp
+		freq *= opts.Lacunarity
+		amp *= opts.Gain
+	}
+	return sum / norm
+}
+
+// NoiseChunk fills dst, a width wide row major grid, with ValueNoise2DFBM at the points
+// (x0 + i*step, y0 + j*step), eg the height map of one terrain chunk. Chunks of the same key meet
+// seamlessly, each point is exactly the value ValueNoise2DFBM gives it
+func NoiseChunk(dst []float64, width int, key WorldSeed, x0 float64, y0 float64, step float64, opts FBMOptions) []float64 {
+	for k := range dst {
+		i, j := k%width, k/width
+		dst[k] = ValueNoise2DFBM(key, x0+float64(i)*step, y0+float64(j)*step, opts)
+	}
+	return dst
+}
+
+// valueNoise interpolates the random values at the corners of the lattice cell holding (x, y)
+// with the quintic fade, so the noise and its first two derivatives are continuous
+func valueNoise(key WorldSeed, x float64, y float64) float64 {
+	fx, fy := math.Floor(x), math.Floor(y)
+	ix, iy := int64(fx), int64(fy)
+	tx, ty := noiseFade(x-fx), noiseFade(y-fy)
+	v00 := latticeValue(key, ix, iy)
+	v10 := latticeValue(key, ix+1, iy)
+	v01 := latticeValue(key, ix, iy+1)
+	v11 := latticeValue(key, ix+1, iy+1)
+	top := v00 + tx*(v10-v00)
+	bottom := v01 + tx*(v11-v01)
+	return top + ty*(bottom-top)
+}
+
+// latticeValue is the random value in [-1, 1) at a lattice point
+func latticeValue(key WorldSeed, ix int64, iy int64) float64 {
+	return float64(key.Region(ix, iy).Uint64()>>11)*(1.0/(1<<52)) - 1
+}
+
+func noiseFade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
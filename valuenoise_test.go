@@ -0,0 +1,52 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValueNoise2D(t *testing.T) {
+	key := World(7).Feature("height")
+	assert.Equal(t, ValueNoise2D(key, 1.25, -3.5, 4), ValueNoise2D(World(7).Feature("height"), 1.25, -3.5, 4))
+	assert.NotEqual(t, ValueNoise2D(key, 1.25, -3.5, 4), ValueNoise2D(World(8).Feature("height"), 1.25, -3.5, 4))
+
+	// one octave passes through the lattice values
+	assert.Equal(t, latticeValue(key.Child(0), 3, -2), ValueNoise2D(key, 3, -2, 1))
+
+	var xs []float64
+	for i := 0; i < 20000; i++ {
+		x, y := float64(i%200)*0.37-30, float64(i/200)*0.41-20
+		v := ValueNoise2D(key, x, y, 5)
+		assert.True(t, v >= -1 && v <= 1, v)
+		xs = append(xs, v)
+		// continuous, a tiny step makes a tiny change
+		assert.InDelta(t, v, ValueNoise2D(key, x+1e-6, y, 5), 1e-4)
+	}
+	mean, variance := meanAndVariance(xs)
+	assert.InDelta(t, 0, mean, 0.05)
+	assert.Greater(t, variance, 0.02)
+
+	// the options change the shape
+	opts := FBMOptions{Octaves: 3, Frequency: 0.1, Lacunarity: 3, Gain: 0.4}
+	assert.NotEqual(t, ValueNoise2D(key, 1.25, 2.5, 3), ValueNoise2DFBM(key, 1.25, 2.5, opts))
+	assert.Equal(t, ValueNoise2D(key, 1.25, 2.5, 0), ValueNoise2DFBM(key, 1.25, 2.5, FBMOptions{}))
+	assert.False(t, math.IsNaN(ValueNoise2DFBM(key, -1e9, 1e9, opts)))
+}
+
+func Test_NoiseChunk(t *testing.T) {
+	key := World(7).Feature("height")
+	opts := FBMOptions{Octaves: 4, Frequency: 1.0 / 16}
+	size, step := 16, 1.0
+	left := NoiseChunk(make([]float64, size*size), size, key, 0, 0, step, opts)
+	right := NoiseChunk(make([]float64, size*size), size, key, float64(size), 0, step, opts)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			assert.Equal(t, ValueNoise2DFBM(key, float64(i), float64(j), opts), left[j*size+i])
+		}
+		// neighbouring chunks meet smoothly
+		assert.InDelta(t, left[j*size+size-1], right[j*size], 0.25)
+		assert.Equal(t, ValueNoise2DFBM(key, float64(size), float64(j), opts), right[j*size])
+	}
+}
@@ -0,0 +1,59 @@
+package fastrand64
+
+// WorldSeed is a node in a tree of seeds for procedural generation, World(seed).Region(x, y) for a
+// map cell and .Feature("caves") for one layer of it, so any coordinate's generator can be
+// derived directly, in any order, without storing state. Each step hashes the parent's key with
+// the step through splitmix64, which is cheap enough to do per cell. It is a value, safe to copy
+// and share between goroutines. These keys are stable, changing how they are derived would change
+// every world, so it won't be done.
+type WorldSeed struct {
+	key uint64
+}
+
+// the domain tags keep the kinds of steps apart, so Region(1, 2) is unrelated to Child(1).Child(2)
+const (
+	worldTagRegion  = 0x7265676e00000000
+	worldTagFeature = 0x6665617400000000
+	worldTagChild   = 0x6368696c00000000
+)
+
+// World is the root of a world's seed tree
+func World(seed int64) WorldSeed {
+	return WorldSeed{key: Splitmix64(uint64(seed))}
+}
+
+// Region returns the seed of the cell at (x, y), eg a chunk of the map
+func (w WorldSeed) Region(x int64, y int64) WorldSeed {
+	h := Splitmix64(w.key ^ worldTagRegion)
+	h = Splitmix64(h ^ uint64(x))
+	return WorldSeed{key: Splitmix64(h ^ uint64(y))}
+}
+
+// Feature returns the seed of a named layer, eg "caves" or "rivers", so adding a feature never
+// changes the others
+func (w WorldSeed) Feature(name string) WorldSeed {
+	h := Splitmix64(w.key ^ worldTagFeature)
+	return WorldSeed{key: Splitmix64(h ^ HashKey([]byte(name)))}
+}
+
+// Child returns the i-th numbered seed, eg the i-th tree of a region
+func (w WorldSeed) Child(i uint64) WorldSeed {
+	h := Splitmix64(w.key ^ worldTagChild)
+	return WorldSeed{key: Splitmix64(h ^ i)}
+}
+
+// Uint64 returns the node's own random value, for a single decision like whether a cell has a
+// tree, without a generator. It is the first value of RNG
+func (w WorldSeed) Uint64() uint64 {
+	return Splitmix64(w.key)
+}
+
+// Seed returns the node as a seed for the New...RNG(seed int64) constructors
+func (w WorldSeed) Seed() int64 {
+	return int64(w.key)
+}
+
+// RNG returns a thread unsafe splitmix64 generator for the node, for as many draws as it needs
+func (w WorldSeed) RNG() *UnsafeSplitmix64RNG {
+	return &UnsafeSplitmix64RNG{state: w.key}
+}
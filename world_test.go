@@ -0,0 +1,36 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_World(t *testing.T) {
+	w := World(42)
+	caves := w.Region(3, -7).Feature("caves")
+	assert.Equal(t, caves, World(42).Region(3, -7).Feature("caves"))
+	// pinned, the tree is part of every saved world
+	assert.Equal(t, uint64(0x8706df9629fc7d2c), caves.Uint64())
+	assert.Equal(t, caves.Uint64(), caves.RNG().Uint64())
+	assert.Equal(t, caves.Uint64(), NewUnsafeSplitmix64RNG(caves.Seed()).Uint64())
+
+	// every path is different
+	seen := map[WorldSeed]bool{}
+	for _, node := range []WorldSeed{
+		w, World(43), w.Region(0, 0), w.Region(3, -7), w.Region(-7, 3), w.Region(3, -7).Feature("rivers"),
+		caves, w.Feature("caves").Region(3, -7), w.Child(0), w.Child(1), w.Child(0).Child(0), w.Feature(""),
+	} {
+		assert.False(t, seen[node], node)
+		seen[node] = true
+	}
+
+	// neighbouring cells are unrelated, on average half their bits differ
+	diff := 0
+	n := 1000
+	for x := 0; x < n; x++ {
+		diff += bits.OnesCount64(w.Region(int64(x), 0).Uint64() ^ w.Region(int64(x+1), 0).Uint64())
+	}
+	assert.InDelta(t, 32, float64(diff)/float64(n), 1)
+}
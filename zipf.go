@@ -0,0 +1,91 @@
+package fastrand64
+
+import "math"
+
+// zipf draws from a Zipf distribution, it is a port of math/rand.Zipf that samples from any UnsafeRNG.
+// P(k) is proportional to (v + k) ** (-s), for k in [0, imax], s > 1 and v >= 1
+//
+// See: Hörmann, Derflinger: "Rejection-inversion to generate variates from monotone discrete distributions"
+type zipf struct {
+	imax         float64
+	v            float64
+	q            float64
+	s            float64
+	oneminusQ    float64
+	oneminusQinv float64
+	hxm          float64
+	hx0minusHxm  float64
+}
+
+func (z *zipf) h(x float64) float64 {
+	return math.Exp(z.oneminusQ*math.Log(z.v+x)) * z.oneminusQinv
+}
+
+func (z *zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneminusQinv*math.Log(z.oneminusQ*x)) - z.v
+}
+
+// newZipf returns nil if s <= 1 or v < 1, like math/rand.NewZipf
+func newZipf(s float64, v float64, imax uint64) *zipf {
+	if s <= 1.0 || v < 1 {
+		return nil
+	}
+	z := &zipf{imax: float64(imax), v: v, q: s}
+	z.oneminusQ = 1.0 - z.q
+	z.oneminusQinv = 1.0 / z.oneminusQ
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0minusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1.0)))
+	return z
+}
+
+func (z *zipf) sample(r UnsafeRNG) uint64 {
+	k := 0.0
+	for {
+		ur := z.hxm + unitFloat64(r)*z.hx0minusHxm
+		x := z.hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s {
+			break
+		}
+		if ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+	return uint64(k)
+}
+
+// Zipf is a thread safe Zipf generator with the parameters and distribution of math/rand.Zipf,
+// P(k) is proportional to (v + k) ** (-s), for k in [0, imax], so cache and popularity skew
+// workloads can switch to this package and keep their distribution
+type Zipf struct {
+	z   *zipf
+	rng *ThreadsafePoolRNG
+}
+
+// NewZipf returns a Zipf generator drawing from the pool RNG rng, or the Default pool if rng is
+// nil. Like math/rand.NewZipf it returns nil unless s > 1 and v >= 1
+func NewZipf(rng *ThreadsafePoolRNG, s float64, v float64, imax uint64) *Zipf {
+	z := newZipf(s, v, imax)
+	if z == nil {
+		return nil
+	}
+	if rng == nil {
+		rng = defaultRNG()
+	}
+	return &Zipf{z: z, rng: rng}
+}
+
+// Uint64 returns a value drawn from the Zipf distribution using a pool checkout. Threadsafe
+func (z *Zipf) Uint64() uint64 {
+	rngPool := z.rng.pool()
+	r := rngPool.Get().(UnsafeRNG)
+	x := z.z.sample(r)
+	rngPool.Put(r)
+	return x
+}
+
+// Sample returns a value drawn from the Zipf distribution with a thread unsafe RNG instead of the pool
+func (z *Zipf) Sample(r UnsafeRNG) uint64 {
+	return z.z.sample(r)
+}
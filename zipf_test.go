@@ -0,0 +1,57 @@
+package fastrand64
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Zipf(t *testing.T) {
+	const s, v, imax = 1.5, 2.0, 20
+	z := NewZipf(NewSyncPoolXoshiro256ssRNG(), s, v, imax)
+	std := rand.NewZipf(rand.New(rand.NewSource(1)), s, v, imax)
+
+	pmf := make([]float64, imax+1)
+	total := 0.0
+	for k := range pmf {
+		pmf[k] = math.Pow(v+float64(k), -s)
+		total += pmf[k]
+	}
+	const n = 200000
+	counts, stdCounts := make([]float64, imax+1), make([]float64, imax+1)
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < n; i++ {
+		counts[z.Sample(r)]++
+		stdCounts[std.Uint64()]++
+	}
+	// both match the exact pmf, so they match each other
+	for _, c := range [][]float64{counts, stdCounts} {
+		stat := 0.0
+		for k := range pmf {
+			expected := n * pmf[k] / total
+			stat += (c[k] - expected) * (c[k] - expected) / expected
+		}
+		assert.Less(t, stat, imax+4*math.Sqrt(2*imax))
+	}
+
+	assert.Nil(t, NewZipf(nil, 1, 1, 10))
+	assert.Nil(t, NewZipf(nil, 2, 0.5, 10))
+	assert.LessOrEqual(t, NewZipf(nil, 1.1, 1, 5).Uint64(), uint64(5))
+	assert.LessOrEqual(t, z.Uint64(), uint64(imax))
+
+	// thread safe
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				z.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+}